@@ -16,11 +16,38 @@ type AggregateState struct {
 	CloseTime           time.Time
 }
 
+// IsClosed returns true, if the stream of the aggregate is closed at the given time. Streams with a close event in the
+// future are open until their close time.
+func (a AggregateState) IsClosed(at time.Time) bool {
+	return !a.CloseTime.IsZero() && !a.CloseTime.After(at)
+}
+
+type StreamStatus string
+
+const (
+	// OpenStreams filters the aggregates, whose streams are not closed (see AggregateState.IsClosed)
+	OpenStreams StreamStatus = "open"
+	// ClosedStreams filters the aggregates, whose streams are closed (see AggregateState.IsClosed)
+	ClosedStreams StreamStatus = "closed"
+)
+
 type AggregateManagement interface {
 	// GetAggregatesEvents returns all events paginated  defined by the page object, i.e., it searchs and sorts fields
 	GetAggregatesEvents(ctx context.Context, tenantID string, page PageDTO) ([]PersistenceEvent, PagesDTO, error)
 
 	GetAggregateState(ctx context.Context, tenantID, aggregateType, aggregateID string) (AggregateState, error)
-	GetAggregateStatesForAggregateType(ctx context.Context, tenantID string, aggregateType string) ([]AggregateState, error)
-	GetAggregateStatesForAggregateTypeTill(ctx context.Context, tenantID string, aggregateType string, until time.Time) ([]AggregateState, error)
+	// GetAggregateStatesForAggregateType returns the states of all aggregates of the type, or only of the aggregates
+	// with one of the given statuses (as of now).
+	GetAggregateStatesForAggregateType(ctx context.Context, tenantID string, aggregateType string, status ...StreamStatus) ([]AggregateState, error)
+	// GetAggregateStatesForAggregateTypeTill is the counterpart of GetAggregateStatesForAggregateType, whereby the
+	// statuses are as of until.
+	GetAggregateStatesForAggregateTypeTill(ctx context.Context, tenantID string, aggregateType string, until time.Time, status ...StreamStatus) ([]AggregateState, error)
+
+	// CloseAggregate writes a StreamClosed event into the stream, i.e. no events can be written after the valid time
+	// anymore (see ErrorStreamClosed). A zero valid time closes the stream now, other valid times must not be in the
+	// past.
+	CloseAggregate(ctx context.Context, tenantID, aggregateType, aggregateID string, validTime time.Time) error
+	// ReopenAggregate writes a StreamReopened event into the closed stream, i.e. events can be written again. A zero
+	// valid time reopens the stream now, other valid times must not be before the close time.
+	ReopenAggregate(ctx context.Context, tenantID, aggregateType, aggregateID string, validTime time.Time) error
 }
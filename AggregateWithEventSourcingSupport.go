@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"iter"
 	"reflect"
 	"time"
 )
@@ -53,6 +54,11 @@ func SaveAggregates(ctx context.Context, eventStore EventStore, aggregates ...Ag
 	}
 
 	for _, aggregate := range aggregates {
+		var expect Expectation
+		if withExpectation, ok := aggregate.(aggregateWithExpectation); ok {
+			aggregate, expect = withExpectation.AggregateWithEventSourcingSupport, withExpectation.expect
+		}
+
 		// we do not allow storage of aggregates with different tenants in one single save call
 		if tenantID == "" {
 			tenantID = aggregate.GetTenantID()
@@ -62,38 +68,23 @@ func SaveAggregates(ctx context.Context, eventStore EventStore, aggregates ...Ag
 			}
 		}
 
-		aggregateType := reflect.TypeOf(aggregate)
-		if aggregateType.Kind() == reflect.Ptr {
-			aggregateType = aggregateType.Elem()
-		}
+		aggregateType := aggregateTypeName(reflect.TypeOf(aggregate))
 
 		if len(aggregate.GetUnsavedChanges()) > 0 {
 			events := make([]PersistenceEvent, len(aggregate.GetUnsavedChanges()))
 			for i, evt := range aggregate.GetUnsavedChanges() {
-				eventID, _ := uuid.NewUUID()
-				eType := EventType(evt)
 				evt.setUserID(GetUserID(ctx))
-				data, err := SerializeEvent(evt)
+				persistenceEvent, err := NewPersistenceEvent(ctx, evt, aggregateType)
 				if err != nil {
-					return nil, fmt.Errorf("could not serialize action %q: %w", EventType(evt), err)
-				}
-				events[i] = PersistenceEvent{
-					ID:              eventID.String(),
-					AggregateID:     evt.GetAggregateID(),
-					TenantID:        evt.GetTenantID(),
-					AggregateType:   aggregateType.Name(),
-					Type:            eType,
-					ValidTime:       evt.GetValidTime(),
-					TransactionTime: evt.GetTransactionTime(),
-					Data:            data,
-					Class:           evt.GetClass(),
-					FromMigration:   evt.GetMigration(),
+					return nil, err
 				}
+				events[i] = persistenceEvent
 			}
 
 			allEvents = append(allEvents, PersistenceEvents{
 				Events:  events,
 				Version: aggregate.GetVersion(),
+				Expect:  expect,
 			})
 		}
 	}
@@ -101,6 +92,39 @@ func SaveAggregates(ctx context.Context, eventStore EventStore, aggregates ...Ag
 	return eventStore.SaveAll(ctx, tenantID, allEvents)
 }
 
+// aggregateTypeName returns the aggregate type of an aggregate, which is the name of its type (without pointer).
+func aggregateTypeName(aggregateType reflect.Type) string {
+	if aggregateType.Kind() == reflect.Ptr {
+		aggregateType = aggregateType.Elem()
+	}
+	return aggregateType.Name()
+}
+
+// NewPersistenceEvent serializes the event of an aggregate of the aggregate type into a new persistence event.
+func NewPersistenceEvent(ctx context.Context, evt IEvent, aggregateType string) (PersistenceEvent, error) {
+	eventID, _ := uuid.NewUUID()
+	eType := EventType(evt)
+	data, codec, err := SerializeEventWithCodec(evt, WithContext(ctx), WithAggregateType(aggregateType))
+	if err != nil {
+		return PersistenceEvent{}, fmt.Errorf("could not serialize action %q: %w", eType, err)
+	}
+
+	return PersistenceEvent{
+		ID:              eventID.String(),
+		AggregateID:     evt.GetAggregateID(),
+		TenantID:        evt.GetTenantID(),
+		AggregateType:   aggregateType,
+		Type:            eType,
+		ValidTime:       evt.GetValidTime(),
+		TransactionTime: evt.GetTransactionTime(),
+		Data:            data,
+		SchemaVersion:   persistedSchemaVersion(eType),
+		Codec:           codec,
+		Class:           evt.GetClass(),
+		FromMigration:   evt.GetMigration(),
+	}, nil
+}
+
 func LoadAggregateAsAt(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime time.Time, eventStore EventStore) (eventStream []IEvent, version int, err error) {
 	var persistenceEvents []PersistenceEvent
 	persistenceEvents, version, err = eventStore.LoadAsAt(ctx, tenantID, aggregateType, aggregateID, projectionTime)
@@ -221,20 +245,73 @@ func LoadAllAggregatesAsOfTill(ctx context.Context, tenantID string, projectionT
 	return eventStreams, nil
 }
 
+// StreamAllOfAggregateTypeAsAt is the streaming variant of LoadAllOfAggregateTypeAsAt. It deserializes one event
+// stream after the other while iterating.
+func StreamAllOfAggregateTypeAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time, eventStore EventStore) iter.Seq2[EventStream, error] {
+	return deserializeEventStreamSeq(eventStore.StreamAllOfAggregateTypeAsAt(ctx, tenantID, aggregateType, projectionTime))
+}
+
+func StreamAllOfAggregateTypeAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time, eventStore EventStore) iter.Seq2[EventStream, error] {
+	return deserializeEventStreamSeq(eventStore.StreamAllOfAggregateTypeAsOf(ctx, tenantID, aggregateType, projectionTime))
+}
+
+func StreamAllOfAggregateTypeAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time, reportTime time.Time, eventStore EventStore) iter.Seq2[EventStream, error] {
+	return deserializeEventStreamSeq(eventStore.StreamAllOfAggregateTypeAsOfTill(ctx, tenantID, aggregateType, projectionTime, reportTime))
+}
+
+func StreamAllAggregatesAsAt(ctx context.Context, tenantID string, projectionTime time.Time, eventStore EventStore) iter.Seq2[EventStream, error] {
+	return deserializeEventStreamSeq(eventStore.StreamAllAsAt(ctx, tenantID, projectionTime))
+}
+
+func StreamAllAggregatesAsOf(ctx context.Context, tenantID string, projectionTime time.Time, eventStore EventStore) iter.Seq2[EventStream, error] {
+	return deserializeEventStreamSeq(eventStore.StreamAllAsOf(ctx, tenantID, projectionTime))
+}
+
+func StreamAllAggregatesAsOfTill(ctx context.Context, tenantID string, projectionTime, reportTime time.Time, eventStore EventStore) iter.Seq2[EventStream, error] {
+	return deserializeEventStreamSeq(eventStore.StreamAllAsOfTill(ctx, tenantID, projectionTime, reportTime))
+}
+
 func deserializeEventStreams(persistenceStreams []PersistenceEvents) (eventStreams []EventStream, err error) {
 	eventStreams = make([]EventStream, len(persistenceStreams))
 	for p, persistenceStream := range persistenceStreams {
-		eventStream := make([]IEvent, len(persistenceStream.Events))
-		for i, event := range persistenceStream.Events {
-			eventStream[i], err = DeserializeEvent(event)
+		eventStreams[p], err = deserializeEventStream(persistenceStream)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return eventStreams, nil
+}
+
+func deserializeEventStreamSeq(persistenceStreams iter.Seq2[PersistenceEvents, error]) iter.Seq2[EventStream, error] {
+	return func(yield func(EventStream, error) bool) {
+		for persistenceStream, err := range persistenceStreams {
 			if err != nil {
-				return nil, err
+				yield(EventStream{}, err)
+				return
+			}
+
+			eventStream, err := deserializeEventStream(persistenceStream)
+			if err != nil {
+				yield(EventStream{}, err)
+				return
+			}
+			if !yield(eventStream, nil) {
+				return
 			}
 		}
-		eventStreams[p] = EventStream{
-			Stream:  eventStream,
-			Version: persistenceStream.Version,
+	}
+}
+
+func deserializeEventStream(persistenceStream PersistenceEvents) (eventStream EventStream, err error) {
+	stream := make([]IEvent, len(persistenceStream.Events))
+	for i, event := range persistenceStream.Events {
+		stream[i], err = DeserializeEvent(event)
+		if err != nil {
+			return EventStream{}, err
 		}
 	}
-	return eventStreams, nil
+	return EventStream{
+		Stream:  stream,
+		Version: persistenceStream.Version,
+	}, nil
 }
@@ -0,0 +1,24 @@
+package event
+
+import "time"
+
+// Diff is the difference of the event stream of an aggregate between two views with as-of-till semantic (see
+// LoadAsOfTill): the view at projection time as reported at ReportTimeA and as reported at ReportTimeB. Entered
+// contains the events, patches and deletes (tombstones of the RevisionDelete strategy) that are in view B but not in
+// view A, Left the ones that are in view A but not in view B. Both are classified by Class and ordered by valid time
+// and version. Snapshots are not part of the difference.
+type Diff struct {
+	TenantID       string
+	AggregateType  string
+	AggregateID    string
+	ProjectionTime time.Time
+	ReportTimeA    time.Time
+	ReportTimeB    time.Time
+	Entered        map[Class][]PersistenceEvent
+	Left           map[Class][]PersistenceEvent
+}
+
+// IsEmpty returns true, if both views are equal.
+func (d Diff) IsEmpty() bool {
+	return len(d.Entered) == 0 && len(d.Left) == 0
+}
@@ -0,0 +1,19 @@
+package event
+
+import "fmt"
+
+// ErrorExpectationViolated is returned, if an event stream is not in the expected state during saving (see
+// Expectation). Version is the actual version of the event stream (0, if it does not exist).
+type ErrorExpectationViolated struct {
+	AggregateID   string
+	TenantID      string
+	AggregateType string
+	Expected      Expectation
+	Version       int
+	Closed        bool
+}
+
+func (c *ErrorExpectationViolated) Error() string {
+	return fmt.Sprintf("expectation %q violated for aggregateID %q of type %q and tenant %q with version %d (closed: %t)",
+		c.Expected, c.AggregateID, c.AggregateType, c.TenantID, c.Version, c.Closed)
+}
@@ -0,0 +1,18 @@
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrorStreamArchived is returned for archived streams, if they are not rehydrated (see RejectArchivedStreams).
+type ErrorStreamArchived struct {
+	TenantID      string
+	AggregateType string
+	AggregateID   string
+	ArchivedAt    time.Time
+}
+
+func (c *ErrorStreamArchived) Error() string {
+	return fmt.Sprintf("stream of aggregate %q of type %q and tenant %q is archived since %v", c.AggregateID, c.AggregateType, c.TenantID, c.ArchivedAt)
+}
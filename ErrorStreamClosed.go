@@ -0,0 +1,19 @@
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrorStreamClosed is returned for events, which are written into a closed stream after its close time, and for
+// further close events of a closed stream.
+type ErrorStreamClosed struct {
+	TenantID      string
+	AggregateType string
+	AggregateID   string
+	CloseTime     time.Time
+}
+
+func (c *ErrorStreamClosed) Error() string {
+	return fmt.Sprintf("stream of aggregate %q of type %q and tenant %q is closed since %v", c.AggregateID, c.AggregateType, c.TenantID, c.CloseTime)
+}
@@ -0,0 +1,12 @@
+package event
+
+import "fmt"
+
+// ErrorTenantSuspended is returned, if events are saved for a suspended tenant (see TenantManagement.SuspendTenant).
+type ErrorTenantSuspended struct {
+	TenantID string
+}
+
+func (c *ErrorTenantSuspended) Error() string {
+	return fmt.Sprintf("tenant %q is suspended", c.TenantID)
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -18,11 +19,13 @@ type Class string
 
 const (
 	CreateStreamEvent  Class = "create stream event" // must be unique
-	CloseStreamEvent   Class = "close stream event"  // must be unique
+	CloseStreamEvent   Class = "close stream event"  // the latest one defines the close time (see ReopenStreamEvent)
+	ReopenStreamEvent  Class = "reopen stream event" // cannot be deleted
 	InstantEvent       Class = "instant event"
 	HistoricalPatch    Class = "historical patch"
 	FuturePatch        Class = "future patch"
 	DeletePatch        Class = "delete patch"
+	DeleteRevision     Class = "delete revision" // tombstone of the RevisionDelete strategy
 	SnapShot           Class = "snapshot"
 	HistoricalSnapShot Class = "historical snapshot"
 )
@@ -31,6 +34,7 @@ type IEvent interface {
 	isEvent()
 	setUserID(id string)
 	setFromPersistenceEvent(evt PersistenceEvent)
+	setRedactedFields(fields []string)
 	GetMigration() bool
 	GetClass() Class
 	GetAggregateID() string
@@ -38,6 +42,7 @@ type IEvent interface {
 	GetTransactionTime() time.Time
 	GetValidTime() time.Time
 	GetEventID() string
+	GetRedactedFields() []string
 }
 
 type IEvents struct {
@@ -82,6 +87,26 @@ func NewCloseEventWithValidTime(aggregateID string, tenantID string, validTimest
 		FromMigration: false,
 	}
 }
+
+func NewReopenEvent(aggregateID string, tenantID string) Event {
+	return Event{
+		AggregateID:   aggregateID,
+		TenantID:      tenantID,
+		Class:         ReopenStreamEvent,
+		FromMigration: false,
+	}
+}
+
+func NewReopenEventWithValidTime(aggregateID string, tenantID string, validTimestamp time.Time) Event {
+	return Event{
+		AggregateID:   aggregateID,
+		TenantID:      tenantID,
+		ValidTime:     validTimestamp,
+		Class:         ReopenStreamEvent,
+		FromMigration: false,
+	}
+}
+
 func NewEvent(aggregateID string, tenantID string) Event {
 	return Event{
 		AggregateID:   aggregateID,
@@ -151,6 +176,8 @@ func NewMigrationEvent(aggregateID string, tenantID string, validTimestamp, tran
 type Deleted struct {
 	DeletedAt time.Time
 	DeletedBy string
+	// EventID is the id of the deleted event (only for tombstones of the RevisionDelete strategy)
+	EventID string `json:"EventID,omitempty"`
 }
 
 func (d *Deleted) IsDeleted() bool {
@@ -167,6 +194,7 @@ type Event struct {
 	Deleted         *Deleted `json:"Deleted,omitempty"`
 	Class           Class    `json:"-"`
 	FromMigration   bool     `json:"-"`
+	redactedFields  []string
 }
 
 func (e *Event) isEvent() {}
@@ -185,6 +213,16 @@ func (e *Event) setFromPersistenceEvent(evt PersistenceEvent) {
 	e.FromMigration = evt.FromMigration
 }
 
+func (e *Event) setRedactedFields(fields []string) {
+	e.redactedFields = fields
+}
+
+// GetRedactedFields returns the personal data fields, which are redacted because the key of the data subject was
+// deleted (see ShredPersonalData).
+func (e *Event) GetRedactedFields() []string {
+	return e.redactedFields
+}
+
 func (e *Event) GetMigration() bool {
 	return e.FromMigration
 }
@@ -253,19 +291,59 @@ func CreateEventForDeserialization(eventType string) (IEvent, error) {
 	return nil, fmt.Errorf("event %q is not registered", eventType)
 }
 
+// SerializeEvent encodes the event as SerializeEventWithCodec without options does, i.e. with the serializer of its
+// event type, and returns the data only.
 func SerializeEvent(evt IEvent) ([]byte, error) {
-	return json.Marshal(evt)
+	data, _, err := SerializeEventWithCodec(evt)
+	return data, err
+}
+
+// SerializeEventWithCodec encrypts the personal data of the event (see SetKeyStore and WithContext) and encodes the
+// event with the serializer of its event type or aggregate type (see RegisterSerializer and WithAggregateType). It
+// returns the data and the codec as they are stored in a PersistenceEvent.
+func SerializeEventWithCodec(evt IEvent, opts ...SerializeOption) (data json.RawMessage, codec string, err error) {
+	options := serializeOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if evt, err = encryptPersonalData(options.ctx, evt); err != nil {
+		return nil, "", err
+	}
+	serializer := serializerForEncoding(options.aggregateType, EventType(evt))
+	encoded, err := serializer.Marshal(evt)
+	if err != nil {
+		return nil, "", err
+	}
+	return wrapData(serializer.Codec(), encoded)
 }
 
+// DeserializeEvent upcasts the persistence event (see Upcast), decodes its data with the serializer of its codec into
+// the registered event and decrypts its personal data.
 func DeserializeEvent(persistenceEvent PersistenceEvent) (evt IEvent, err error) {
+	if persistenceEvent, err = Upcast(persistenceEvent); err != nil {
+		return nil, err
+	}
+	serializer, err := serializerForDecoding(persistenceEvent.Codec)
+	if err != nil {
+		return nil, err
+	}
+	data, err := unwrapData(persistenceEvent)
+	if err != nil {
+		return nil, err
+	}
 	if evt, err = CreateEventForDeserialization(persistenceEvent.Type); err != nil {
 		return nil, err
 	} else {
-		err = json.Unmarshal(persistenceEvent.Data, &evt)
+		err = serializer.Unmarshal(data, evt)
 		if err != nil {
 			return nil, err
 		}
 		evt.setFromPersistenceEvent(persistenceEvent)
+		// deserialization has no context, the key store is called without deadline
+		if err = decryptPersonalData(context.Background(), evt); err != nil {
+			return nil, err
+		}
 		return evt, nil
 	}
 }
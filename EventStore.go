@@ -3,6 +3,7 @@ package event
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"time"
 )
 
@@ -43,15 +44,17 @@ const (
 	// (but can be retrieved by using GetAggregatesEvents)
 	SoftDelete DeleteStrategy = "soft"
 
-	// not yet implemented
-	// RevisionDelete a dedicated delete event is used with a new version.
-	// The delete event will not be loaded but send to projection
-	// RevisionDelete DeleteStrategy = "revision"
+	// RevisionDelete strategy: A dedicated delete event (tombstone) is appended with a new version. Neither the tombstone
+	// nor the deleted event are loaded (as of the transaction time of the tombstone), but the tombstone is sent to the
+	// projections like any other event (with class DeleteRevision and the id of the deleted event in Deleted.EventID)
+	RevisionDelete DeleteStrategy = "revision"
 )
 
 type PersistenceEvents struct {
 	Events  []PersistenceEvent
 	Version int
+	// Expect is the expected state of the event stream instead of Version (optional, see Expectation)
+	Expect Expectation
 }
 
 type PersistenceEvent struct {
@@ -66,12 +69,26 @@ type PersistenceEvent struct {
 	ValidTime       time.Time       `json:"validTime"`
 	FromMigration   bool            `json:"FromMigration"`
 	Data            json.RawMessage `json:"data"`
+	// SchemaVersion is the version of the schema of the data (see RegisterUpcaster). The schema version 0 stands for
+	// the InitialSchemaVersion, i.e. it is only set for event types with upcasters.
+	SchemaVersion int `json:"schemaVersion"`
+	// Codec identifies the serializer of the data (see RegisterSerializer). Data of the JSONCodec is stored without a
+	// codec, the data of other codecs is stored as base64 encoded JSON string.
+	Codec string `json:"codec"`
+	// Position is the gap-free position of the event in the (per tenant) global event log, assigned in commit order.
+	// It is set by the store during saving, i.e. events which are not yet persisted have the position 0.
+	Position int64 `json:"position"`
 }
 
 type EventStore interface {
 	AggregateManagement
 	SnapshotManagement
 	ProjectionManagement
+	SubscriptionManagement
+	OutboxManagement
+	TenantManagement
+	PartitionManagement
+	StreamArchiveManagement
 
 	Save(ctx context.Context, tenantID string, events []PersistenceEvent, version int) (chan error, error)
 	SaveAll(ctx context.Context, tenantID string, events []PersistenceEvents) (chan error, error)
@@ -87,14 +104,39 @@ type EventStore interface {
 	// This means in practise that "patch events" are considered if their valid time is before the projection time, but only if their transaction time is before the report time.
 	// Confused? Take a look here: https://www.youtube.com/watch?v=xzekp1RuZbM and https://www.youtube.com/watch?v=GZA1fNVGEV0
 	LoadAsOfTill(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime, reportTime time.Time) (eventStream []PersistenceEvent, version int, err error)
+	// DiffAsOfTill answers the question: "Why does the event stream at projection time as reported at report time A
+	// differ from the one as reported at report time B?" It returns the events, patches and deletes that entered or left
+	// the view between both report times (see Diff).
+	DiffAsOfTill(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime, reportTimeA, reportTimeB time.Time) (Diff, error)
+	// DiffAllOfAggregateTypeAsOfTill returns the non-empty differences of all aggregates of the aggregate type (see DiffAsOfTill).
+	DiffAllOfAggregateTypeAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTimeA, reportTimeB time.Time) ([]Diff, error)
 
 	LoadAllOfAggregateTypeAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []PersistenceEvents, err error)
 	LoadAllOfAggregateTypeAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []PersistenceEvents, err error)
 	LoadAllOfAggregateTypeAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) (eventStreams []PersistenceEvents, err error)
+	// LoadAllOfAggregateTypeAsOfSeries loads the event streams of all aggregates of the type with as-of semantic for
+	// several projection times in one pass, using the snapshots at the earliest one (see PersistenceEventsSeries and
+	// ProjectionTimesBetween). The projection times are sorted and duplicates are removed. An empty result is no error.
+	LoadAllOfAggregateTypeAsOfSeries(ctx context.Context, tenantID, aggregateType string, projectionTimes []time.Time) ([]PersistenceEventsSeries, error)
 
 	LoadAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) (eventStreams []PersistenceEvents, err error)
 	LoadAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) (eventStreams []PersistenceEvents, err error)
 	LoadAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) (eventStreams []PersistenceEvents, err error)
 
+	// StreamAll... are the streaming variants of LoadAll... with the same bi-temporal semantics. They read the event
+	// streams lazily while iterating (Postgres uses a server-side cursor) and hold a database connection until the
+	// iteration ends. An empty result yields nothing. After an error the iteration ends.
+	StreamAllOfAggregateTypeAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[PersistenceEvents, error]
+	StreamAllOfAggregateTypeAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[PersistenceEvents, error]
+	StreamAllOfAggregateTypeAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[PersistenceEvents, error]
+
+	StreamAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[PersistenceEvents, error]
+	StreamAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[PersistenceEvents, error]
+	StreamAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[PersistenceEvents, error]
+
+	// LoadFromPosition reads the global event log of a tenant. It returns at most limit events with a position greater
+	// than the given one, ordered by position. Consumers can use the position of the last received event as checkpoint.
+	LoadFromPosition(ctx context.Context, tenantID string, position int64, limit int) (events []PersistenceEvent, err error)
+
 	DeleteEvent(ctx context.Context, tenantID, aggregateType, aggregateID, eventID string) error
 }
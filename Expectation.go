@@ -0,0 +1,64 @@
+package event
+
+import (
+	"fmt"
+)
+
+type ExpectedState string
+
+const (
+	// ExpectedNoStream the event stream must not exist, e.g. for idempotent create-if-absent saves
+	ExpectedNoStream ExpectedState = "no stream"
+	// ExpectedAny the events are appended to the event stream in any version (regardless of the
+	// ConcurrentModificationStrategy)
+	ExpectedAny ExpectedState = "any"
+	// ExpectedVersion the event stream must have exactly the version (regardless of the ConcurrentModificationStrategy)
+	ExpectedVersion ExpectedState = "version"
+	// ExpectedClosed the event stream must exist and be closed
+	ExpectedClosed ExpectedState = "closed"
+)
+
+// Expectation is the expected state of an event stream during saving (see PersistenceEvents). The empty expectation
+// expects the version of PersistenceEvents with respect to the ConcurrentModificationStrategy of the aggregate type.
+type Expectation struct {
+	State   ExpectedState
+	Version int
+}
+
+func ExpectNoStream() Expectation {
+	return Expectation{State: ExpectedNoStream}
+}
+
+func ExpectAny() Expectation {
+	return Expectation{State: ExpectedAny}
+}
+
+func ExpectVersion(version int) Expectation {
+	return Expectation{State: ExpectedVersion, Version: version}
+}
+
+func ExpectClosed() Expectation {
+	return Expectation{State: ExpectedClosed}
+}
+
+// IsEmpty returns true, if the version of PersistenceEvents is expected.
+func (e Expectation) IsEmpty() bool {
+	return e.State == ""
+}
+
+func (e Expectation) String() string {
+	if e.State == ExpectedVersion {
+		return fmt.Sprintf("%s %d", e.State, e.Version)
+	}
+	return string(e.State)
+}
+
+// WithExpectation saves the aggregate with the expectation instead of its version (see SaveAggregates).
+func WithExpectation(aggregate AggregateWithEventSourcingSupport, expect Expectation) AggregateWithEventSourcingSupport {
+	return aggregateWithExpectation{AggregateWithEventSourcingSupport: aggregate, expect: expect}
+}
+
+type aggregateWithExpectation struct {
+	AggregateWithEventSourcingSupport
+	expect Expectation
+}
@@ -0,0 +1,25 @@
+package event
+
+import (
+	"context"
+)
+
+// The outbox delivers saved events to external systems (e.g. a message broker). Events of the configured event types
+// (see eventstore.WithOutbox) are written into the outbox within the transaction which saves them, so that no event
+// gets lost between the commit and the delivery. A dispatcher (see eventstore.WithOutboxPublisher) passes the events
+// at least once to a publisher. If the publisher fails, the delivery is retried with an exponential backoff. After
+// the maximal number of attempts, the event is moved into the dead letter state, until it is requeued. In case of
+// retries, the events are not necessarily delivered in the order they were saved.
+
+type DeadLetter struct {
+	Event     PersistenceEvent
+	Attempts  int
+	LastError string
+}
+
+type OutboxManagement interface {
+	// GetDeadLetters returns the events of the tenant, which could not be delivered by the outbox.
+	GetDeadLetters(ctx context.Context, tenantID string) ([]DeadLetter, error)
+	// RequeueDeadLetters resets the attempts of the given dead letters, so that the outbox delivers them again.
+	RequeueDeadLetters(ctx context.Context, tenantID string, eventIDs ...string) error
+}
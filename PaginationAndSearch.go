@@ -19,7 +19,7 @@ const (
 	SearchAggregateClass     = "SearchAggregateClass"
 	SearchValidTime          = "SearchValidTime"       //as RFC3339
 	SearchTransactionTime    = "SearchTransactionTime" //As RFC3339
-	SearchData               = "SearchData"
+	SearchData               = "SearchData"            //fails, if events of other codecs than the JSONCodec match
 )
 
 type SortField struct {
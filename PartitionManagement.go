@@ -0,0 +1,29 @@
+package event
+
+import "context"
+
+// The Postgres adapter can partition the event store tables by aggregate type (and the queued projection events by
+// projection), optionally with sub-partitions by tenant (see postgres.PartitionOptions). The partitions are created
+// on first use. Rows, which were saved before, stay in the default partitions until they are migrated.
+
+type PartitionSize struct {
+	Table     string
+	Partition string
+	// Key is the aggregate type or projection of the partition. It is empty for the default partition of a table.
+	Key string
+	// TenantID is set for tenant sub-partitions. It is empty for the default sub-partition of a key.
+	TenantID string
+	Default  bool
+	// Rows is estimated by the database statistics.
+	Rows  int64
+	Bytes int64
+}
+
+type PartitionManagement interface {
+	// MigrateDefaultPartitions moves the rows of the default partitions into the partitions of their aggregate types,
+	// projections and tenants. Each partition is migrated in its own transaction, so that only the writes into the
+	// default partitions are blocked for the duration of a single partition.
+	MigrateDefaultPartitions(ctx context.Context) error
+	// GetPartitionSizes reports the sizes of all partitions. Without partitioning (e.g. in memory) it is empty.
+	GetPartitionSizes(ctx context.Context) ([]PartitionSize, error)
+}
@@ -0,0 +1,207 @@
+package event
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/keyStore"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Personal data (e.g. for the GDPR) is encrypted with a key per data subject (see keyStore.Port). String fields of
+// events are marked as personal data by the struct tag `personal:"true"`. The data subject is the aggregate, unless
+// the event implements PersonalDataSubject. After the key of a data subject is deleted (see ShredPersonalData), its
+// personal data is redacted in all loads, projections and snapshots, i.e. the fields are empty and listed in
+// GetRedactedFields of the event.
+
+const (
+	personalDataTag    = "personal"
+	personalDataPrefix = "personal:"
+)
+
+// PersonalDataSubject is implemented by events whose personal data belongs to another data subject than the aggregate.
+type PersonalDataSubject interface {
+	GetPersonalDataSubjectID() string
+}
+
+var (
+	keyStoreMu sync.RWMutex
+	keys       keyStore.Port
+)
+
+// SetKeyStore sets the key store for the encryption of personal data (see eventstore.WithKeyStore).
+func SetKeyStore(keyStore keyStore.Port) {
+	keyStoreMu.Lock()
+	defer keyStoreMu.Unlock()
+
+	keys = keyStore
+}
+
+func getKeyStore() keyStore.Port {
+	keyStoreMu.RLock()
+	defer keyStoreMu.RUnlock()
+
+	return keys
+}
+
+// ShredPersonalData deletes the key of the data subject, so that its personal data becomes unreadable in all events.
+func ShredPersonalData(ctx context.Context, tenantID, subjectID string) error {
+	store := getKeyStore()
+	if store == nil {
+		return fmt.Errorf("shredding of personal data failed: no key store set")
+	}
+	if err := store.DeleteKey(ctx, tenantID, subjectID); err != nil {
+		return fmt.Errorf("shredding of personal data of data subject %q failed:%w", subjectID, err)
+	}
+	return nil
+}
+
+type personalDataField struct {
+	name  string
+	value reflect.Value
+}
+
+func personalDataFields(evt any) (fields []personalDataField, err error) {
+	value := reflect.ValueOf(evt)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	value = value.Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if _, ok := value.Type().Field(i).Tag.Lookup(personalDataTag); !ok {
+			continue
+		}
+		if value.Field(i).Kind() != reflect.String {
+			return nil, fmt.Errorf("personal data field %q of event %q is not a string", value.Type().Field(i).Name, EventType(evt))
+		}
+		fields = append(fields, personalDataField{name: value.Type().Field(i).Name, value: value.Field(i)})
+	}
+	return fields, nil
+}
+
+func personalDataSubjectID(evt IEvent) string {
+	if subject, ok := evt.(PersonalDataSubject); ok {
+		return subject.GetPersonalDataSubjectID()
+	}
+	return evt.GetAggregateID()
+}
+
+// encryptPersonalData returns a copy of the event, in which the personal data is encrypted.
+func encryptPersonalData(ctx context.Context, evt IEvent) (IEvent, error) {
+	if reflect.ValueOf(evt).Kind() != reflect.Ptr {
+		return evt, nil
+	}
+	encrypted := reflect.New(reflect.TypeOf(evt).Elem())
+	encrypted.Elem().Set(reflect.ValueOf(evt).Elem())
+
+	fields, err := personalDataFields(encrypted.Interface())
+	if err != nil || len(fields) == 0 {
+		return evt, err
+	}
+
+	store := getKeyStore()
+	if store == nil {
+		return nil, fmt.Errorf("encryption of personal data of event %q failed: no key store set", EventType(evt))
+	}
+	key, err := store.GetOrCreateKey(ctx, evt.GetTenantID(), personalDataSubjectID(evt))
+	if err != nil {
+		return nil, fmt.Errorf("encryption of personal data of event %q failed:%w", EventType(evt), err)
+	}
+
+	for _, field := range fields {
+		if field.value.String() == "" {
+			continue
+		}
+		cipherText, err := encrypt(key, field.value.String())
+		if err != nil {
+			return nil, fmt.Errorf("encryption of personal data of event %q failed:%w", EventType(evt), err)
+		}
+		field.value.SetString(cipherText)
+	}
+	return encrypted.Interface().(IEvent), nil
+}
+
+// decryptPersonalData decrypts the personal data of the event. The personal data of shredded data subjects is
+// redacted.
+func decryptPersonalData(ctx context.Context, evt IEvent) error {
+	fields, err := personalDataFields(evt)
+	if err != nil || len(fields) == 0 {
+		return err
+	}
+
+	var key []byte
+	var fetched bool
+	var redacted []string
+	for _, field := range fields {
+		if !strings.HasPrefix(field.value.String(), personalDataPrefix) {
+			continue // e.g. saved before the field was marked as personal data
+		}
+
+		if !fetched {
+			store := getKeyStore()
+			if store == nil {
+				return fmt.Errorf("decryption of personal data of event %q failed: no key store set", evt.GetEventID())
+			}
+			if key, err = store.GetKey(ctx, evt.GetTenantID(), personalDataSubjectID(evt)); err != nil && !errors.Is(err, keyStore.ErrKeyNotFound) {
+				return fmt.Errorf("decryption of personal data of event %q failed:%w", evt.GetEventID(), err)
+			}
+			fetched = true
+		}
+
+		plainText, err := decrypt(key, field.value.String())
+		if err != nil {
+			// the key was deleted (or replaced by a new key after the deletion)
+			plainText = ""
+			redacted = append(redacted, field.name)
+		}
+		field.value.SetString(plainText)
+	}
+	evt.setRedactedFields(redacted)
+	return nil
+}
+
+func encrypt(key []byte, plainText string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return personalDataPrefix + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plainText), nil)), nil
+}
+
+func decrypt(key []byte, cipherText string) (string, error) {
+	if key == nil {
+		return "", keyStore.ErrKeyNotFound
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(cipherText, personalDataPrefix))
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("cipher text too short")
+	}
+	plainText, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	return string(plainText), err
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
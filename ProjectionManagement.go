@@ -31,6 +31,48 @@ type ProjectionState struct {
 	InputQueueLength        int
 	ProjectionType          ProjectionType
 	RetryDurations          []time.Duration
+	Quarantine              bool
+	Lanes                   int
+	Generation              int
+}
+
+// ProjectionHealth reports how far a projection of a tenant is behind, e.g. to alert on its lag.
+type ProjectionHealth struct {
+	TenantID     string
+	ProjectionID string
+	State        string
+	// QueuedEvents is the number of events in the queue of the projection, which are due to be projected (i.e. without
+	// future patches).
+	QueuedEvents int
+	// OldestQueuedEvent is the transaction time of the oldest of these events (zero without queued events).
+	OldestQueuedEvent time.Time
+	// LastSuccessfulRun, LastError and ConsecutiveFailures are updated by the executions of eventual consistent
+	// projections, which projected events or failed.
+	LastSuccessfulRun   time.Time
+	LastError           string
+	ConsecutiveFailures int
+	// WorkerQueueFill is the number of pending execution requests in the input queues of the workers of the projection
+	// in this instance of the eventStore, whose capacity is WorkerQueueLength.
+	WorkerQueueFill   int
+	WorkerQueueLength int
+}
+
+// Lag returns the time, which the oldest queued event of the projection is waiting for its projection.
+func (h ProjectionHealth) Lag() time.Duration {
+	if h.OldestQueuedEvent.IsZero() {
+		return 0
+	}
+	return time.Since(h.OldestQueuedEvent)
+}
+
+// QuarantinedEvent is an event, which could not be projected after all retries of the projection (see
+// eventstore.WithProjectionRetry and eventstore.WithProjectionQuarantine). It is removed from the queue of the
+// projection, so that the following events are still projected, until it is replayed or discarded.
+type QuarantinedEvent struct {
+	ProjectionID  string
+	Event         PersistenceEvent
+	LastError     string
+	QuarantinedAt time.Time
 }
 
 // A projection is a set of events for which a separate storage or execution model is used in the domain.
@@ -50,6 +92,33 @@ type ProjectionSince interface {
 	PrepareRebuildSince(ctx context.Context, tenantID string, since time.Time) error
 }
 
+// ProjectionGeneration identifies the read model, which a projection writes. The number of the generation is
+// increased with each blue/green rebuild (see ProjectionManagement.RebuildProjectionBlueGreen).
+type ProjectionGeneration struct {
+	Number int
+	// Shadow is true for the read model of a running blue/green rebuild, which is not live yet.
+	Shadow bool
+}
+
+// ProjectionGenerations is implemented by projections, which support blue/green rebuilds. Instead of Execute, the
+// eventStore calls ExecuteGeneration with the generation to write, i.e. the live read model or the shadow read model of
+// a running blue/green rebuild.
+type ProjectionGenerations interface {
+	// PrepareGeneration creates the empty shadow read model of the generation.
+	PrepareGeneration(ctx context.Context, tenantID string, generation ProjectionGeneration) error
+	ExecuteGeneration(ctxWithTimeOut context.Context, generation ProjectionGeneration, events []IEvent) error
+	// SwitchGeneration atomically replaces the live read model by the shadow read model of the generation. The read
+	// model of the previous generation is no longer written afterward.
+	SwitchGeneration(ctx context.Context, tenantID string, generation ProjectionGeneration) error
+}
+
+// ProjectionPurge is implemented by projections, which remove the read model of a tenant, after the tenant was purged
+// (see TenantManagement.PurgeTenant). Read models partitioned per tenant can drop the partition of the tenant with
+// transactor/postgres.Adapter.DropTenantIntern.
+type ProjectionPurge interface {
+	PurgeTenant(ctx context.Context, tenantID string) error
+}
+
 type ProjectionManagement interface {
 	// StartProjection  start/re-start a projection (initially all projection are started with the eventStore)
 	StartProjection(ctx context.Context, tenantID, projectionID string) (chan error, error)
@@ -72,6 +141,11 @@ type ProjectionManagement interface {
 	// possible during rebuilds and will fail with error. For the projection to remain consistent, the rebuild must be
 	// completed before new events are added.
 	//
+	// Projections, which implement ProjectionGenerations, can be rebuilt without these restrictions with
+	// RebuildProjectionBlueGreen.
+	//
+	// All rebuilds restore the archived streams of the tenant beforehand (see StreamArchiveManagement).
+	//
 	RebuildAllProjection(ctx context.Context, tenantID string) chan error
 
 	// RebuildAllProjectionSince sinceTime means domain time (valid time) not transaction time
@@ -80,9 +154,25 @@ type ProjectionManagement interface {
 	RebuildProjection(ctx context.Context, tenantID, projectionID string) chan error
 	// RebuildProjectionSince sinceTime means domain time (valid time) not transaction time
 	RebuildProjectionSince(ctx context.Context, tenantID, projectionID string, sinceTime time.Time) chan error
+	// RebuildProjectionBlueGreen rebuilds the projection into a shadow read model (see ProjectionGenerations), while the
+	// live read model is still projected. The shadow is projected from all events of the projection. New events are
+	// projected into both read models until the shadow caught up and replaces the live read model.
+	RebuildProjectionBlueGreen(ctx context.Context, tenantID, projectionID string) chan error
 
 	RemoveProjection(ctx context.Context, projectionID string) error
 
 	GetProjectionStates(ctx context.Context, tenantID string, projectionID ...string) ([]ProjectionState, error)
 	GetAllProjectionStates(ctx context.Context, tenantID string) ([]ProjectionState, error)
+
+	// GetProjectionHealth returns the queue depth, the lag and the latest execution results of the projections.
+	GetProjectionHealth(ctx context.Context, tenantID string, projectionID ...string) ([]ProjectionHealth, error)
+	GetAllProjectionHealth(ctx context.Context, tenantID string) ([]ProjectionHealth, error)
+
+	// GetQuarantinedEvents returns the quarantined events of the projection, ordered by valid time.
+	GetQuarantinedEvents(ctx context.Context, tenantID, projectionID string) ([]QuarantinedEvent, error)
+	// ReplayQuarantinedEvents moves the given quarantined events back into the queue of the projection and executes
+	// the projection (e.g. after the projection was fixed).
+	ReplayQuarantinedEvents(ctx context.Context, tenantID, projectionID string, eventIDs ...string) (chan error, error)
+	// DiscardQuarantinedEvents deletes the given quarantined events, i.e. they are never passed to the projection.
+	DiscardQuarantinedEvents(ctx context.Context, tenantID, projectionID string, eventIDs ...string) error
 }
@@ -0,0 +1,127 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// EventApplier rebuilds an aggregate from its event stream and version. If the aggregate was loaded from a snapshot,
+// the event stream begins with the snapshot event, followed by the events after the snapshot.
+type EventApplier[T AggregateWithEventSourcingSupport] interface {
+	ApplyEvents(version int, eventStream ...IEvent) (T, error)
+}
+
+// EventApplierFunc allows the use of an ordinary function (e.g. a LoadFromEventStream function) as EventApplier.
+type EventApplierFunc[T AggregateWithEventSourcingSupport] func(version int, eventStream ...IEvent) (T, error)
+
+func (f EventApplierFunc[T]) ApplyEvents(version int, eventStream ...IEvent) (T, error) {
+	return f(version, eventStream...)
+}
+
+// Repository is a typed repository for the aggregates of type T on top of SaveAggregate and LoadAggregateAsOf etc.
+// The aggregate type is the name of T (without pointer), as used by SaveAggregates.
+type Repository[T AggregateWithEventSourcingSupport] struct {
+	eventStore    EventStore
+	applier       EventApplier[T]
+	aggregateType string
+}
+
+func NewRepository[T AggregateWithEventSourcingSupport](eventStore EventStore, applier EventApplier[T]) *Repository[T] {
+	return &Repository[T]{
+		eventStore:    eventStore,
+		applier:       applier,
+		aggregateType: aggregateTypeName(reflect.TypeFor[T]()),
+	}
+}
+
+// AggregateType returns the aggregate type of the repository.
+func (r *Repository[T]) AggregateType() string {
+	return r.aggregateType
+}
+
+// Get returns the current state of the aggregate.
+func (r *Repository[T]) Get(ctx context.Context, tenantID, aggregateID string) (T, error) {
+	return r.GetAsOf(ctx, tenantID, aggregateID, time.Now())
+}
+
+func (r *Repository[T]) GetAsAt(ctx context.Context, tenantID, aggregateID string, projectionTime time.Time) (T, error) {
+	eventStream, version, err := LoadAggregateAsAt(ctx, tenantID, r.aggregateType, aggregateID, projectionTime, r.eventStore)
+	return r.apply(tenantID, aggregateID, eventStream, version, err)
+}
+
+func (r *Repository[T]) GetAsOf(ctx context.Context, tenantID, aggregateID string, projectionTime time.Time) (T, error) {
+	eventStream, version, err := LoadAggregateAsOf(ctx, tenantID, r.aggregateType, aggregateID, projectionTime, r.eventStore)
+	return r.apply(tenantID, aggregateID, eventStream, version, err)
+}
+
+func (r *Repository[T]) GetAsOfTill(ctx context.Context, tenantID, aggregateID string, projectionTime, reportTime time.Time) (T, error) {
+	eventStream, version, err := LoadAggregateAsOfTill(ctx, tenantID, r.aggregateType, aggregateID, projectionTime, reportTime, r.eventStore)
+	return r.apply(tenantID, aggregateID, eventStream, version, err)
+}
+
+// Save saves the unsaved changes of the aggregate with its version as expected version (see ConcurrentModificationStrategy).
+// It returns the aggregate as stored, i.e. reloaded as of its latest valid time (at least now, including future
+// events), so that the version is carried forward for the next save. The error channel reports the errors of the
+// consistent projections (see SaveAggregate). On errors, the zero value of T is returned. If only the reload failed,
+// the changes are saved nevertheless and the error channel is returned as well.
+func (r *Repository[T]) Save(ctx context.Context, aggregate T) (T, chan error, error) {
+	if len(aggregate.GetUnsavedChanges()) == 0 {
+		errCh := make(chan error, 1)
+		close(errCh)
+		return aggregate, errCh, nil
+	}
+
+	var empty T
+	errCh, err := SaveAggregate(ctx, r.eventStore, aggregate)
+	if err != nil {
+		return empty, nil, err
+	}
+
+	// an aggregate with only future events has no state as of now
+	state, err := r.eventStore.GetAggregateState(ctx, aggregate.GetTenantID(), r.aggregateType, aggregate.GetID())
+	if err != nil {
+		return empty, errCh, fmt.Errorf("reload of saved aggregate %q failed: %w", aggregate.GetID(), err)
+	}
+	saved, err := r.GetAsOf(ctx, aggregate.GetTenantID(), aggregate.GetID(), latest(time.Now(), state.LatestValidTime))
+	if err != nil {
+		return empty, errCh, fmt.Errorf("reload of saved aggregate %q failed: %w", aggregate.GetID(), err)
+	}
+
+	return saved, errCh, nil
+}
+
+// SaveAll saves the unsaved changes of all aggregates within one transaction (see SaveAggregates). In contrast to Save,
+// the aggregates are not reloaded, i.e. they have to be loaded again before their next save.
+func (r *Repository[T]) SaveAll(ctx context.Context, aggregates ...T) (chan error, error) {
+	toSave := make([]AggregateWithEventSourcingSupport, len(aggregates))
+	for i, aggregate := range aggregates {
+		toSave[i] = aggregate
+	}
+	return SaveAggregates(ctx, r.eventStore, toSave...)
+}
+
+func (r *Repository[T]) apply(tenantID, aggregateID string, eventStream []IEvent, version int, err error) (T, error) {
+	var empty T
+	if err != nil {
+		return empty, err
+	}
+	if len(eventStream) == 0 {
+		return empty, &ErrorEmptyEventStream{AggregateID: aggregateID, TenantID: tenantID, AggregateType: r.aggregateType}
+	}
+
+	aggregate, err := r.applier.ApplyEvents(version, eventStream...)
+	if err != nil {
+		return empty, fmt.Errorf("could not apply events of aggregate %q of type %q: %w", aggregateID, r.aggregateType, err)
+	}
+
+	return aggregate, nil
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
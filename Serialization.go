@@ -0,0 +1,137 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// The data of events is encoded by a Serializer. By default, events are encoded as JSON. Other codecs (e.g. protobuf
+// or msgpack) are registered per aggregate type or event type (see RegisterSerializer or eventstore.WithSerializer).
+// The codec is recorded with each event (see PersistenceEvent.Codec), so that loads pick the right serializer. The
+// data of other codecs is kept as base64 encoded JSON string, i.e. PersistenceEvent.Data is always valid JSON.
+
+// JSONCodec is the codec of the default serializer. Events of the JSONCodec are stored without a codec.
+const JSONCodec = "json"
+
+// Serializer encodes and decodes the data of events.
+type Serializer interface {
+	// Codec identifies the serializer. It is recorded with each event and must therefore be stable.
+	Codec() string
+	Marshal(evt any) ([]byte, error)
+	Unmarshal(data []byte, evt any) error
+}
+
+type JSONSerializer struct{}
+
+func (j JSONSerializer) Codec() string {
+	return JSONCodec
+}
+
+func (j JSONSerializer) Marshal(evt any) ([]byte, error) {
+	return json.Marshal(evt)
+}
+
+func (j JSONSerializer) Unmarshal(data []byte, evt any) error {
+	return json.Unmarshal(data, evt)
+}
+
+// SerializeOption configures SerializeEventWithCodec.
+type SerializeOption func(options *serializeOptions)
+
+type serializeOptions struct {
+	ctx           context.Context
+	aggregateType string
+}
+
+// WithContext is the context of the key store calls for the encryption of personal data (see SetKeyStore).
+func WithContext(ctx context.Context) SerializeOption {
+	return func(options *serializeOptions) {
+		options.ctx = ctx
+	}
+}
+
+// WithAggregateType selects the serializer of the aggregate type, if there is none for the event type.
+func WithAggregateType(aggregateType string) SerializeOption {
+	return func(options *serializeOptions) {
+		options.aggregateType = aggregateType
+	}
+}
+
+var (
+	serializersMu   sync.RWMutex
+	serializers     = map[string]Serializer{JSONCodec: JSONSerializer{}}
+	typeSerializers = make(map[string]string) // [aggregateType or eventType]codec
+)
+
+// RegisterSerializer registers the serializer for the decoding of events with its codec and uses it for the encoding
+// of the given aggregate types or event types. The serializer of an event type takes precedence over the serializer
+// of an aggregate type.
+func RegisterSerializer(serializer Serializer, types ...string) error {
+	if serializer == nil || serializer.Codec() == "" {
+		return fmt.Errorf("serializer without codec")
+	}
+
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+
+	serializers[serializer.Codec()] = serializer
+	for _, t := range types {
+		typeSerializers[t] = serializer.Codec()
+	}
+	return nil
+}
+
+func serializerForEncoding(aggregateType, eventType string) Serializer {
+	serializersMu.RLock()
+	defer serializersMu.RUnlock()
+
+	if codec, ok := typeSerializers[eventType]; ok {
+		return serializers[codec]
+	}
+	if codec, ok := typeSerializers[aggregateType]; ok {
+		return serializers[codec]
+	}
+	return serializers[JSONCodec]
+}
+
+func serializerForDecoding(codec string) (Serializer, error) {
+	serializersMu.RLock()
+	defer serializersMu.RUnlock()
+
+	if codec == "" {
+		codec = JSONCodec
+	}
+	if serializer, ok := serializers[codec]; ok {
+		return serializer, nil
+	}
+	return nil, fmt.Errorf("serializer for codec %q is not registered", codec)
+}
+
+// wrapData returns the data, as encoded by the serializer of the codec, and the codec as they are stored in a
+// PersistenceEvent.
+func wrapData(codec string, data []byte) (json.RawMessage, string, error) {
+	if codec == "" || codec == JSONCodec {
+		return data, "", nil
+	}
+
+	wrapped, err := json.Marshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return wrapped, codec, nil
+}
+
+// unwrapData returns the data of the event, as encoded by the serializer of its codec.
+func unwrapData(evt PersistenceEvent) ([]byte, error) {
+	if evt.Codec == "" || evt.Codec == JSONCodec {
+		return evt.Data, nil
+	}
+
+	var data []byte
+	if err := json.Unmarshal(evt.Data, &data); err != nil {
+		return nil, fmt.Errorf("data of event %q with codec %q is not base64 encoded: %w", evt.ID, evt.Codec, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,141 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore/transactor/postgres/temporal"
+	"iter"
+	"time"
+)
+
+// PersistenceEventsSeries is the event stream of an aggregate with as-of semantic (see LoadAsOf) for several projection
+// times, loaded in one pass. The event stream at ProjectionTimes[i] is Events[:Ends[i]], so each event stream is a
+// prefix of the next one. It is empty, if the aggregate did not exist at the projection time.
+type PersistenceEventsSeries struct {
+	ProjectionTimes []time.Time
+	Events          []PersistenceEvent
+	Ends            []int
+	Version         int
+}
+
+// At returns the event stream at ProjectionTimes[i].
+func (s PersistenceEventsSeries) At(i int) []PersistenceEvent {
+	return s.Events[:s.Ends[i]]
+}
+
+// EventStreamSeries is the deserialized PersistenceEventsSeries.
+type EventStreamSeries struct {
+	ProjectionTimes []time.Time
+	Stream          []IEvent
+	Ends            []int
+	Version         int
+}
+
+// At returns the event stream at ProjectionTimes[i].
+func (s EventStreamSeries) At(i int) []IEvent {
+	return s.Stream[:s.Ends[i]]
+}
+
+// All yields the projection times with their event streams in ascending order.
+func (s EventStreamSeries) All() iter.Seq2[time.Time, []IEvent] {
+	return func(yield func(time.Time, []IEvent) bool) {
+		for i, projectionTime := range s.ProjectionTimes {
+			if !yield(projectionTime, s.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// FoldSeries folds the event stream of the series incrementally. The fold function gets the state of the previous
+// projection time (initially the given state) and the events since then. It returns the state for each projection
+// time. The fold function must not change the previous state, if it is a reference type.
+func FoldSeries[S any](series EventStreamSeries, state S, fold func(state S, events ...IEvent) (S, error)) ([]S, error) {
+	states := make([]S, len(series.ProjectionTimes))
+	start := 0
+	for i := range series.ProjectionTimes {
+		var err error
+		state, err = fold(state, series.Stream[start:series.Ends[i]]...)
+		if err != nil {
+			return nil, fmt.Errorf("fold of aggregate at %s failed: %w", series.ProjectionTimes[i], err)
+		}
+		states[i] = state
+		start = series.Ends[i]
+	}
+	return states, nil
+}
+
+// ProjectionTimesBetween returns the projection times from the start of the period of from (see temporal.TruncateDate)
+// till the given time in steps of the granularity, e.g. the first day of each month.
+func ProjectionTimesBetween(from, till time.Time, granularity temporal.Granularity) ([]time.Time, error) {
+	if till.Before(from) {
+		return nil, fmt.Errorf("till %s is before from %s", till, from)
+	}
+	start, err := temporal.TruncateDate(from, granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectionTimes []time.Time
+	for i := 0; ; i++ {
+		// always step from the start, so that e.g. month ends do not drift
+		projectionTime := addGranularity(start, granularity, i)
+		if projectionTime.After(till) {
+			return projectionTimes, nil
+		}
+		projectionTimes = append(projectionTimes, projectionTime)
+	}
+}
+
+func addGranularity(t time.Time, granularity temporal.Granularity, n int) time.Time {
+	switch granularity {
+	case temporal.Microsecond:
+		return t.Add(time.Duration(n) * time.Microsecond)
+	case temporal.Millisecond:
+		return t.Add(time.Duration(n) * time.Millisecond)
+	case temporal.Second:
+		return t.Add(time.Duration(n) * time.Second)
+	case temporal.Minute:
+		return t.Add(time.Duration(n) * time.Minute)
+	case temporal.Hour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case temporal.Day:
+		return t.AddDate(0, 0, n)
+	case temporal.Week:
+		return t.AddDate(0, 0, 7*n)
+	case temporal.Month:
+		return t.AddDate(0, n, 0)
+	case temporal.Quarter:
+		return t.AddDate(0, 3*n, 0)
+	case temporal.Year:
+		return t.AddDate(n, 0, 0)
+	case temporal.Decade:
+		return t.AddDate(10*n, 0, 0)
+	default: // Millennium, the granularity is validated by TruncateDate
+		return t.AddDate(1000*n, 0, 0)
+	}
+}
+
+// LoadAllOfAggregateTypeAsOfSeries loads and deserializes the event streams of all aggregates of the type for each
+// projection time (see EventStore.LoadAllOfAggregateTypeAsOfSeries).
+func LoadAllOfAggregateTypeAsOfSeries(ctx context.Context, tenantID, aggregateType string, projectionTimes []time.Time, eventStore EventStore) ([]EventStreamSeries, error) {
+	persistenceSeries, err := eventStore.LoadAllOfAggregateTypeAsOfSeries(ctx, tenantID, aggregateType, projectionTimes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]EventStreamSeries, len(persistenceSeries))
+	for i, series := range persistenceSeries {
+		eventStream, err := deserializeEventStream(PersistenceEvents{Events: series.Events, Version: series.Version})
+		if err != nil {
+			return nil, err
+		}
+		result[i] = EventStreamSeries{
+			ProjectionTimes: series.ProjectionTimes,
+			Stream:          eventStream.Stream,
+			Ends:            series.Ends,
+			Version:         series.Version,
+		}
+	}
+	return result, nil
+}
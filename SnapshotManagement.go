@@ -19,7 +19,60 @@ type TimeInterval struct {
 	End   time.Time
 }
 
+// SnapshotPolicy defines when the eventStore creates the snapshots of the aggregates of an aggregate type itself (see
+// eventstore.WithSnapshotPolicy). The policy is checked in the background after each save of an aggregate. A snapshot
+// is created as soon as one of the conditions is met and at least one event was saved since the last snapshot.
+type SnapshotPolicy struct {
+	// EveryNEvents creates a snapshot, if at least N events were saved since the last snapshot.
+	EveryNEvents int
+	// Every creates a snapshot, if the last snapshot (or the creation of the aggregate) is older than the duration.
+	Every time.Duration
+	// OnClose creates a snapshot, if the stream of the aggregate is closed.
+	OnClose bool
+}
+
+// Snapshotter provides the state of the aggregates of an aggregate type for the SnapshotPolicy. It gets the events of
+// the aggregate as of the valid time of the snapshot, beginning with its most recent snapshot (if any), and returns
+// the state as snapshot event. Class and timestamps of the returned event are set by the eventStore.
+type Snapshotter interface {
+	Snapshot(ctx context.Context, tenantID, aggregateID string, events []IEvent) (IEvent, error)
+}
+
+// SnapshotCoverage describes how far the aggregates of an aggregate type are covered by snapshots.
+type SnapshotCoverage struct {
+	AggregateType string
+	// Aggregates is the number of aggregates, SnapshotAggregates the number of aggregates, which are currently loaded
+	// from a snapshot.
+	Aggregates         int
+	SnapshotAggregates int
+	// EventsSinceSnapshots is the number of events, which are currently loaded after the snapshots (or without a
+	// snapshot) to rebuild all aggregates, MaxEventsSinceSnapshot the maximum of a single aggregate.
+	EventsSinceSnapshots   int
+	MaxEventsSinceSnapshot int
+	// CreatedSnapshots and SkippedSnapshots count the due snapshots of the SnapshotPolicy in this instance of the
+	// eventStore. A due snapshot is skipped, if the aggregate has no patch-free period since its last snapshot or if
+	// the aggregate was modified concurrently.
+	CreatedSnapshots int
+	SkippedSnapshots int
+}
+
+// Ratio returns the share of the aggregates, which are loaded from a snapshot.
+func (c SnapshotCoverage) Ratio() float64 {
+	if c.Aggregates == 0 {
+		return 0
+	}
+	return float64(c.SnapshotAggregates) / float64(c.Aggregates)
+}
+
 type SnapshotManagement interface {
 	GetPatchFreePeriodsForInterval(ctx context.Context, tenantID, aggregateType, aggregateID string, start time.Time, end time.Time) ([]TimeInterval, error)
 	DeleteSnapShots(ctx context.Context, tenantID, aggregateType, aggregateID string, sinceTime time.Time) error
+
+	// GetSnapshotCoverage returns the snapshot coverage of the aggregates of the aggregate type. It loads the current
+	// event streams of all these aggregates.
+	GetSnapshotCoverage(ctx context.Context, tenantID, aggregateType string) (SnapshotCoverage, error)
+	// WaitForSnapshots waits until the snapshots of the snapshot policies, which are created in the background, are
+	// finished (e.g. on shutdown, after the context of eventstore.WithSnapshotContext is canceled). It returns the error
+	// of the context, if it is done before.
+	WaitForSnapshots(ctx context.Context) error
 }
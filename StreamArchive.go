@@ -0,0 +1,29 @@
+package event
+
+import "context"
+
+// The events and snapshots of closed aggregate streams can be moved into an archive, e.g. a cold storage (see
+// eventstore.WithStreamArchive). The aggregate states of archived streams remain in the event store, so that they are
+// still listed (see AggregateManagement) and further writes are still checked against their close time.
+
+type ArchivedStreamStrategy string
+
+const (
+	// RehydrateArchivedStreams strategy: Archived streams are restored from the archive, when they are loaded, when
+	// events are saved into them, or when one of their events (e.g. the close event) is deleted. This is the default
+	// ArchivedStreamStrategy
+	RehydrateArchivedStreams ArchivedStreamStrategy = "rehydrate"
+	// RejectArchivedStreams strategy: Loads, saves and deletes of archived streams fail with ErrorStreamArchived. The
+	// streams must be restored explicitly (see StreamArchiveManagement.RestoreArchivedStream).
+	RejectArchivedStreams ArchivedStreamStrategy = "reject"
+)
+
+type StreamArchiveManagement interface {
+	// ArchiveClosedStreams moves the events and snapshots of all streams (of all tenants), which are closed for longer
+	// than the configured age, into the archive. Each stream is archived in its own transaction. It returns the number
+	// of archived streams.
+	ArchiveClosedStreams(ctx context.Context) (int, error)
+	// RestoreArchivedStream moves the events and snapshots of an archived stream back into the event store. Streams,
+	// which are not archived, are ignored.
+	RestoreArchivedStream(ctx context.Context, tenantID, aggregateType, aggregateID string) error
+}
@@ -0,0 +1,42 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// The events of AggregateManagement.CloseAggregate and AggregateManagement.ReopenAggregate are written into the stream
+// of the aggregate like its own events, i.e. aggregates loaded via LoadAggregateAsAt etc. must accept (or ignore) them.
+// Who closed or reopened the stream is the user of the context (see CtxKeyUserID), and the time of the close or
+// reopen is the valid time and transaction time of the event.
+
+func init() {
+	RegisterEvent(StreamClosed{})
+	RegisterEvent(StreamReopened{})
+}
+
+// StreamClosed is the close event of AggregateManagement.CloseAggregate.
+type StreamClosed struct {
+	Event
+}
+
+// NewStreamClosed creates the close event for the user of the context. A zero valid time closes the stream at the time
+// of saving.
+func NewStreamClosed(ctx context.Context, aggregateID, tenantID string, validTime time.Time) *StreamClosed {
+	evt := &StreamClosed{Event: NewCloseEventWithValidTime(aggregateID, tenantID, validTime)}
+	evt.setUserID(GetUserID(ctx))
+	return evt
+}
+
+// StreamReopened is the reopen event of AggregateManagement.ReopenAggregate.
+type StreamReopened struct {
+	Event
+}
+
+// NewStreamReopened creates the reopen event for the user of the context. A zero valid time reopens the stream at the
+// time of saving.
+func NewStreamReopened(ctx context.Context, aggregateID, tenantID string, validTime time.Time) *StreamReopened {
+	evt := &StreamReopened{Event: NewReopenEventWithValidTime(aggregateID, tenantID, validTime)}
+	evt.setUserID(GetUserID(ctx))
+	return evt
+}
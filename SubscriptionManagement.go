@@ -0,0 +1,23 @@
+package event
+
+import (
+	"context"
+)
+
+// A subscription is a lightweight alternative to a projection. It reads the global event log of a tenant (see
+// LoadFromPosition) and passes the events one by one to a handler. The eventStore persists the position of the last
+// handled event as checkpoint of the subscription, so that a subscription resumes after this checkpoint when it is
+// subscribed again (e.g. after a restart). In contrast to projections, events are not copied into a queue per
+// subscription. The delivery is at least once: if the handling of an event succeeds but the checkpoint can not be
+// saved, the event is delivered again.
+
+type SubscriptionHandler func(ctx context.Context, event PersistenceEvent) error
+
+type SubscriptionManagement interface {
+	// Subscribe blocks and passes all events of the tenant's global event log with one of the given event types (all
+	// events, if no event types are given) to the handler. It starts after the checkpoint of the subscription or after
+	// the position from, if the subscription has no checkpoint yet. If all events are handled, it waits for new ones.
+	// Subscribe returns when the context is canceled (with the context error) or the handler fails (with the handler
+	// error). In the latter case, the checkpoint points to the last successfully handled event.
+	Subscribe(ctx context.Context, tenantID, subscriptionID string, from int64, handler SubscriptionHandler, eventTypes ...string) error
+}
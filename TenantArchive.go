@@ -0,0 +1,96 @@
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// TenantArchiveFormat and TenantArchiveVersion identify the archive of ExportTenant. The archive consists of JSON
+// records, one per line (NDJSON). Each record has a kind and exactly one field with the data of that kind:
+//
+//	{"kind":"header","header":{"format":"go-eventstore/tenant-archive","version":1,"tenantID":"...","exportedAt":"..."}}
+//	{"kind":"aggregate","aggregate":{...}}    the state of an aggregate (see ArchivedAggregate)
+//	{"kind":"event","event":{...}}            an event as PersistenceEvent, ordered by position
+//	{"kind":"snapshot","snapshot":{...}}      a snapshot as PersistenceEvent
+//	{"kind":"projection","projection":{...}}  the state of a projection (see ArchivedProjection)
+//	{"kind":"trailer","trailer":{"aggregates":1,"events":1,"snapshots":1,"projections":1}}
+//
+// The header is the first and the trailer the last record, i.e. an archive without trailer is incomplete. The other
+// records follow in the order above. All times are RFC 3339 timestamps with nanoseconds. Events and snapshots keep
+// their class, so that deleted events (soft deletes) and delete patches are preserved. The data of events with a
+// codec other than JSON stays base64 encoded (see PersistenceEvent.Codec).
+//
+// The version is incremented with each incompatible change of the records. ImportTenant reads all versions up to
+// TenantArchiveVersion.
+const (
+	TenantArchiveFormat  = "go-eventstore/tenant-archive"
+	TenantArchiveVersion = 1
+)
+
+type TenantArchiveRecordKind string
+
+const (
+	TenantArchiveHeader     TenantArchiveRecordKind = "header"
+	TenantArchiveAggregate  TenantArchiveRecordKind = "aggregate"
+	TenantArchiveEvent      TenantArchiveRecordKind = "event"
+	TenantArchiveSnapshot   TenantArchiveRecordKind = "snapshot"
+	TenantArchiveProjection TenantArchiveRecordKind = "projection"
+	TenantArchiveTrailer    TenantArchiveRecordKind = "trailer"
+)
+
+type TenantArchiveRecord struct {
+	Kind       TenantArchiveRecordKind `json:"kind"`
+	Header     *ArchiveHeader          `json:"header,omitempty"`
+	Aggregate  *ArchivedAggregate      `json:"aggregate,omitempty"`
+	Event      *PersistenceEvent       `json:"event,omitempty"`
+	Snapshot   *PersistenceEvent       `json:"snapshot,omitempty"`
+	Projection *ArchivedProjection     `json:"projection,omitempty"`
+	Trailer    *ArchiveTrailer         `json:"trailer,omitempty"`
+}
+
+type ArchiveHeader struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	TenantID   string    `json:"tenantID"`
+	ExportedAt time.Time `json:"exportedAt"`
+}
+
+// Validate checks, if the header belongs to a tenant archive with a supported version.
+func (h ArchiveHeader) Validate() error {
+	if h.Format != TenantArchiveFormat {
+		return fmt.Errorf("unknown archive format %q", h.Format)
+	}
+	if h.Version < 1 || h.Version > TenantArchiveVersion {
+		return fmt.Errorf("unsupported version %d of archive format %q", h.Version, h.Format)
+	}
+	if h.TenantID == "" {
+		return fmt.Errorf("missing tenant in archive header")
+	}
+	return nil
+}
+
+type ArchivedAggregate struct {
+	AggregateType       string    `json:"aggregateType"`
+	AggregateID         string    `json:"aggregateID"`
+	CurrentVersion      int64     `json:"currentVersion"`
+	LastTransactionTime time.Time `json:"lastTransactionTime"`
+	LatestValidTime     time.Time `json:"latestValidTime"`
+	CreateTime          time.Time `json:"createTime"`
+	CloseTime           time.Time `json:"closeTime"`
+}
+
+type ArchivedProjection struct {
+	ProjectionID string    `json:"projectionID"`
+	State        string    `json:"state"`
+	Generation   int       `json:"generation"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// ArchiveTrailer contains the number of records of each kind, so that an incomplete archive is detected during the
+// import.
+type ArchiveTrailer struct {
+	Aggregates  int `json:"aggregates"`
+	Events      int `json:"events"`
+	Snapshots   int `json:"snapshots"`
+	Projections int `json:"projections"`
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type TenantStatus string
+
+const (
+	TenantActive    TenantStatus = "active"
+	TenantSuspended TenantStatus = "suspended"
+)
+
+// Tenant is registered as active with its first save. To offboard it, the tenant is suspended and afterward purged.
+type Tenant struct {
+	TenantID  string
+	Status    TenantStatus
+	CreatedAt time.Time
+	// UpdatedAt is the time of the latest change of the status.
+	UpdatedAt time.Time
+}
+
+type TenantManagement interface {
+	// ExportTenant writes the archive of the tenant as NDJSON into the writer to move it between environments (e.g.
+	// from one database to another). The archive contains the raw data of the tenant, i.e. the aggregate states, all
+	// events (including deleted events and delete patches) with their bi-temporal timestamps, the snapshots and the
+	// projection states (see TenantArchiveFormat). The read models of the projections are not part of the archive and
+	// have to be rebuilt after the import (see ProjectionManagement.RebuildAllProjection). The archive is streamed,
+	// i.e. it is not kept in memory. To compress it, pass a gzip.Writer. Saves of the tenant during the export are not
+	// isolated from it, so the tenant should not be changed in the meantime. Archived streams of the tenant are restored
+	// beforehand (see StreamArchiveManagement).
+	ExportTenant(ctx context.Context, tenantID string, w io.Writer) error
+	// ImportTenant reads an archive of ExportTenant (plain or gzip compressed) and writes it into the store within one
+	// transaction. The tenant of the archive must not contain any aggregates. The events and snapshots are marked as
+	// FromMigration and get the next positions of the tenant in the order of the archive.
+	ImportTenant(ctx context.Context, r io.Reader) error
+
+	// ListTenants returns all tenants of the store ordered by their ID.
+	ListTenants(ctx context.Context) ([]Tenant, error)
+	// SuspendTenant rejects all further saves of the tenant with ErrorTenantSuspended (also in other instances of the
+	// store) and stops the projection workers of the tenant in this instance only. Other instances keep running the
+	// workers of the tenant until they are restarted. Running saves are finished before.
+	SuspendTenant(ctx context.Context, tenantID string) error
+	// ResumeTenant accepts the saves of a suspended tenant again and restarts its projection workers.
+	ResumeTenant(ctx context.Context, tenantID string) error
+	// PurgeTenant deletes all data of a suspended tenant within one transaction, i.e. its events, snapshots, aggregates,
+	// projection states and queued projection events (as well as its subscription checkpoints, outbox messages and
+	// scheduled projection tasks). Afterward, the read models of the projections, which implement ProjectionPurge, are
+	// purged. Because they are not part of the transaction, a failed purge of a read model can be repeated.
+	PurgeTenant(ctx context.Context, tenantID string) error
+}
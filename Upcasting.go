@@ -0,0 +1,134 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Upcasting evolves the schema of persisted events without rewriting them. Each event is stored with the schema
+// version of its data. During the deserialization (see DeserializeEvent), which is also used to pass events to
+// projections, the historical event type is resolved (see RegisterEventAlias) and the data is transformed by the
+// chain of upcasters of the event type, until it has the current schema version.
+
+// InitialSchemaVersion is the schema version of an event type without upcasters (and of events saved without a
+// schema version).
+const InitialSchemaVersion = 1
+
+// Upcaster transforms the data of an event from one schema version into the next schema version. The data is encoded
+// by the serializer of the event (see PersistenceEvent.Codec), i.e. it is JSON by default.
+type Upcaster func(data json.RawMessage) (json.RawMessage, error)
+
+var (
+	upcastersMu  sync.RWMutex
+	upcasters    = make(map[string]map[int]Upcaster) // [eventType][fromSchemaVersion]
+	eventAliases = make(map[string]string)           // [historicalEventType]eventType
+)
+
+// RegisterUpcaster registers the upcaster for the data of event e from the given schema version into the next one.
+// The current schema version of the event type, which is stored with new events, is the schema version after the
+// last upcaster.
+func RegisterUpcaster(e any, fromSchemaVersion int, upcaster Upcaster) {
+	upcastersMu.Lock()
+	defer upcastersMu.Unlock()
+
+	eventType := EventType(e)
+	if upcasters[eventType] == nil {
+		upcasters[eventType] = make(map[int]Upcaster)
+	}
+	upcasters[eventType][fromSchemaVersion] = upcaster
+}
+
+// RegisterEventAlias registers a historical event type of event e, e.g. the event type before the struct was moved
+// into another package or renamed. Events of the historical event type are deserialized into e.
+func RegisterEventAlias(e any, historicalEventType string) {
+	upcastersMu.Lock()
+	defer upcastersMu.Unlock()
+
+	eventAliases[historicalEventType] = EventType(e)
+}
+
+// SchemaVersion returns the current schema version of the event type.
+func SchemaVersion(eventType string) int {
+	upcastersMu.RLock()
+	defer upcastersMu.RUnlock()
+
+	return currentSchemaVersion(eventType)
+}
+
+// persistedSchemaVersion returns the schema version which is stored with new events of the event type. Events of the
+// InitialSchemaVersion are stored without a schema version.
+func persistedSchemaVersion(eventType string) int {
+	if version := SchemaVersion(eventType); version > InitialSchemaVersion {
+		return version
+	}
+	return 0
+}
+
+func currentSchemaVersion(eventType string) int {
+	version := InitialSchemaVersion
+	for from := range upcasters[eventType] {
+		version = max(version, from+1)
+	}
+	return version
+}
+
+// HistoricalEventTypes returns the given event types together with their historical event types (see
+// RegisterEventAlias), e.g. to load all events of a projection.
+func HistoricalEventTypes(eventTypes ...string) []string {
+	upcastersMu.RLock()
+	defer upcastersMu.RUnlock()
+
+	result := append([]string(nil), eventTypes...)
+	for _, eventType := range eventTypes {
+		var aliases []string
+		for historical, current := range eventAliases {
+			if current == eventType {
+				aliases = append(aliases, historical)
+			}
+		}
+		sort.Strings(aliases)
+		result = append(result, aliases...)
+	}
+	return result
+}
+
+// Upcast resolves the historical event type and transforms the data into the current schema version of the event type.
+func Upcast(evt PersistenceEvent) (PersistenceEvent, error) {
+	upcastersMu.RLock()
+	defer upcastersMu.RUnlock()
+
+	if current, ok := eventAliases[evt.Type]; ok {
+		evt.Type = current
+	}
+	if evt.SchemaVersion == 0 {
+		evt.SchemaVersion = InitialSchemaVersion
+	}
+
+	target := currentSchemaVersion(evt.Type)
+	if evt.SchemaVersion >= target {
+		return evt, nil
+	}
+
+	data, err := unwrapData(evt)
+	if err != nil {
+		return evt, fmt.Errorf("upcast of event %q failed: %w", evt.ID, err)
+	}
+	for evt.SchemaVersion < target {
+		upcaster, ok := upcasters[evt.Type][evt.SchemaVersion]
+		if !ok {
+			return evt, fmt.Errorf("upcast of event %q failed: no upcaster for event type %q and schema version %d", evt.ID, evt.Type, evt.SchemaVersion)
+		}
+
+		if data, err = upcaster(data); err != nil {
+			return evt, fmt.Errorf("upcast of event %q failed for event type %q and schema version %d: %w", evt.ID, evt.Type, evt.SchemaVersion, err)
+		}
+		evt.SchemaVersion++
+	}
+	if evt.Data, _, err = wrapData(evt.Codec, data); err != nil {
+		return evt, fmt.Errorf("upcast of event %q failed: %w", evt.ID, err)
+	}
+
+	return evt, nil
+}
@@ -2,6 +2,7 @@ package eventstore
 
 import (
 	"context"
+	"fmt"
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
 	"time"
@@ -21,16 +22,47 @@ func (e eventStore) GetAggregateState(ctx context.Context, tenantID, aggregateTy
 	return e.loader.GetAggregateStates(ctx, tenantID, aggregateType, aggregateID)
 }
 
-func (e eventStore) GetAggregateStatesForAggregateType(ctx context.Context, tenantID string, aggregateType string) ([]event.AggregateState, error) {
+func (e eventStore) GetAggregateStatesForAggregateType(ctx context.Context, tenantID string, aggregateType string, status ...event.StreamStatus) ([]event.AggregateState, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "GetAggregateStatesForAggregateType (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType})
 	defer endSpan()
 
-	return e.loader.GetAllAggregateStatesForAggregateType(ctx, tenantID, aggregateType)
+	return e.loader.GetAllAggregateStatesForAggregateType(ctx, tenantID, aggregateType, status...)
 }
 
-func (e eventStore) GetAggregateStatesForAggregateTypeTill(ctx context.Context, tenantID string, aggregateType string, until time.Time) ([]event.AggregateState, error) {
+func (e eventStore) GetAggregateStatesForAggregateTypeTill(ctx context.Context, tenantID string, aggregateType string, until time.Time, status ...event.StreamStatus) ([]event.AggregateState, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "GetAggregateStatesForAggregateType (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType})
 	defer endSpan()
 
-	return e.loader.GetAllAggregateStatesForAggregateTypeTill(ctx, tenantID, aggregateType, until)
+	return e.loader.GetAllAggregateStatesForAggregateTypeTill(ctx, tenantID, aggregateType, until, status...)
+}
+
+func (e eventStore) CloseAggregate(ctx context.Context, tenantID, aggregateType, aggregateID string, validTime time.Time) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "CloseAggregate (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "aggregateID": aggregateID})
+	defer endSpan()
+
+	if err := e.saveLifecycleEvent(ctx, tenantID, aggregateType, event.NewStreamClosed(ctx, aggregateID, tenantID, validTime), event.ExpectAny()); err != nil {
+		return fmt.Errorf("CloseAggregate failed for tenant %q and aggregate %q:%w", tenantID, aggregateID, err)
+	}
+	return nil
+}
+
+func (e eventStore) ReopenAggregate(ctx context.Context, tenantID, aggregateType, aggregateID string, validTime time.Time) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "ReopenAggregate (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "aggregateID": aggregateID})
+	defer endSpan()
+
+	if err := e.saveLifecycleEvent(ctx, tenantID, aggregateType, event.NewStreamReopened(ctx, aggregateID, tenantID, validTime), event.ExpectClosed()); err != nil {
+		return fmt.Errorf("ReopenAggregate failed for tenant %q and aggregate %q:%w", tenantID, aggregateID, err)
+	}
+	return nil
+}
+
+// saveLifecycleEvent saves the close or reopen event like any other event of the aggregate, i.e. the snapshot policies
+// (e.g. event.SnapshotPolicy.OnClose) apply as well.
+func (e eventStore) saveLifecycleEvent(ctx context.Context, tenantID, aggregateType string, evt event.IEvent, expect event.Expectation) error {
+	persistenceEvent, err := event.NewPersistenceEvent(ctx, evt, aggregateType)
+	if err != nil {
+		return err
+	}
+	_, err = e.SaveAll(ctx, tenantID, []event.PersistenceEvents{{Events: []event.PersistenceEvent{persistenceEvent}, Expect: expect}})
+	return err
 }
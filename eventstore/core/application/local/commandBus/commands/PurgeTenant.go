@@ -0,0 +1,20 @@
+package commands
+
+func CmdPurgeTenant(tenantID string) PurgeTenant {
+	return PurgeTenant{
+		id: tenantID,
+	}
+}
+
+// PurgeTenant removes the remaining data of a purged tenant outside the event store (e.g. the read models).
+type PurgeTenant struct {
+	id string
+}
+
+func (e PurgeTenant) Name() string {
+	return "cmd.tenant.purge"
+}
+
+func (e PurgeTenant) ID() string {
+	return e.id
+}
@@ -0,0 +1,20 @@
+package commands
+
+func CmdResumeTenant(tenantID string) ResumeTenant {
+	return ResumeTenant{
+		id: tenantID,
+	}
+}
+
+// ResumeTenant restarts the processing (e.g. the scheduled projection tasks) of a resumed tenant.
+type ResumeTenant struct {
+	id string
+}
+
+func (e ResumeTenant) Name() string {
+	return "cmd.tenant.resume"
+}
+
+func (e ResumeTenant) ID() string {
+	return e.id
+}
@@ -0,0 +1,20 @@
+package commands
+
+func CmdStopTenant(tenantID string) StopTenant {
+	return StopTenant{
+		id: tenantID,
+	}
+}
+
+// StopTenant stops the processing (e.g. the projection workers) of a suspended tenant.
+type StopTenant struct {
+	id string
+}
+
+func (e StopTenant) Name() string {
+	return "cmd.tenant.stop"
+}
+
+func (e StopTenant) ID() string {
+	return e.id
+}
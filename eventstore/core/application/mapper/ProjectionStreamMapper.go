@@ -3,6 +3,7 @@ package mapper
 import (
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
+	projPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
 )
 
 func MapStreamsToProjectionStates(streams []projection.Stream) []event.ProjectionState {
@@ -27,6 +28,24 @@ func MapStreamToProjectionState(stream projection.Stream) event.ProjectionState
 		InputQueueLength:        stream.Options().InputQueueLength,
 		ProjectionType:          stream.Options().ProjectionType,
 		RetryDurations:          stream.Options().RetryDurations,
+		Quarantine:              stream.Options().Quarantine,
+		Lanes:                   stream.Options().Lanes,
+		Generation:              stream.Generation().Number,
 	}
 
 }
+
+func MapStreamToProjectionHealth(stream projection.Stream, statistics projPort.QueueStatistics, workerQueueFill, workerQueueLength int) event.ProjectionHealth {
+	return event.ProjectionHealth{
+		TenantID:            stream.ID().TenantID,
+		ProjectionID:        stream.ID().ProjectionID,
+		State:               string(stream.State()),
+		QueuedEvents:        statistics.QueuedEvents,
+		OldestQueuedEvent:   statistics.OldestTransactionTime,
+		LastSuccessfulRun:   stream.LastRunAt(),
+		LastError:           stream.LastError(),
+		ConsecutiveFailures: stream.Failures(),
+		WorkerQueueFill:     workerQueueFill,
+		WorkerQueueLength:   workerQueueLength,
+	}
+}
@@ -73,3 +73,22 @@ func (r Registry) SetDeleteStrategy(aggregateType string, strategy event.DeleteS
 	}
 	return nil
 }
+
+func (r Registry) SetSnapshotPolicy(aggregateType string, snapshotter event.Snapshotter, policy event.SnapshotPolicy) error {
+	switch {
+	case snapshotter == nil:
+		return fmt.Errorf("no snapshotter for snapshot policy of aggregate type %s given", aggregateType)
+	case policy.EveryNEvents < 0 || policy.Every < 0:
+		return fmt.Errorf("invalid snapshot policy %+v for aggregate type %s", policy, aggregateType)
+	case policy.EveryNEvents == 0 && policy.Every == 0 && !policy.OnClose:
+		return fmt.Errorf("snapshot policy of aggregate type %s has no condition", aggregateType)
+	}
+
+	currOptions := r.currentOrDefaultOptions(aggregateType)
+	currOptions.SnapshotPolicy = policy
+	currOptions.Snapshotter = snapshotter
+	if err := kvTable2.Set(r.options, kvTable2.NewKey(aggregateType), currOptions); err != nil {
+		return fmt.Errorf("could not store snapshot policy options: %w", err)
+	}
+	return nil
+}
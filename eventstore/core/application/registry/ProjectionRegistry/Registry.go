@@ -21,6 +21,9 @@ const (
 
 	// number of possible parallel requests for projection execution
 	defaultProjectionWorkerInputQueue = 100
+
+	// number of lanes in which an eventual consistent single stream projection is executed in parallel
+	defaultProjectionLanes = 1
 )
 
 var defaultOptions = projection.Options{
@@ -32,6 +35,7 @@ var defaultOptions = projection.Options{
 	RebuildExecutionTimeOut: defaultProjectionExecutionTimeOut,
 	InputQueueLength:        defaultProjectionWorkerInputQueue,
 	ProjectionType:          defaultProjectionType,
+	Lanes:                   defaultProjectionLanes,
 }
 
 func NewRegistry() *Registry {
@@ -158,6 +162,33 @@ func (r Registry) SetWorkerQueueLength(projectionID string, length int) error {
 	return nil
 }
 
+func (r Registry) SetRetryDurations(projectionID string, durations []time.Duration) error {
+	currOptions := r.currentOrDefaultOptions(projectionID)
+	currOptions.RetryDurations = durations
+	if err := kvTable2.Set(r.options, kvTable2.NewKey(projectionID), currOptions); err != nil {
+		return fmt.Errorf("could not store projection options: %w", err)
+	}
+	return nil
+}
+
+func (r Registry) SetQuarantine(projectionID string, quarantine bool) error {
+	currOptions := r.currentOrDefaultOptions(projectionID)
+	currOptions.Quarantine = quarantine
+	if err := kvTable2.Set(r.options, kvTable2.NewKey(projectionID), currOptions); err != nil {
+		return fmt.Errorf("could not store projection options: %w", err)
+	}
+	return nil
+}
+
+func (r Registry) SetLanes(projectionID string, lanes int) error {
+	currOptions := r.currentOrDefaultOptions(projectionID)
+	currOptions.Lanes = lanes
+	if err := kvTable2.Set(r.options, kvTable2.NewKey(projectionID), currOptions); err != nil {
+		return fmt.Errorf("could not store projection options: %w", err)
+	}
+	return nil
+}
+
 func (r Registry) ForEventTypes(eventTypes ...string) []string {
 	var result []string
 	uniqueIds := make(map[string]struct{})
@@ -5,6 +5,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/registry/ProjectionRegistry"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/registry/TenantRegistry"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/registry/WorkerRegistry"
+	"github.com/google/uuid"
 )
 
 func NewRegistries() *Registries {
@@ -13,6 +14,7 @@ func NewRegistries() *Registries {
 		ProjectionRegistry: ProjectionRegistry.NewRegistry(),
 		TenantRegistry:     TenantRegistry.NewRegistry(),
 		WorkerRegistry:     WorkerRegistry.NewRegistry(),
+		InstanceID:         uuid.NewString(),
 	}
 }
 
@@ -21,4 +23,8 @@ type Registries struct {
 	ProjectionRegistry *ProjectionRegistry.Registry
 	TenantRegistry     *TenantRegistry.Registry
 	WorkerRegistry     *WorkerRegistry.Registry
+
+	// InstanceID identifies this instance of the event store, e.g. to distinguish between own notifications and
+	// notifications of other instances (pods).
+	InstanceID string
 }
@@ -6,12 +6,14 @@ import (
 
 func NewRegistry() *Registry {
 	return &Registry{
-		tenants: kvTable.NewKeyValuesTable[string](),
+		tenants:   kvTable.NewKeyValuesTable[string](),
+		suspended: kvTable.NewKeyValuesTable[string](),
 	}
 }
 
 type Registry struct {
-	tenants kvTable.IKVTable[string]
+	tenants   kvTable.IKVTable[string]
+	suspended kvTable.IKVTable[string]
 }
 
 func (r Registry) Exists(tenantID string) bool {
@@ -20,5 +22,21 @@ func (r Registry) Exists(tenantID string) bool {
 }
 
 func (r Registry) Register(tenantID string) error {
+	if err := kvTable.Del(r.suspended, kvTable.NewKey(tenantID)); err != nil {
+		return err
+	}
 	return kvTable.Add(r.tenants, kvTable.NewKey(tenantID), tenantID)
 }
+
+// Suspend unregisters the tenant and marks it as suspended, until it is registered again.
+func (r Registry) Suspend(tenantID string) error {
+	if err := kvTable.Del(r.tenants, kvTable.NewKey(tenantID)); err != nil {
+		return err
+	}
+	return kvTable.Set(r.suspended, kvTable.NewKey(tenantID), tenantID)
+}
+
+func (r Registry) IsSuspended(tenantID string) bool {
+	_, err := kvTable.Get(r.suspended, kvTable.NewKey(tenantID))
+	return err == nil
+}
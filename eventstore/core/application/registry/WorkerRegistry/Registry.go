@@ -5,6 +5,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared/kvTable"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared/rateWorker"
+	"strconv"
 )
 
 const (
@@ -21,7 +22,7 @@ func NewRegistry() *Registry {
 }
 
 type Registry struct {
-	workers kvTable.IKVTable[chan rateWorker.ExecutionParams[error]] //key[tenantID][projectionID]
+	workers kvTable.IKVTable[chan rateWorker.ExecutionParams[error]] //key[tenantID][projectionID] or key[tenantID][projectionID][lane]
 }
 
 func (r Registry) Delete(id shared.ProjectionID) error {
@@ -33,7 +34,16 @@ func (r Registry) Delete(id shared.ProjectionID) error {
 }
 
 func (r Registry) Queue(id shared.ProjectionID) (chan rateWorker.ExecutionParams[error], error) {
-	resCh, err := kvTable.GetFirst(r.workers, kvTable.NewKey(id.TenantID, id.ProjectionID))
+	return r.queue(id, kvTable.NewKey(id.TenantID, id.ProjectionID))
+}
+
+// LaneQueue returns the input queue of the worker of a single lane of the projection (see CreateAndStartLane).
+func (r Registry) LaneQueue(id shared.ProjectionID, lane int) (chan rateWorker.ExecutionParams[error], error) {
+	return r.queue(id, laneKey(id, lane))
+}
+
+func (r Registry) queue(id shared.ProjectionID, key kvTable.Key) (chan rateWorker.ExecutionParams[error], error) {
+	resCh, err := kvTable.GetFirst(r.workers, key)
 	if err != nil {
 		if kvTable.IsKeyNotFound(err) {
 			return nil, err
@@ -43,17 +53,47 @@ func (r Registry) Queue(id shared.ProjectionID) (chan rateWorker.ExecutionParams
 	return resCh, err
 }
 
+// QueueFill returns the number of pending execution requests in the input queues of the workers of the projection
+// (including the workers of its lanes) and the capacity of these queues.
+func (r Registry) QueueFill(id shared.ProjectionID) (fill int, length int) {
+	queues, _ := kvTable.GetPartial(r.workers, kvTable.NewKey(id.TenantID, id.ProjectionID, kvTable.KeyWildcardString))
+	if queue, _ := r.Queue(id); queue != nil {
+		queues = append(queues, queue)
+	}
+
+	for _, queue := range queues {
+		fill += len(queue)
+		length += cap(queue)
+	}
+	return fill, length
+}
+
 func (r Registry) CreateAndStart(id shared.ProjectionID, inputQueueLength int) error {
 	//one worker per tenant and projection
 	if r.Exists(id) {
 		return fmt.Errorf("worker already exists for projection %q and tenant %q", id.ProjectionID, id.TenantID)
 	}
 
+	return r.createAndStart(id, kvTable.NewKey(id.TenantID, id.ProjectionID), inputQueueLength)
+}
+
+// CreateAndStartLane starts an additional worker for a single lane of a projection, whose queue is partitioned into
+// lanes (see projection.Options.QueueLanes).
+func (r Registry) CreateAndStartLane(id shared.ProjectionID, lane int, inputQueueLength int) error {
+	//one worker per tenant, projection and lane
+	if ch, _ := r.LaneQueue(id, lane); ch != nil {
+		return fmt.Errorf("worker already exists for lane %d of projection %q and tenant %q", lane, id.ProjectionID, id.TenantID)
+	}
+
+	return r.createAndStart(id, laneKey(id, lane), inputQueueLength)
+}
+
+func (r Registry) createAndStart(id shared.ProjectionID, key kvTable.Key, inputQueueLength int) error {
 	inputCh := make(chan rateWorker.ExecutionParams[error], inputQueueLength)
 	rateLimitedWorker := rateWorker.NewRateLimitedWorker[error](defaultProjectionRateLimit, inputCh)
 
 	//save input queue
-	if err := kvTable.Set(r.workers, kvTable.NewKey(id.TenantID, id.ProjectionID), inputCh); err != nil {
+	if err := kvTable.Set(r.workers, key, inputCh); err != nil {
 		return fmt.Errorf("saving failed for worker %q: %w", id, err)
 	}
 
@@ -61,21 +101,28 @@ func (r Registry) CreateAndStart(id shared.ProjectionID, inputQueueLength int) e
 	return nil
 }
 
-func (r Registry) Shutdown(id shared.ProjectionID) error {
-	if r.Exists(id) {
-		return fmt.Errorf("worker for potjection %q doesn't exists", id)
+// Shutdown stops the worker of the projection and the workers of its lanes (see CreateAndStartLane).
+func (r Registry) Shutdown(id shared.ProjectionID, lanes int) error {
+	if !r.Exists(id) {
+		return fmt.Errorf("worker for projection %q doesn't exists", id)
 	}
-	existingCh, err := r.Queue(id)
-	if err != nil {
-		return err
+
+	keys := []kvTable.Key{kvTable.NewKey(id.TenantID, id.ProjectionID)}
+	for lane := 0; lanes > 1 && lane < lanes; lane++ {
+		keys = append(keys, laneKey(id, lane))
 	}
-	close(existingCh)
+	for _, key := range keys {
+		existingCh, err := kvTable.GetFirst(r.workers, key)
+		if err != nil {
+			return fmt.Errorf("could not find worker queue for projection %q: %w", id, err)
+		}
+		close(existingCh)
 
-	err = r.Delete(id)
-	if err != nil {
-		return fmt.Errorf("could not delete worker queue for porjection %q: %w", id, err)
+		if err = kvTable.Del(r.workers, key); err != nil {
+			return fmt.Errorf("could not delete worker queue for projection %q: %w", id, err)
+		}
 	}
-	return err
+	return nil
 }
 
 func (r Registry) Exists(id shared.ProjectionID) bool {
@@ -85,3 +132,7 @@ func (r Registry) Exists(id shared.ProjectionID) bool {
 	}
 	return false
 }
+
+func laneKey(id shared.ProjectionID, lane int) kvTable.Key {
+	return kvTable.NewKey(id.TenantID, id.ProjectionID, strconv.Itoa(lane))
+}
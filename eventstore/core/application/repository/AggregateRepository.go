@@ -9,6 +9,7 @@ import (
 	aggPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"iter"
 	"sort"
 	"time"
 )
@@ -24,39 +25,115 @@ type AggregateRepository struct {
 }
 
 func (a AggregateRepository) LoadAsAt(txCtx context.Context, id shared.AggregateID, projectionTime time.Time) (events event.PersistenceEvents, err error) {
-	return a.port.LoadAsAt(txCtx, projectionTime, id)
+	return removeRevisionDeletes(a.port.LoadAsAt(txCtx, projectionTime, id))
 }
 
 func (a AggregateRepository) LoadAsOf(txCtx context.Context, id shared.AggregateID, projectionTime time.Time) (events event.PersistenceEvents, err error) {
-	return a.port.LoadAsOf(txCtx, projectionTime, id)
+	return removeRevisionDeletes(a.port.LoadAsOf(txCtx, projectionTime, id))
 }
 
 func (a AggregateRepository) LoadAsOfTill(txCtx context.Context, id shared.AggregateID, projectionTime, reportTime time.Time) (events event.PersistenceEvents, err error) {
-	return a.port.LoadAsOfTill(txCtx, projectionTime, reportTime, id)
+	return removeRevisionDeletes(a.port.LoadAsOfTill(txCtx, projectionTime, reportTime, id))
 }
 
 func (a AggregateRepository) LoadAllOfAggregateAsAt(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
-	return a.port.LoadAllOfAggregateAsAt(txCtx, tenantID, aggregateType, projectionTime)
+	return removeRevisionDeletesOfAll(a.port.LoadAllOfAggregateAsAt(txCtx, tenantID, aggregateType, projectionTime))
 }
 
 func (a AggregateRepository) LoadAllOfAggregateAsOf(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
-	return a.port.LoadAllOfAggregateAsOf(txCtx, tenantID, aggregateType, projectionTime)
+	return removeRevisionDeletesOfAll(a.port.LoadAllOfAggregateAsOf(txCtx, tenantID, aggregateType, projectionTime))
 }
 
 func (a AggregateRepository) LoadAllOfAggregateAsOfTill(txCtx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
-	return a.port.LoadAllOfAggregateAsOfTill(txCtx, tenantID, aggregateType, projectionTime, reportTime)
+	return removeRevisionDeletesOfAll(a.port.LoadAllOfAggregateAsOfTill(txCtx, tenantID, aggregateType, projectionTime, reportTime))
+}
+
+func (a AggregateRepository) LoadAllOfAggregateAsOfRange(txCtx context.Context, tenantID, aggregateType string, from, till time.Time) (eventStreams []event.PersistenceEvents, err error) {
+	return removeRevisionDeletesOfAll(a.port.LoadAllOfAggregateAsOfRange(txCtx, tenantID, aggregateType, from, till))
 }
 
 func (a AggregateRepository) LoadAllAsAt(txCtx context.Context, tenantID string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
-	return a.port.LoadAllAsAt(txCtx, tenantID, projectionTime)
+	return removeRevisionDeletesOfAll(a.port.LoadAllAsAt(txCtx, tenantID, projectionTime))
 }
 
 func (a AggregateRepository) LoadAllAsOf(txCtx context.Context, tenantID string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
-	return a.port.LoadAllAsOf(txCtx, tenantID, projectionTime)
+	return removeRevisionDeletesOfAll(a.port.LoadAllAsOf(txCtx, tenantID, projectionTime))
 }
 
 func (a AggregateRepository) LoadAllAsOfTill(txCtx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
-	return a.port.LoadAllAsOfTill(txCtx, tenantID, projectionTime, reportTime)
+	return removeRevisionDeletesOfAll(a.port.LoadAllAsOfTill(txCtx, tenantID, projectionTime, reportTime))
+}
+
+func (a AggregateRepository) LoadEventsAsOfTill(txCtx context.Context, id shared.AggregateID, projectionTime, reportTime time.Time) (events []event.PersistenceEvent, err error) {
+	return a.port.LoadEventsAsOfTill(txCtx, projectionTime, reportTime, id)
+}
+
+func (a AggregateRepository) LoadAllEventsOfAggregateAsOfTill(txCtx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) (events []event.PersistenceEvent, err error) {
+	return a.port.LoadAllEventsOfAggregateAsOfTill(txCtx, tenantID, aggregateType, projectionTime, reportTime)
+}
+
+func (a AggregateRepository) StreamAllOfAggregateAsAt(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return removeRevisionDeletesOfStream(a.port.StreamAllOfAggregateAsAt(txCtx, tenantID, aggregateType, projectionTime))
+}
+
+func (a AggregateRepository) StreamAllOfAggregateAsOf(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return removeRevisionDeletesOfStream(a.port.StreamAllOfAggregateAsOf(txCtx, tenantID, aggregateType, projectionTime))
+}
+
+func (a AggregateRepository) StreamAllOfAggregateAsOfTill(txCtx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return removeRevisionDeletesOfStream(a.port.StreamAllOfAggregateAsOfTill(txCtx, tenantID, aggregateType, projectionTime, reportTime))
+}
+
+func (a AggregateRepository) StreamAllAsAt(txCtx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return removeRevisionDeletesOfStream(a.port.StreamAllAsAt(txCtx, tenantID, projectionTime))
+}
+
+func (a AggregateRepository) StreamAllAsOf(txCtx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return removeRevisionDeletesOfStream(a.port.StreamAllAsOf(txCtx, tenantID, projectionTime))
+}
+
+func (a AggregateRepository) StreamAllAsOfTill(txCtx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return removeRevisionDeletesOfStream(a.port.StreamAllAsOfTill(txCtx, tenantID, projectionTime, reportTime))
+}
+
+// removeRevisionDeletes hides the tombstones of the RevisionDelete strategy and the events deleted by them. The
+// version of the stream stays untouched, since the tombstones are versioned events.
+func removeRevisionDeletes(events event.PersistenceEvents, err error) (event.PersistenceEvents, error) {
+	if err != nil {
+		return events, err
+	}
+	events.Events = aggregate.RemoveRevisionDeletes(events.Events)
+	return events, nil
+}
+
+func removeRevisionDeletesOfAll(eventStreams []event.PersistenceEvents, err error) ([]event.PersistenceEvents, error) {
+	if err != nil {
+		return eventStreams, err
+	}
+	for i := range eventStreams {
+		eventStreams[i].Events = aggregate.RemoveRevisionDeletes(eventStreams[i].Events)
+	}
+	return eventStreams, nil
+}
+
+func removeRevisionDeletesOfStream(eventStreams iter.Seq2[event.PersistenceEvents, error]) iter.Seq2[event.PersistenceEvents, error] {
+	return func(yield func(event.PersistenceEvents, error) bool) {
+		for eventStream, err := range eventStreams {
+			if err == nil {
+				eventStream.Events = aggregate.RemoveRevisionDeletes(eventStream.Events)
+			}
+			if !yield(eventStream, err) {
+				return
+			}
+		}
+	}
+}
+
+func (a AggregateRepository) LoadFromPosition(txCtx context.Context, tenantID string, position int64, limit int) (events []event.PersistenceEvent, err error) {
+	if limit <= 0 || limit > aggPort.MaxLimitLoadFromPosition {
+		return nil, fmt.Errorf("LoadFromPosition failed: limit %d must be between 1 and %d", limit, aggPort.MaxLimitLoadFromPosition)
+	}
+	return a.port.LoadFromPosition(txCtx, tenantID, position, limit)
 }
 
 func (a AggregateRepository) GetAggregateState(txCtx context.Context, tenantID, aggregateType, aggregateID string) (event.AggregateState, error) {
@@ -243,6 +320,10 @@ func (a AggregateRepository) GetAggregatesEvents(ctx context.Context, tenantID s
 	return a.port.GetAggregatesEvents(ctx, tenantID, page)
 }
 
+func (a AggregateRepository) GetLatestTransactionTime(txCtx context.Context) (time.Time, error) {
+	return a.port.GetLatestTransactionTime(txCtx)
+}
+
 func (a AggregateRepository) DeleteEvent(txCtx context.Context, id shared.AggregateID, evt event.PersistenceEvent) error {
 	txCtx, endSpan := metrics.StartSpan(txCtx, "DeletePatch (repository)", map[string]interface{}{"tenantID": id.TenantID, "aggregateType": id.AggregateType, "aggregateID": id.AggregateID})
 	defer endSpan()
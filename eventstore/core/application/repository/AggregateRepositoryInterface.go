@@ -5,6 +5,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/aggregate"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"iter"
 	"time"
 )
 
@@ -21,10 +22,22 @@ type LoaderInterface interface {
 	LoadAllOfAggregateAsAt(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error)
 	LoadAllOfAggregateAsOf(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error)
 	LoadAllOfAggregateAsOfTill(txCtx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) (eventStreams []event.PersistenceEvents, err error)
+	LoadAllOfAggregateAsOfRange(txCtx context.Context, tenantID, aggregateType string, from, till time.Time) (eventStreams []event.PersistenceEvents, err error)
 
 	LoadAllAsAt(txCtx context.Context, tenantID string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error)
 	LoadAllAsOf(txCtx context.Context, tenantID string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error)
 	LoadAllAsOfTill(txCtx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) (eventStreams []event.PersistenceEvents, err error)
+	LoadFromPosition(txCtx context.Context, tenantID string, position int64, limit int) (events []event.PersistenceEvent, err error)
+	LoadEventsAsOfTill(txCtx context.Context, id shared.AggregateID, projectionTime, reportTime time.Time) (events []event.PersistenceEvent, err error)
+	LoadAllEventsOfAggregateAsOfTill(txCtx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) (events []event.PersistenceEvent, err error)
+
+	StreamAllOfAggregateAsAt(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllOfAggregateAsOf(txCtx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllOfAggregateAsOfTill(txCtx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+
+	StreamAllAsAt(txCtx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllAsOf(txCtx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllAsOfTill(txCtx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error]
 
 	GetAggregateState(txCtx context.Context, tenantID, aggregateType, aggregateID string) (event.AggregateState, error)
 	GetAggregateStatesForAggregateType(txCtx context.Context, tenantID string, aggregateType string) ([]event.AggregateState, error)
@@ -33,6 +46,7 @@ type LoaderInterface interface {
 	GetPatchFreePeriodsForInterval(txCtx context.Context, tenantID, aggregateType, aggregateID string, start time.Time, end time.Time) ([]event.TimeInterval, error)
 
 	GetAggregatesEvents(txCtx context.Context, tenantID string, page event.PageDTO) (events []event.PersistenceEvent, pages event.PagesDTO, err error)
+	GetLatestTransactionTime(txCtx context.Context) (time.Time, error)
 }
 
 type SaverInterface interface {
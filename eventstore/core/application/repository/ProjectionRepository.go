@@ -8,7 +8,9 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
 	projPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"slices"
 	"time"
 )
 
@@ -38,20 +40,84 @@ func (p ProjectionRepository) GetProjectionIDsForEventTypes(tenantID string, eve
 	return eventualConsistent, consistent, nil
 }
 
+// Lock locks the projections. The projections with a queue partitioned into lanes are locked together with all their
+// lanes, so that no lane is executed in the meantime (see LockLane). If a lane cannot be locked, the locks taken so far
+// are released, because the callers only unlock after a successful lock.
 func (p ProjectionRepository) Lock(txCtx context.Context, ids ...shared.ProjectionID) error {
 	if err := p.projPort.Lock(txCtx, ids...); err != nil {
 		return fmt.Errorf("lock of projections %v failed: %w", ids, err)
 	}
+	for i, id := range ids {
+		for lane := 0; lane < p.lanes(id); lane++ {
+			if err := p.projPort.LockLane(txCtx, id, lane); err != nil {
+				p.releaseLocks(txCtx, ids, ids[:i], id, lane)
+				return fmt.Errorf("lock of lane %d of projection %v failed: %w", lane, id, err)
+			}
+		}
+	}
 	return nil
 }
 
+// releaseLocks releases the locks of a failed Lock, i.e. all lanes of the completely locked projections, the lanes
+// before the failed lane of the current projection and the projections themselves.
+func (p ProjectionRepository) releaseLocks(txCtx context.Context, ids, completed []shared.ProjectionID, current shared.ProjectionID, failedLane int) {
+	for _, id := range completed {
+		for lane := 0; lane < p.lanes(id); lane++ {
+			if err := p.projPort.UnLockLane(txCtx, id, lane); err != nil {
+				logger.Error(fmt.Errorf("unlock of lane %d of projection %v failed: %w", lane, id, err))
+			}
+		}
+	}
+	for lane := 0; lane < failedLane; lane++ {
+		if err := p.projPort.UnLockLane(txCtx, current, lane); err != nil {
+			logger.Error(fmt.Errorf("unlock of lane %d of projection %v failed: %w", lane, current, err))
+		}
+	}
+	if err := p.projPort.UnLock(txCtx, ids...); err != nil {
+		logger.Error(fmt.Errorf("unlock of projections %v failed: %w", ids, err))
+	}
+}
+
 func (p ProjectionRepository) UnLock(txCtx context.Context, ids ...shared.ProjectionID) error {
+	for _, id := range ids {
+		for lane := 0; lane < p.lanes(id); lane++ {
+			if err := p.projPort.UnLockLane(txCtx, id, lane); err != nil {
+				return fmt.Errorf("unlock of lane %d of projection %v failed: %w", lane, id, err)
+			}
+		}
+	}
 	if err := p.projPort.UnLock(txCtx, ids...); err != nil {
 		return fmt.Errorf("unlock of projections %v failed: %w", ids, err)
 	}
 	return nil
 }
 
+// LockLane locks a single lane of the projection. The lanes of a projection are executed independently of each other.
+func (p ProjectionRepository) LockLane(txCtx context.Context, id shared.ProjectionID, lane int) error {
+	if err := p.projPort.LockLane(txCtx, id, lane); err != nil {
+		return fmt.Errorf("lock of lane %d of projection %v failed: %w", lane, id, err)
+	}
+	return nil
+}
+
+func (p ProjectionRepository) UnLockLane(txCtx context.Context, id shared.ProjectionID, lane int) error {
+	if err := p.projPort.UnLockLane(txCtx, id, lane); err != nil {
+		return fmt.Errorf("unlock of lane %d of projection %v failed: %w", lane, id, err)
+	}
+	return nil
+}
+
+// lanes returns the number of lanes of the projection. The shadow of a blue/green rebuild is not partitioned into lanes.
+func (p ProjectionRepository) lanes(id shared.ProjectionID) int {
+	if projection.IsShadowID(id.ProjectionID) {
+		return 0
+	}
+	if lanes := p.registries.ProjectionRegistry.Options(id.ProjectionID).QueueLanes(); lanes > 1 {
+		return lanes
+	}
+	return 0
+}
+
 func (p ProjectionRepository) Create(ctx context.Context, projectionIDs ...shared.ProjectionID) (streams []projection.Stream, err error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "Create projections (repository)", map[string]interface{}{"numberOfProjections": len(projectionIDs)})
 	defer endSpan()
@@ -123,20 +189,33 @@ func (p ProjectionRepository) GetAllForTenant(txCtx context.Context, tenantID st
 		return nil, fmt.Errorf("getAllForTenant() projections failed for %q :%w", tenantID, err)
 	}
 
-	return p.mapToProjectionStream(txCtx, dtos)
+	return p.mapToProjectionStream(txCtx, withoutShadows(dtos))
 }
 
 func (p ProjectionRepository) GetWithNewEventsSinceLastRun(txCtx context.Context, id shared.ProjectionID) (projection.Stream, error) {
 	txCtx, endSpan := metrics.StartSpan(txCtx, "GetWithNewEventsSinceLastRun (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID})
 	defer endSpan()
 
-	opt := p.registries.ProjectionRegistry.Options(id.ProjectionID)
-	project, err := p.registries.ProjectionRegistry.Projection(id.ProjectionID)
+	return p.getWithNewEventsSinceLastRun(txCtx, id, projPort.LoadOptions{})
+}
+
+// GetWithNewEventsOfLaneSinceLastRun returns the stream with the new events of the aggregates of a single lane.
+func (p ProjectionRepository) GetWithNewEventsOfLaneSinceLastRun(txCtx context.Context, id shared.ProjectionID, lane int) (projection.Stream, error) {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "GetWithNewEventsOfLaneSinceLastRun (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID, "lane": lane})
+	defer endSpan()
+
+	return p.getWithNewEventsSinceLastRun(txCtx, id, projPort.LoadOptions{Lane: lane, Lanes: p.registries.ProjectionRegistry.Options(id.ProjectionID).QueueLanes()})
+}
+
+func (p ProjectionRepository) getWithNewEventsSinceLastRun(txCtx context.Context, id shared.ProjectionID, loadOpt projPort.LoadOptions) (projection.Stream, error) {
+	opt := p.registries.ProjectionRegistry.Options(projection.LiveID(id.ProjectionID))
+	project, err := p.registries.ProjectionRegistry.Projection(projection.LiveID(id.ProjectionID))
 	if err != nil {
 		return projection.Stream{}, fmt.Errorf("GetWithNewEventsSinceLastRun() retrieve from registry failed for  %q :%w", id, err)
 	}
 
-	dto, err := p.projPort.GetSinceLastRun(txCtx, id, projPort.LoadOptions{ChunkSize: project.ChunkSize()})
+	loadOpt.ChunkSize = project.ChunkSize()
+	dto, err := p.projPort.GetSinceLastRun(txCtx, id, loadOpt)
 	if err != nil {
 		return projection.Stream{}, fmt.Errorf("GetWithNewEventsSinceLastRun() retrieve events failed for  %q :%w", id, err)
 	}
@@ -149,7 +228,7 @@ func (p ProjectionRepository) GetAllForAllTenants(txCtx context.Context) ([]proj
 	if err != nil {
 		return nil, fmt.Errorf("GetAllForAllTenants() retrieve projections failed :%w", err)
 	}
-	return p.mapToProjectionStream(txCtx, dtos)
+	return p.mapToProjectionStream(txCtx, withoutShadows(dtos))
 }
 
 func (p ProjectionRepository) SaveStates(txCtx context.Context, streams ...projection.Stream) error {
@@ -162,6 +241,7 @@ func (p ProjectionRepository) SaveStates(txCtx context.Context, streams ...proje
 			TenantID:     stream.ID().TenantID,
 			ProjectionID: stream.ID().ProjectionID,
 			State:        string(stream.State()),
+			Generation:   stream.Generation().Number,
 			UpdatedAt:    stream.UpdatedAt(),
 		}
 	}
@@ -192,6 +272,47 @@ func (p ProjectionRepository) SaveEvents(txCtx context.Context, streams ...proje
 	return nil
 }
 
+// SaveEventsToShadow adds the events of the stream to the queue of its shadow, if a blue/green rebuild of the
+// projection is running. It is called with the executed events of the projection, so that the shadow catches up with
+// the live projection.
+func (p ProjectionRepository) SaveEventsToShadow(txCtx context.Context, stream projection.Stream) error {
+	if len(stream.Events()) == 0 || projection.IsShadowID(stream.ID().ProjectionID) {
+		return nil
+	}
+
+	shadowID := shared.NewProjectionID(stream.ID().TenantID, projection.ShadowID(stream.ID().ProjectionID))
+	dtos, _, err := p.projPort.Get(txCtx, shadowID)
+	if err != nil {
+		return fmt.Errorf("get() shadow of projection %q failed :%w", stream.ID(), err)
+	}
+	if len(dtos) == 0 {
+		return nil
+	}
+
+	if err = p.projPort.SaveEvents(txCtx, projPort.DTO{
+		TenantID:     shadowID.TenantID,
+		ProjectionID: shadowID.ProjectionID,
+		Events:       stream.Events(),
+	}); err != nil {
+		return fmt.Errorf("save() events of shadow of projection %q failed :%w", stream.ID(), err)
+	}
+	return nil
+}
+
+func (p ProjectionRepository) SaveExecutionResult(txCtx context.Context, id shared.ProjectionID, result projPort.ExecutionResult) error {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "SaveExecutionResult (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID})
+	defer endSpan()
+
+	return p.projPort.SaveExecutionResult(txCtx, id, result)
+}
+
+func (p ProjectionRepository) GetQueueStatistics(txCtx context.Context, ids ...shared.ProjectionID) ([]projPort.QueueStatistics, error) {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "GetQueueStatistics (repository)", map[string]interface{}{"numberOfProjections": len(ids)})
+	defer endSpan()
+
+	return p.projPort.GetQueueStatistics(txCtx, ids...)
+}
+
 func (p ProjectionRepository) Reset(txCtx context.Context, id shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error {
 	return p.projPort.ResetSince(txCtx, id, sinceTime, eventTypes...)
 }
@@ -200,9 +321,28 @@ func (p ProjectionRepository) RemoveProjection(txCtx context.Context, projection
 	txCtx, endSpan := metrics.StartSpan(txCtx, "RemoveProjection (repository)", map[string]interface{}{"projectionID": projectionID})
 	defer endSpan()
 
+	if err := p.projPort.RemoveProjection(txCtx, projection.ShadowID(projectionID)); err != nil {
+		return err
+	}
 	return p.projPort.RemoveProjection(txCtx, projectionID)
 }
 
+// Fork fills the queue of the shadow of a blue/green rebuild with all events of the projection since sinceTime, which
+// the projection already executed.
+func (p ProjectionRepository) Fork(txCtx context.Context, id, shadow shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "Fork (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID})
+	defer endSpan()
+
+	return p.projPort.ForkQueue(txCtx, id, shadow, sinceTime, eventTypes...)
+}
+
+func (p ProjectionRepository) Delete(txCtx context.Context, id shared.ProjectionID) error {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "Delete (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID})
+	defer endSpan()
+
+	return p.projPort.DeleteProjection(txCtx, id)
+}
+
 func (p ProjectionRepository) DeleteEventFromQueue(txCtx context.Context, eventID string, ids ...shared.ProjectionID) error {
 	txCtx, endSpan := metrics.StartSpan(txCtx, "DeleteEventFromQueue (repository)", map[string]interface{}{"eventID": eventID, "numberOfProjections": len(ids)})
 	defer endSpan()
@@ -217,6 +357,27 @@ func (p ProjectionRepository) GetProjectionsWithEventInQueue(txCtx context.Conte
 	return p.projPort.GetProjectionsWithEventInQueue(txCtx, id, eventID)
 }
 
+func (p ProjectionRepository) AddQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, events ...projPort.QuarantinedEvent) error {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "AddQuarantinedEvents (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID, "numberOfEvents": len(events)})
+	defer endSpan()
+
+	return p.projPort.AddQuarantinedEvents(txCtx, id, events...)
+}
+
+func (p ProjectionRepository) GetQuarantinedEvents(txCtx context.Context, id shared.ProjectionID) ([]projPort.QuarantinedEvent, error) {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "GetQuarantinedEvents (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID})
+	defer endSpan()
+
+	return p.projPort.GetQuarantinedEvents(txCtx, id)
+}
+
+func (p ProjectionRepository) DelQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, eventIDs ...string) error {
+	txCtx, endSpan := metrics.StartSpan(txCtx, "DelQuarantinedEvents (repository)", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID, "eventIDs": eventIDs})
+	defer endSpan()
+
+	return p.projPort.DelQuarantinedEvents(txCtx, id, eventIDs...)
+}
+
 func (p ProjectionRepository) create(_ context.Context, id shared.ProjectionID, state projection.State, updatedAt time.Time) (projection.Stream, error) {
 	opt := p.registries.ProjectionRegistry.Options(id.ProjectionID)
 	proj, err := p.registries.ProjectionRegistry.Projection(id.ProjectionID)
@@ -227,20 +388,27 @@ func (p ProjectionRepository) create(_ context.Context, id shared.ProjectionID,
 	return projection.CreateStream(id, state, updatedAt, proj, opt), nil
 }
 
-func (p ProjectionRepository) mapToProjectionStream(txCtx context.Context, dtos []projPort.DTO) ([]projection.Stream, error) {
+func (p ProjectionRepository) mapToProjectionStream(_ context.Context, dtos []projPort.DTO) ([]projection.Stream, error) {
 	var result []projection.Stream
 	for _, dto := range dtos {
-		var stream projection.Stream
-		stream, err := p.create(txCtx, shared.NewProjectionID(dto.TenantID, dto.ProjectionID), projection.State(dto.State), dto.UpdatedAt)
+		// the shadow of a blue/green rebuild uses the projection and the options of the live projection
+		liveID := projection.LiveID(dto.ProjectionID)
+		proj, err := p.registries.ProjectionRegistry.Projection(liveID)
 		if err != nil {
-			return nil, fmt.Errorf("could not mapToProjectionStream: %w", err)
+			return nil, fmt.Errorf("could not mapToProjectionStream: could not create ProjectionRepository for Projection %v: %w", dto.ProjectionID, err)
 		}
 
-		result = append(result, stream)
+		result = append(result, projection.LoadFromDTO(dto, proj, p.registries.ProjectionRegistry.Options(liveID)))
 	}
 	return result, nil
 }
 
+func withoutShadows(dtos []projPort.DTO) []projPort.DTO {
+	return slices.DeleteFunc(dtos, func(dto projPort.DTO) bool {
+		return projection.IsShadowID(dto.ProjectionID)
+	})
+}
+
 func (p ProjectionRepository) mapToProjectionID(_ context.Context, notFound []projPort.NotFoundError) ([]shared.ProjectionID, error) {
 	var result []shared.ProjectionID
 	for _, dto := range notFound {
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
+	projPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"time"
 )
@@ -12,6 +13,8 @@ type ProjectionRepositoryInterface interface {
 
 	Lock(txCtx context.Context, ids ...shared.ProjectionID) error
 	UnLock(txCtx context.Context, ids ...shared.ProjectionID) error
+	LockLane(txCtx context.Context, id shared.ProjectionID, lane int) error
+	UnLockLane(txCtx context.Context, id shared.ProjectionID, lane int) error
 
 	Create(txCtx context.Context, id ...shared.ProjectionID) (streams []projection.Stream, err error)
 
@@ -24,13 +27,24 @@ type ProjectionRepositoryInterface interface {
 	GetAllForAllTenants(txCtx context.Context) ([]projection.Stream, error)
 
 	GetWithNewEventsSinceLastRun(txCtx context.Context, id shared.ProjectionID) (projection.Stream, error)
+	GetWithNewEventsOfLaneSinceLastRun(txCtx context.Context, id shared.ProjectionID, lane int) (projection.Stream, error)
 	Reset(txCtx context.Context, id shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error
+	Fork(txCtx context.Context, id, shadow shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error
 
 	RemoveProjection(ctx context.Context, projectionID string) error
+	Delete(txCtx context.Context, id shared.ProjectionID) error
 
 	SaveStates(txCtx context.Context, stream ...projection.Stream) error
 	SaveEvents(txCtx context.Context, stream ...projection.Stream) error
+	SaveEventsToShadow(txCtx context.Context, stream projection.Stream) error
+	SaveExecutionResult(txCtx context.Context, id shared.ProjectionID, result projPort.ExecutionResult) error
+
+	GetQueueStatistics(txCtx context.Context, ids ...shared.ProjectionID) ([]projPort.QueueStatistics, error)
 
 	DeleteEventFromQueue(txCtx context.Context, eventID string, id ...shared.ProjectionID) error
 	GetProjectionsWithEventInQueue(txCtx context.Context, id shared.AggregateID, eventID string) ([]shared.ProjectionID, error)
+
+	AddQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, events ...projPort.QuarantinedEvent) error
+	GetQuarantinedEvents(txCtx context.Context, id shared.ProjectionID) ([]projPort.QuarantinedEvent, error)
+	DelQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, eventIDs ...string) error
 }
@@ -2,35 +2,50 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/aggregate"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/service"
 	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"iter"
+	"slices"
 	"time"
 )
 
-func NewLoaderService(aggRepro repository.AggregateRepositoryInterface, transactor transactor2.Port) LoaderService {
+// lifecyclePageSize is the number of close, reopen and revision delete events, which are fetched at once for the
+// historical status of reopened streams or the tombstones of a stream
+const lifecyclePageSize = 1000
+
+// lifecycleClasses matches the classes of the events, which define the historical status of a stream
+var lifecycleClasses = fmt.Sprintf("^(%s|%s|%s)$", event.CloseStreamEvent, event.ReopenStreamEvent, event.DeleteRevision)
+
+func NewLoaderService(aggRepro repository.AggregateRepositoryInterface, transactor transactor2.Port, archives StreamArchiveService) LoaderService {
 	return LoaderService{
 		domain:              service.DomainService{},
 		aggregateRepository: aggRepro,
 		transactor:          transactor,
+		archives:            archives,
 	}
 }
 
 type LoaderService struct {
 	domain              service.DomainService
 	aggregateRepository repository.AggregateRepositoryInterface
+	archives            StreamArchiveService
 
 	transactor transactor2.Port
 }
 
 func (l *LoaderService) LoadAsAt(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime time.Time) (eventStream []event.PersistenceEvent, version int, err error) {
 	var events event.PersistenceEvents
-	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
-		events, err = l.aggregateRepository.LoadAsAt(txCtx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), projectionTime)
-		return err
+	errTrans := l.rehydrateEmptyStream(ctx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), func() error {
+		return l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+			events, err = l.aggregateRepository.LoadAsAt(txCtx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), projectionTime)
+			return err
+		})
 	})
 
 	if errTrans != nil {
@@ -42,9 +57,11 @@ func (l *LoaderService) LoadAsAt(ctx context.Context, tenantID, aggregateType, a
 
 func (l *LoaderService) LoadAsOf(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime time.Time) (eventStream []event.PersistenceEvent, version int, err error) {
 	var events event.PersistenceEvents
-	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
-		events, err = l.aggregateRepository.LoadAsOf(txCtx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), projectionTime)
-		return err
+	errTrans := l.rehydrateEmptyStream(ctx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), func() error {
+		return l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+			events, err = l.aggregateRepository.LoadAsOf(txCtx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), projectionTime)
+			return err
+		})
 	})
 
 	if errTrans != nil {
@@ -56,9 +73,11 @@ func (l *LoaderService) LoadAsOf(ctx context.Context, tenantID, aggregateType, a
 
 func (l *LoaderService) LoadAsOfTill(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime, reportTime time.Time) (eventStream []event.PersistenceEvent, version int, err error) {
 	var events event.PersistenceEvents
-	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
-		events, err = l.aggregateRepository.LoadAsOfTill(txCtx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), projectionTime, reportTime)
-		return err
+	errTrans := l.rehydrateEmptyStream(ctx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), func() error {
+		return l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+			events, err = l.aggregateRepository.LoadAsOfTill(txCtx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), projectionTime, reportTime)
+			return err
+		})
 	})
 
 	if errTrans != nil {
@@ -68,6 +87,25 @@ func (l *LoaderService) LoadAsOfTill(ctx context.Context, tenantID, aggregateTyp
 	return events.Events, events.Version, err
 }
 
+// rehydrateEmptyStream repeats the load, if the stream was empty because it is archived and has been restored (see
+// StreamArchiveService.rehydrate). Streams of bulk loads are not rehydrated, i.e. archived streams are skipped there.
+func (l *LoaderService) rehydrateEmptyStream(ctx context.Context, id shared.AggregateID, load func() error) error {
+	err := load()
+	var errEmpty *event.ErrorEmptyEventStream
+	if !errors.As(err, &errEmpty) {
+		return err
+	}
+
+	rehydrated, errRehydrate := l.archives.rehydrate(ctx, id)
+	if errRehydrate != nil {
+		return errRehydrate
+	}
+	if !rehydrated {
+		return err
+	}
+	return load()
+}
+
 func (l *LoaderService) LoadAllOfAggregateAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
 	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
 		eventStreams, err = l.aggregateRepository.LoadAllOfAggregateAsAt(txCtx, tenantID, aggregateType, projectionTime)
@@ -146,6 +184,79 @@ func (l *LoaderService) LoadAllAsOfTill(ctx context.Context, tenantID string, pr
 	return eventStreams, err
 }
 
+func (l *LoaderService) StreamAllOfAggregateAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.stream(ctx, fmt.Sprintf("StreamAllOfAggregateTypeAsAt failed for tenant %q and type %q", tenantID, aggregateType), func(txCtx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return l.aggregateRepository.StreamAllOfAggregateAsAt(txCtx, tenantID, aggregateType, projectionTime)
+	})
+}
+
+func (l *LoaderService) StreamAllOfAggregateAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.stream(ctx, fmt.Sprintf("StreamAllOfAggregateTypeAsOf failed for tenant %q and type %q", tenantID, aggregateType), func(txCtx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return l.aggregateRepository.StreamAllOfAggregateAsOf(txCtx, tenantID, aggregateType, projectionTime)
+	})
+}
+
+func (l *LoaderService) StreamAllOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.stream(ctx, fmt.Sprintf("StreamAllOfAggregateTypeAsOfTill failed for tenant %q and type %q", tenantID, aggregateType), func(txCtx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return l.aggregateRepository.StreamAllOfAggregateAsOfTill(txCtx, tenantID, aggregateType, projectionTime, reportTime)
+	})
+}
+
+func (l *LoaderService) StreamAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.stream(ctx, fmt.Sprintf("StreamAllAsAt failed for tenant %q", tenantID), func(txCtx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return l.aggregateRepository.StreamAllAsAt(txCtx, tenantID, projectionTime)
+	})
+}
+
+func (l *LoaderService) StreamAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.stream(ctx, fmt.Sprintf("StreamAllAsOf failed for tenant %q", tenantID), func(txCtx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return l.aggregateRepository.StreamAllAsOf(txCtx, tenantID, projectionTime)
+	})
+}
+
+func (l *LoaderService) StreamAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.stream(ctx, fmt.Sprintf("StreamAllAsOfTill failed for tenant %q", tenantID), func(txCtx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return l.aggregateRepository.StreamAllAsOfTill(txCtx, tenantID, projectionTime, reportTime)
+	})
+}
+
+// stream holds the connection for the duration of the iteration. It is released, when the iteration ends or the
+// consumer stops it. After an error the iteration ends.
+func (l *LoaderService) stream(ctx context.Context, errMsg string, load func(txCtx context.Context) iter.Seq2[event.PersistenceEvents, error]) iter.Seq2[event.PersistenceEvents, error] {
+	return func(yield func(event.PersistenceEvents, error) bool) {
+		stopped := false
+		errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+			for eventStream, err := range load(txCtx) {
+				if err != nil {
+					return err
+				}
+				if !yield(eventStream, nil) {
+					stopped = true
+					return nil
+				}
+			}
+			return nil
+		})
+
+		if errTrans != nil && !stopped {
+			yield(event.PersistenceEvents{}, fmt.Errorf("%s:%w", errMsg, errTrans))
+		}
+	}
+}
+
+func (l *LoaderService) LoadFromPosition(ctx context.Context, tenantID string, position int64, limit int) (events []event.PersistenceEvent, err error) {
+	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+		events, err = l.aggregateRepository.LoadFromPosition(txCtx, tenantID, position, limit)
+		return err
+	})
+
+	if errTrans != nil {
+		return nil, fmt.Errorf("LoadFromPosition failed for tenant %q and position %d:%w", tenantID, position, errTrans)
+	}
+
+	return events, err
+}
+
 func (l *LoaderService) GetAggregateStates(ctx context.Context, tenantID, aggregateType, aggregateID string) (state event.AggregateState, err error) {
 	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
 		state, err = l.aggregateRepository.GetAggregateState(txCtx, tenantID, aggregateType, aggregateID)
@@ -159,7 +270,7 @@ func (l *LoaderService) GetAggregateStates(ctx context.Context, tenantID, aggreg
 	return state, err
 }
 
-func (l *LoaderService) GetAllAggregateStatesForAggregateType(ctx context.Context, tenantID string, aggregateType string) (states []event.AggregateState, err error) {
+func (l *LoaderService) GetAllAggregateStatesForAggregateType(ctx context.Context, tenantID string, aggregateType string, status ...event.StreamStatus) (states []event.AggregateState, err error) {
 	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
 		states, err = l.aggregateRepository.GetAggregateStatesForAggregateType(txCtx, tenantID, aggregateType)
 		return err
@@ -169,12 +280,17 @@ func (l *LoaderService) GetAllAggregateStatesForAggregateType(ctx context.Contex
 		return nil, fmt.Errorf("GetAggregateStatesForAggregateType failed for aggregate type %q of tenant %q:%w", aggregateType, tenantID, errTrans)
 	}
 
-	return states, err
+	return filterAggregateStates(states, nil, time.Now(), status...), err
 }
 
-func (l *LoaderService) GetAllAggregateStatesForAggregateTypeTill(ctx context.Context, tenantID string, aggregateType string, until time.Time) (states []event.AggregateState, err error) {
+func (l *LoaderService) GetAllAggregateStatesForAggregateTypeTill(ctx context.Context, tenantID string, aggregateType string, until time.Time, status ...event.StreamStatus) (states []event.AggregateState, err error) {
+	var lifecycles map[string][]event.PersistenceEvent
 	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
 		states, err = l.aggregateRepository.GetAggregateStatesForAggregateTypeTill(txCtx, tenantID, aggregateType, until)
+		if err != nil || len(status) == 0 {
+			return err
+		}
+		lifecycles, err = l.getReopenedLifecycles(txCtx, tenantID, aggregateType)
 		return err
 	})
 
@@ -182,7 +298,93 @@ func (l *LoaderService) GetAllAggregateStatesForAggregateTypeTill(ctx context.Co
 		return nil, fmt.Errorf("GetAggregateStatesForAggregateTypeTill failed for aggregate type %q of tenant %q and time %s:%w", aggregateType, tenantID, until, errTrans)
 	}
 
-	return states, err
+	return filterAggregateStates(states, lifecycles, until, status...), err
+}
+
+// getReopenedLifecycles returns the close, reopen and revision delete events of the aggregates of the type, which were
+// reopened or whose close was deleted by the RevisionDelete strategy. The status of all other aggregates follows from
+// their close time, which is the time of their only close event.
+func (l *LoaderService) getReopenedLifecycles(txCtx context.Context, tenantID, aggregateType string) (map[string][]event.PersistenceEvent, error) {
+	events, err := getAllAggregatesEvents(txCtx, l.aggregateRepository, tenantID,
+		event.SearchField{Name: event.SearchAggregateType, Value: aggregateType, Operator: event.SearchEqual},
+		event.SearchField{Name: event.SearchAggregateClass, Value: lifecycleClasses, Operator: event.SearchMatch})
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycles := make(map[string][]event.PersistenceEvent)
+	for _, evt := range events {
+		lifecycles[evt.AggregateID] = append(lifecycles[evt.AggregateID], evt)
+	}
+	for aggregateID, lifecycle := range lifecycles {
+		deleted := aggregate.RevisionDeletedEventIDs(lifecycle)
+		if !slices.ContainsFunc(lifecycle, func(evt event.PersistenceEvent) bool {
+			return evt.Class == event.ReopenStreamEvent || evt.Class == event.CloseStreamEvent && deleted[evt.ID]
+		}) {
+			delete(lifecycles, aggregateID)
+		}
+	}
+	return lifecycles, nil
+}
+
+// getAllAggregatesEvents returns the events of all pages, which match the search fields (see also SaverService).
+func getAllAggregatesEvents(txCtx context.Context, aggregateRepository repository.AggregateRepositoryInterface, tenantID string, searchFields ...event.SearchField) ([]event.PersistenceEvent, error) {
+	page := event.PageDTO{PageSize: lifecyclePageSize, SearchFields: searchFields}
+	var result []event.PersistenceEvent
+	for {
+		events, pages, err := aggregateRepository.GetAggregatesEvents(txCtx, tenantID, page)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, events...)
+		if len(events) < lifecyclePageSize {
+			return result, nil
+		}
+		page = pages.Next
+	}
+}
+
+// filterAggregateStates returns the states of the aggregates with one of the statuses at the given time, or all states
+// without statuses. The lifecycles contain the close and reopen events of reopened aggregates (see
+// getReopenedLifecycles), because their close time only tells the status since their latest close.
+func filterAggregateStates(states []event.AggregateState, lifecycles map[string][]event.PersistenceEvent, at time.Time, status ...event.StreamStatus) []event.AggregateState {
+	if len(status) == 0 {
+		return states
+	}
+
+	var result []event.AggregateState
+	for _, state := range states {
+		if slices.Contains(status, streamStatusAt(state, lifecycles[state.AggregateID], at)) {
+			result = append(result, state)
+		}
+	}
+	return result
+}
+
+// streamStatusAt returns the status of the stream at the given time, i.e. the status of its latest close or reopen
+// event until then, which was not deleted by the RevisionDelete strategy.
+func streamStatusAt(state event.AggregateState, lifecycle []event.PersistenceEvent, at time.Time) event.StreamStatus {
+	if len(lifecycle) == 0 {
+		if state.IsClosed(at) {
+			return event.ClosedStreams
+		}
+		return event.OpenStreams
+	}
+
+	deleted := aggregate.RevisionDeletedEventIDs(lifecycle)
+	var latest *event.PersistenceEvent
+	for i, evt := range lifecycle {
+		if evt.Class == event.DeleteRevision || deleted[evt.ID] {
+			continue
+		}
+		if !evt.ValidTime.After(at) && (latest == nil || evt.Version > latest.Version) {
+			latest = &lifecycle[i]
+		}
+	}
+	if latest != nil && latest.Class == event.CloseStreamEvent {
+		return event.ClosedStreams
+	}
+	return event.OpenStreams
 }
 
 func (l *LoaderService) GetPatchFreePeriodsForInterval(ctx context.Context, tenantID, aggregateType, aggregateID string, start time.Time, end time.Time) (intervals []event.TimeInterval, err error) {
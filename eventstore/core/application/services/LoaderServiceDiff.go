@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/aggregate"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"time"
+)
+
+func (l *LoaderService) DiffAsOfTill(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime, reportTimeA, reportTimeB time.Time) (event.Diff, error) {
+	var events []event.PersistenceEvent
+	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		events, err = l.aggregateRepository.LoadEventsAsOfTill(txCtx, shared.NewAggregateID(tenantID, aggregateType, aggregateID), projectionTime, latest(reportTimeA, reportTimeB))
+		return err
+	})
+	if errTrans != nil {
+		return event.Diff{}, fmt.Errorf("DiffAsOfTill failed for tenant %q and aggregate %q:%w", tenantID, aggregateID, errTrans)
+	}
+
+	return diffAsOfTill(event.Diff{
+		TenantID:       tenantID,
+		AggregateType:  aggregateType,
+		AggregateID:    aggregateID,
+		ProjectionTime: projectionTime,
+		ReportTimeA:    reportTimeA,
+		ReportTimeB:    reportTimeB,
+	}, events), nil
+}
+
+func (l *LoaderService) DiffAllOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTimeA, reportTimeB time.Time) ([]event.Diff, error) {
+	var events []event.PersistenceEvent
+	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		events, err = l.aggregateRepository.LoadAllEventsOfAggregateAsOfTill(txCtx, tenantID, aggregateType, projectionTime, latest(reportTimeA, reportTimeB))
+		return err
+	})
+	if errTrans != nil {
+		return nil, fmt.Errorf("DiffAllOfAggregateTypeAsOfTill failed for tenant %q and type %q:%w", tenantID, aggregateType, errTrans)
+	}
+
+	// the events are ordered by aggregate
+	var result []event.Diff
+	for start := 0; start < len(events); {
+		end := start + 1
+		for end < len(events) && events[end].AggregateID == events[start].AggregateID {
+			end++
+		}
+
+		diff := diffAsOfTill(event.Diff{
+			TenantID:       tenantID,
+			AggregateType:  aggregateType,
+			AggregateID:    events[start].AggregateID,
+			ProjectionTime: projectionTime,
+			ReportTimeA:    reportTimeA,
+			ReportTimeB:    reportTimeB,
+		}, events[start:end])
+		if !diff.IsEmpty() {
+			result = append(result, diff)
+		}
+		start = end
+	}
+
+	return result, nil
+}
+
+// diffAsOfTill compares the views of both report times on the events of an aggregate, which are loaded till the later
+// report time.
+func diffAsOfTill(diff event.Diff, events []event.PersistenceEvent) event.Diff {
+	viewA := viewTill(events, diff.ReportTimeA)
+	viewB := viewTill(events, diff.ReportTimeB)
+
+	for _, evt := range events {
+		switch {
+		case viewB[evt.ID] && !viewA[evt.ID]:
+			if diff.Entered == nil {
+				diff.Entered = make(map[event.Class][]event.PersistenceEvent)
+			}
+			diff.Entered[evt.Class] = append(diff.Entered[evt.Class], evt)
+		case viewA[evt.ID] && !viewB[evt.ID]:
+			if diff.Left == nil {
+				diff.Left = make(map[event.Class][]event.PersistenceEvent)
+			}
+			diff.Left[evt.Class] = append(diff.Left[evt.Class], evt)
+		}
+	}
+
+	return diff
+}
+
+// viewTill returns the ids of the events in the view till the report time. Unlike RemoveRevisionDeletes, the tombstones
+// stay part of the view, so that the deletes show up in the difference.
+func viewTill(events []event.PersistenceEvent, reportTime time.Time) map[string]bool {
+	var known []event.PersistenceEvent
+	for _, evt := range events {
+		if !evt.TransactionTime.After(reportTime) {
+			known = append(known, evt)
+		}
+	}
+
+	deleted := aggregate.RevisionDeletedEventIDs(known)
+	view := make(map[string]bool, len(known))
+	for _, evt := range known {
+		if !deleted[evt.ID] {
+			view[evt.ID] = true
+		}
+	}
+	return view
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"slices"
+	"time"
+)
+
+func (l *LoaderService) LoadAllOfAggregateAsOfSeries(ctx context.Context, tenantID, aggregateType string, projectionTimes []time.Time) ([]event.PersistenceEventsSeries, error) {
+	if len(projectionTimes) == 0 {
+		return nil, fmt.Errorf("LoadAllOfAggregateTypeAsOfSeries failed for tenant %q and type %q: no projection times", tenantID, aggregateType)
+	}
+	times := slices.SortedFunc(slices.Values(projectionTimes), func(a, b time.Time) int { return a.Compare(b) })
+	times = slices.CompactFunc(times, time.Time.Equal)
+
+	var eventStreams []event.PersistenceEvents
+	errTrans := l.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		eventStreams, err = l.aggregateRepository.LoadAllOfAggregateAsOfRange(txCtx, tenantID, aggregateType, times[0], times[len(times)-1])
+		return err
+	})
+	if errTrans != nil {
+		return nil, fmt.Errorf("LoadAllOfAggregateTypeAsOfSeries failed for tenant %q and type %q:%w", tenantID, aggregateType, errTrans)
+	}
+
+	result := make([]event.PersistenceEventsSeries, len(eventStreams))
+	for i, eventStream := range eventStreams {
+		result[i] = sliceEventStream(eventStream, times)
+	}
+	return result, nil
+}
+
+// sliceEventStream slices the event stream (ordered by valid time) at the projection times. The snapshot of the stream
+// is valid at the earliest projection time, so it is part of every non-empty slice.
+func sliceEventStream(eventStream event.PersistenceEvents, projectionTimes []time.Time) event.PersistenceEventsSeries {
+	ends := make([]int, len(projectionTimes))
+	end := 0
+	for i, projectionTime := range projectionTimes {
+		for end < len(eventStream.Events) && !eventStream.Events[end].ValidTime.After(projectionTime) {
+			end++
+		}
+		ends[i] = end
+	}
+
+	return event.PersistenceEventsSeries{
+		ProjectionTimes: projectionTimes,
+		Events:          eventStream.Events,
+		Ends:            ends,
+		Version:         eventStream.Version,
+	}
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/publisher"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"github.com/samber/lo"
+	"time"
+)
+
+const (
+	defaultOutboxPollInterval = 500 * time.Millisecond
+	defaultOutboxMaxAttempts  = 10
+	defaultOutboxBackoff      = 100 * time.Millisecond
+	maxOutboxBackoff          = 5 * time.Minute
+	// outboxLease is the time a dispatcher reserves the messages for their delivery. If the dispatcher (pod) dies
+	// during the delivery, the messages are delivered again after the lease.
+	outboxLease     = time.Minute
+	outboxBatchSize = 100
+)
+
+func NewOutboxService(outboxPort outbox.Port, transactor transactor2.Port) OutboxService {
+	return OutboxService{
+		outbox:       outboxPort,
+		pollInterval: defaultOutboxPollInterval,
+		maxAttempts:  defaultOutboxMaxAttempts,
+		backoff:      defaultOutboxBackoff,
+		transactor:   transactor,
+	}
+}
+
+type OutboxService struct {
+	outbox    outbox.Port
+	publisher publisher.Port
+	ctx       context.Context
+
+	pollInterval time.Duration
+	maxAttempts  int
+	backoff      time.Duration
+	transactor   transactor2.Port
+}
+
+// SetPublisher enables the dispatcher, which delivers the outbox to the publisher until the given context is canceled.
+func (o *OutboxService) SetPublisher(ctx context.Context, publisher publisher.Port) {
+	o.ctx = ctx
+	o.publisher = publisher
+}
+
+func (o *OutboxService) SetPollInterval(interval time.Duration) {
+	o.pollInterval = interval
+}
+
+func (o *OutboxService) SetRetry(maxAttempts int, backoff time.Duration) {
+	o.maxAttempts = maxAttempts
+	o.backoff = backoff
+}
+
+// StartDispatcher starts the dispatcher, if a publisher is set.
+func (o *OutboxService) StartDispatcher() {
+	if o.publisher == nil {
+		return
+	}
+
+	go func(o OutboxService) {
+		for {
+			if err := o.dispatch(o.ctx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error(fmt.Errorf("dispatching of outbox failed: %w", err))
+			}
+
+			select {
+			case <-o.ctx.Done():
+				return
+			case <-time.After(o.pollInterval):
+			}
+		}
+	}(*o)
+}
+
+// dispatch delivers the due messages. The messages are reserved (for the duration of the lease) in a first
+// transaction, so that the (possibly slow) publisher is not called within a transaction.
+func (o *OutboxService) dispatch(ctx context.Context) error {
+	var due []outbox.Message
+	// look for due messages first, so that a write transaction is only started if necessary
+	errTx := o.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		due, err = o.outbox.GetDueMessages(txCtx, time.Now(), outboxBatchSize)
+		return err
+	})
+	if errTx != nil || len(due) == 0 {
+		return errTx
+	}
+
+	errTx = o.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
+		now := time.Now()
+		if due, err = o.outbox.GetDueMessages(txCtx, now, outboxBatchSize); err != nil {
+			return err
+		}
+		for i := range due {
+			due[i].NextAttempt = now.Add(outboxLease)
+		}
+		return o.outbox.UpdateMessages(txCtx, due...)
+	})
+	if errTx != nil {
+		return errTx
+	}
+
+	var delivered, failed []outbox.Message
+	for _, msg := range due {
+		if err := o.publisher.Publish(ctx, msg.Event); err != nil {
+			if ctx.Err() != nil {
+				// the remaining messages are delivered after the lease
+				break
+			}
+			failed = append(failed, o.failed(msg, err))
+			continue
+		}
+		delivered = append(delivered, msg)
+	}
+
+	// the result of the delivery is saved, even if the dispatcher is canceled
+	return o.transactor.WithinTX(context.WithoutCancel(ctx), func(txCtx context.Context) error {
+		if err := o.outbox.DelMessages(txCtx, delivered...); err != nil {
+			return err
+		}
+		return o.outbox.UpdateMessages(txCtx, failed...)
+	})
+}
+
+// failed schedules the next attempt with an exponential backoff, or moves the message into the dead letter state.
+func (o *OutboxService) failed(msg outbox.Message, err error) outbox.Message {
+	msg.Attempts++
+	msg.LastError = err.Error()
+	if msg.Attempts >= o.maxAttempts {
+		msg.Status = outbox.DeadLetter
+		logger.Warn("event %q of tenant %q moved into the dead letters of the outbox after %d attempts: %v", msg.Event.ID, msg.Event.TenantID, msg.Attempts, err)
+		return msg
+	}
+
+	backoff := o.backoff
+	for i := 1; i < msg.Attempts && backoff < maxOutboxBackoff; i++ {
+		backoff *= 2
+	}
+	msg.NextAttempt = time.Now().Add(min(backoff, maxOutboxBackoff))
+	return msg
+}
+
+func (o *OutboxService) GetDeadLetters(ctx context.Context, tenantID string) ([]event.DeadLetter, error) {
+	var messages []outbox.Message
+	errTx := o.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		messages, err = o.outbox.GetDeadLetters(txCtx, tenantID)
+		return err
+	})
+	if errTx != nil {
+		return nil, fmt.Errorf("GetDeadLetters failed for tenant %q:%w", tenantID, errTx)
+	}
+
+	return lo.Map(messages, func(msg outbox.Message, _ int) event.DeadLetter {
+		return event.DeadLetter{Event: msg.Event, Attempts: msg.Attempts, LastError: msg.LastError}
+	}), nil
+}
+
+func (o *OutboxService) RequeueDeadLetters(ctx context.Context, tenantID string, eventIDs ...string) error {
+	errTx := o.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		messages, err := o.outbox.GetDeadLetters(txCtx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		requeue := lo.Filter(messages, func(msg outbox.Message, _ int) bool {
+			return lo.Contains(eventIDs, msg.Event.ID)
+		})
+		if len(requeue) != len(lo.Uniq(eventIDs)) {
+			return fmt.Errorf("dead letters %v not found", lo.Without(eventIDs, lo.Map(requeue, func(msg outbox.Message, _ int) string { return msg.Event.ID })...))
+		}
+
+		now := time.Now()
+		for i := range requeue {
+			requeue[i].Status = outbox.Pending
+			requeue[i].Attempts = 0
+			requeue[i].NextAttempt = now
+			requeue[i].LastError = ""
+		}
+		return o.outbox.UpdateMessages(txCtx, requeue...)
+	})
+	if errTx != nil {
+		return fmt.Errorf("RequeueDeadLetters failed for tenant %q:%w", tenantID, errTx)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/partition"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"github.com/samber/lo"
+	"sync"
+)
+
+func NewPartitionService(partitionPort partition.Port, transactor transactor2.Port) PartitionService {
+	return PartitionService{
+		partitions: partitionPort,
+		transactor: transactor,
+		prepared:   &sync.Map{},
+	}
+}
+
+// PartitionService manages the partitions of the tables (see event.PartitionManagement).
+type PartitionService struct {
+	partitions partition.Port
+	transactor transactor2.Port
+	// prepared contains the aggregate types and projections per tenant, whose partitions exist for sure
+	prepared *sync.Map
+}
+
+func (p PartitionService) MigrateDefaultPartitions(ctx context.Context) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "MigrateDefaultPartitions (service)", nil)
+	defer endSpan()
+
+	if !p.partitions.Enabled() {
+		return nil
+	}
+
+	var unpartitioned []partition.UnpartitionedDTO
+	errTrans := p.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		unpartitioned, err = p.partitions.GetUnpartitioned(txCtx)
+		return err
+	})
+	if errTrans != nil {
+		return fmt.Errorf("MigrateDefaultPartitions failed:%w", errTrans)
+	}
+
+	// each partition is migrated in its own transaction to keep the locks short
+	for _, rows := range lo.Uniq(unpartitioned) {
+		aggregateTypes := lo.Compact([]string{rows.AggregateType})
+		projectionIDs := lo.Compact([]string{rows.ProjectionID})
+		errTrans = p.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+			return p.partitions.Prepare(txCtx, rows.TenantID, aggregateTypes, projectionIDs)
+		})
+		if errTrans != nil {
+			return fmt.Errorf("MigrateDefaultPartitions failed for tenant %q:%w", rows.TenantID, errTrans)
+		}
+	}
+	return nil
+}
+
+func (p PartitionService) GetPartitionSizes(ctx context.Context) ([]event.PartitionSize, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetPartitionSizes (service)", nil)
+	defer endSpan()
+
+	var sizes []partition.DTO
+	errTrans := p.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		sizes, err = p.partitions.GetSizes(txCtx)
+		return err
+	})
+	if errTrans != nil {
+		return nil, fmt.Errorf("GetPartitionSizes failed:%w", errTrans)
+	}
+
+	result := make([]event.PartitionSize, len(sizes))
+	for i, size := range sizes {
+		result[i] = event.PartitionSize(size)
+	}
+	return result, nil
+}
+
+// prepare creates the missing partitions before the first save of aggregate types or projections (of a tenant). It
+// runs in its own transaction, because the partition DDL needs exclusive locks. If it fails, the rows are saved into
+// the default partitions and can be migrated later on (see MigrateDefaultPartitions). Hence, the error is only logged.
+func (p PartitionService) prepare(ctx context.Context, tenantID string, aggregateTypes, projectionIDs []string) {
+	if !p.partitions.Enabled() {
+		return
+	}
+
+	aggregateTypes = p.unprepared(tenantID, "aggregate", aggregateTypes)
+	projectionIDs = p.unprepared(tenantID, "projection", projectionIDs)
+	if len(aggregateTypes) == 0 && len(projectionIDs) == 0 {
+		return
+	}
+
+	errTrans := p.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		return p.partitions.Prepare(txCtx, tenantID, aggregateTypes, projectionIDs)
+	})
+	if errTrans != nil {
+		logger.Error(fmt.Errorf("preparation of partitions failed for tenant %q:%w", tenantID, errTrans))
+		return
+	}
+
+	for _, aggregateType := range aggregateTypes {
+		p.prepared.Store(preparedKey(tenantID, "aggregate", aggregateType), true)
+	}
+	for _, projectionID := range projectionIDs {
+		p.prepared.Store(preparedKey(tenantID, "projection", projectionID), true)
+	}
+}
+
+func (p PartitionService) unprepared(tenantID, kind string, values []string) []string {
+	return lo.Filter(lo.Uniq(values), func(value string, _ int) bool {
+		_, prepared := p.prepared.Load(preparedKey(tenantID, kind, value))
+		return !prepared
+	})
+}
+
+func preparedKey(tenantID, kind, value string) string {
+	return tenantID + "\x00" + kind + "\x00" + value
+}
@@ -10,29 +10,42 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/local/eventBus"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/registry"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/aggregate"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/service"
+	consistentClockPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/consistentClock"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
 	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/consistentClock"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
 	"github.com/samber/lo"
+	"slices"
+	"strconv"
 	"time"
 )
 
 var defaultSaveRetryDurations = []time.Duration{5, 10, 100, 385, 500}
 
-func NewSaverService(aggRepro repository.AggregateRepositoryInterface, projRepro repository.ProjectionRepositoryInterface, transactor transactor2.Port, evtBus *eventBus.EventPublisher, cmdBus *commandBus.CommandPublisher, registries *registry.Registries) SaverService {
+func NewSaverService(aggRepro repository.AggregateRepositoryInterface, projRepro repository.ProjectionRepositoryInterface, schedulerPort scheduler.Port, notifierPort notifier.Port, outboxPort outbox.Port, transactor transactor2.Port, evtBus *eventBus.EventPublisher, cmdBus *commandBus.CommandPublisher, registries *registry.Registries, tenants TenantService, partitions PartitionService, archives StreamArchiveService) SaverService {
 	return SaverService{
 		domain:                 service.DomainService{Clock: consistentClock.New()},
 		evtBus:                 evtBus,
 		cmdBus:                 cmdBus,
 		aggregateRepository:    aggRepro,
 		projectionRepository:   projRepro,
+		scheduler:              schedulerPort,
+		notifier:               notifierPort,
+		outbox:                 outboxPort,
 		retryAfterMilliseconds: defaultSaveRetryDurations,
 		transactor:             transactor,
 		registries:             registries,
+		tenants:                tenants,
+		partitions:             partitions,
+		archives:               archives,
 	}
 }
 
@@ -41,9 +54,16 @@ type SaverService struct {
 	evtBus     *eventBus.EventPublisher
 	cmdBus     *commandBus.CommandPublisher
 	registries *registry.Registries
+	tenants    TenantService
+	partitions PartitionService
+	archives   StreamArchiveService
 
 	aggregateRepository  repository.AggregateRepositoryInterface
 	projectionRepository repository.ProjectionRepositoryInterface
+	scheduler            scheduler.Port
+	notifier             notifier.Port
+	outbox               outbox.Port
+	outboxEventTypes     map[string]bool
 
 	retryAfterMilliseconds []time.Duration
 	transactor             transactor2.Port
@@ -53,6 +73,38 @@ func (s *SaverService) SetSaveRetryDuration(durations []time.Duration) {
 	s.retryAfterMilliseconds = durations
 }
 
+// SetOutboxEventTypes defines the event types, which are written into the outbox during saving.
+func (s *SaverService) SetOutboxEventTypes(eventTypes ...string) {
+	if s.outboxEventTypes == nil {
+		s.outboxEventTypes = make(map[string]bool)
+	}
+	for _, eventType := range eventTypes {
+		s.outboxEventTypes[eventType] = true
+	}
+}
+
+// SetClock replaces the consistent clock of the domain service. A seedable clock is seeded with the latest persisted
+// transaction time, so that new events are never ordered before the already stored ones.
+func (s *SaverService) SetClock(ctx context.Context, clock consistentClockPort.Port) error {
+	if seedable, ok := clock.(consistentClockPort.SeedablePort); ok {
+		var latest time.Time
+		errTx := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+			latest, err = s.aggregateRepository.GetLatestTransactionTime(txCtx)
+			return err
+		})
+		if errTx != nil {
+			return fmt.Errorf("SetClock() failed:%w", errTx)
+		}
+
+		if err := seedable.Seed(latest); err != nil {
+			return fmt.Errorf("SetClock() failed:%w", err)
+		}
+	}
+
+	s.domain.Clock = clock
+	return nil
+}
+
 func (s *SaverService) DisableSnapShot(ctx context.Context, tenantID, aggregateType, aggregateID string, sinceTime time.Time) error {
 	errTx := s.transactor.WithinTX(ctx, func(txCtx context.Context) error {
 		return s.aggregateRepository.DisableSnapShots(txCtx, shared.AggregateID{TenantID: tenantID, AggregateType: aggregateType, AggregateID: aggregateID}, sinceTime)
@@ -65,6 +117,12 @@ func (s *SaverService) DisableSnapShot(ctx context.Context, tenantID, aggregateT
 }
 
 func (s *SaverService) SaveWithRetry(ctx context.Context, tenantID string, persistenceEvents []event.PersistenceEvents) (chan error, error) {
+	return s.saveWithRetry(ctx, tenantID, persistenceEvents, nil)
+}
+
+// saveWithRetry is SaveWithRetry with a validation, which is executed within the save transaction, after the aggregates
+// are locked (see save).
+func (s *SaverService) saveWithRetry(ctx context.Context, tenantID string, persistenceEvents []event.PersistenceEvents, validate func(txCtx context.Context) error) (chan error, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "SaveWithRetry (service)", map[string]interface{}{"tenantID": tenantID, "numberOfEvents": len(persistenceEvents)})
 	defer endSpan()
 
@@ -72,7 +130,7 @@ func (s *SaverService) SaveWithRetry(ctx context.Context, tenantID string, persi
 	var concurrentProjectionAccessError *event.ErrorConcurrentProjectionAccess
 
 	for _, retryDuration := range s.retryAfterMilliseconds {
-		errCh, err := s.Save(ctx, tenantID, persistenceEvents)
+		errCh, err := s.save(ctx, tenantID, persistenceEvents, validate)
 		switch {
 		case err == nil:
 			return errCh, nil
@@ -85,7 +143,7 @@ func (s *SaverService) SaveWithRetry(ctx context.Context, tenantID string, persi
 		}
 	}
 	// Last retry attempt
-	ch, err := s.Save(ctx, tenantID, persistenceEvents)
+	ch, err := s.save(ctx, tenantID, persistenceEvents, validate)
 	if err != nil {
 		return nil, fmt.Errorf("save() failed: %w", err)
 	}
@@ -93,6 +151,12 @@ func (s *SaverService) SaveWithRetry(ctx context.Context, tenantID string, persi
 }
 
 func (s *SaverService) Save(ctx context.Context, tenantID string, persistenceEvents []event.PersistenceEvents) (chan error, error) {
+	return s.save(ctx, tenantID, persistenceEvents, nil)
+}
+
+// save validates the events with validate (if any) within the save transaction, after the aggregates are locked, so
+// that no concurrent save can invalidate them until they are saved.
+func (s *SaverService) save(ctx context.Context, tenantID string, persistenceEvents []event.PersistenceEvents, validate func(txCtx context.Context) error) (chan error, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "Save (service)", map[string]interface{}{"tenantID": tenantID, "numberOfEvents": len(persistenceEvents)})
 	defer endSpan()
 
@@ -116,8 +180,19 @@ func (s *SaverService) Save(ctx context.Context, tenantID string, persistenceEve
 		return nil, fmt.Errorf("GetProjectionIDsForEventTypes() failed :%w", err)
 	}
 
+	// The partitions must be created BEFORE the save transaction, because attaching a partition needs an exclusive lock
+	// of the parent table, which would otherwise be held until the end of the save.
+	s.partitions.prepare(ctx, tenantID,
+		lo.Map(aggregateIDs, func(id shared.AggregateID, _ int) string { return id.AggregateType }),
+		lo.Map(slices.Concat(consistentProjIDs, eventualConsistentProjIDs), func(id shared.ProjectionID, _ int) string { return id.ProjectionID }))
+
 	var streamCollection *service.StreamCollection
 	if err = s.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
+		// The tenant cannot be suspended during the save (see TenantService)
+		if err = s.tenants.rejectSuspendedTenant(txCtx, tenantID); err != nil {
+			return err
+		}
+
 		// Lock aggregates BEFORE retrieve/creating the aggregate stream
 		if err = s.aggregateRepository.Lock(txCtx, aggregateIDs...); err != nil {
 			return fmt.Errorf("locking of aggregates failed: %w", err)
@@ -132,6 +207,9 @@ func (s *SaverService) Save(ctx context.Context, tenantID string, persistenceEve
 		// But with the transaction time, we can always rebuild the correct insert order of events in the store.
 		// This insert order is for consistent projections crucial (in case of rebuild or replay of events).
 		if err = s.projectionRepository.Lock(txCtx, consistentProjIDs...); err != nil {
+			if errUnLock := s.aggregateRepository.UnLock(txCtx, aggregateIDs...); errUnLock != nil {
+				logger.Error(fmt.Errorf("unlocking of aggregates failed: %w", errUnLock))
+			}
 			return fmt.Errorf("locking of projections failed: %w", err)
 		}
 
@@ -146,11 +224,22 @@ func (s *SaverService) Save(ctx context.Context, tenantID string, persistenceEve
 			}
 		}()
 
+		if validate != nil {
+			if err = validate(txCtx); err != nil {
+				return err
+			}
+		}
+
 		aggregates, err := s.aggregateRepository.GetOrCreate(txCtx, aggregateIDs...)
 		if err != nil {
 			return fmt.Errorf("GetOrCreate failed: %w", err)
 		}
 
+		// Patches of closed streams are still possible, so that archived streams must be restored before
+		if err = s.archives.rehydrateTX(txCtx, closedStreamIDs(aggregates)...); err != nil {
+			return fmt.Errorf("rehydration of archived streams failed: %w", err)
+		}
+
 		// Because we register all new tenants above, we can assume here that all projections of it are already
 		// initialized and stored in the repository.
 		projections, err := s.projectionRepository.GetProjections(txCtx, append(consistentProjIDs, eventualConsistentProjIDs...)...)
@@ -169,15 +258,19 @@ func (s *SaverService) Save(ctx context.Context, tenantID string, persistenceEve
 	return s.evtBus.Publish(context.Background(), streamCollection.EventsDuringSaving()...), err
 }
 
+func closedStreamIDs(streams []aggregate.Stream) []shared.AggregateID {
+	var ids []shared.AggregateID
+	for _, stream := range streams {
+		if !stream.CloseTime().IsZero() {
+			ids = append(ids, stream.ID())
+		}
+	}
+	return ids
+}
+
 func (s *SaverService) registerAndInitNewTenant(ctx context.Context, tenantID string) error {
 	// TODO: Improvement - Handle unknown tenant with respect to fraud attacks
-	if err := s.registries.TenantRegistry.Register(tenantID); err != nil {
-		return fmt.Errorf("could not register and init new tenant %q: %w", tenantID, err)
-	}
-	if err := s.cmdBus.Execute(ctx, commands.CmdCreateTenant(tenantID)); err != nil {
-		return fmt.Errorf("could not register and init new tenant %q: %w", tenantID, err)
-	}
-	return nil
+	return s.tenants.registerAndInitNewTenant(ctx, tenantID)
 }
 
 func (s *SaverService) saveTX(txCtx context.Context, persistenceEvents []event.PersistenceEvents, streamCollection *service.StreamCollection) (err error) {
@@ -196,6 +289,18 @@ func (s *SaverService) saveTX(txCtx context.Context, persistenceEvents []event.P
 	if err = s.projectionRepository.SaveEvents(txCtx, streamCollection.EvtlConsistentProjections()...); err != nil {
 		return fmt.Errorf("saveTX() failed: %w", err)
 	}
+	// future patches are scheduled within the same transaction, so that they are not lost in case of a restart
+	if err = s.scheduler.AddTasks(txCtx, streamCollection.ScheduledTasks()...); err != nil {
+		return fmt.Errorf("saveTX() failed: %w", err)
+	}
+	// the outbox is written within the same transaction, so that no saved event gets lost before its delivery
+	if err = s.outbox.AddMessages(txCtx, streamCollection.OutboxMessages(s.outboxEventTypes, time.Now())...); err != nil {
+		return fmt.Errorf("saveTX() failed: %w", err)
+	}
+	// other instances are notified on commit, the own instance executes the projections via EventsDuringSaving
+	if err = s.notifier.Notify(txCtx, streamCollection.Notifications(s.registries.InstanceID)...); err != nil {
+		return fmt.Errorf("saveTX() failed: %w", err)
+	}
 	return nil
 }
 
@@ -231,44 +336,29 @@ func (s *SaverService) DeleteEvent(ctx context.Context, tenantID, aggregateType,
 		return fmt.Errorf("delete event is not allowed for aggregate %s of type %s", aggregateID, aggregateType)
 	}
 
+	id := shared.AggregateID{
+		TenantID:      tenantID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+	}
+	// The event (e.g. the close event) can only be deleted from the restored stream
+	if _, err := s.archives.rehydrate(ctx, id); err != nil {
+		return fmt.Errorf("delete event failed: %w", err)
+	}
+	if s.registries.AggregateRegistry.Options(aggregateType).DeleteStrategy == event.RevisionDelete {
+		if err := s.revisionDeleteEvent(ctx, id, eventID); err != nil {
+			return fmt.Errorf("delete event failed: %w", err)
+		}
+		return nil
+	}
+
 	errTX := s.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
-		evts, _, err := s.aggregateRepository.GetAggregatesEvents(txCtx, tenantID, event.PageDTO{
-			PageSize:   1,
-			SortFields: nil,
-			SearchFields: []event.SearchField{
-				{
-					Name:     event.SearchAggregateID,
-					Value:    aggregateID,
-					Operator: event.SearchEqual,
-				},
-				{
-					Name:     event.SearchAggregateType,
-					Value:    aggregateType,
-					Operator: event.SearchEqual,
-				},
-				{
-					Name:     event.SearchAggregateEventID,
-					Value:    eventID,
-					Operator: event.SearchEqual,
-				},
-			},
-			Values:     nil,
-			IsBackward: false,
-		})
+		evt, err := s.getEvent(txCtx, id, eventID)
 		if err != nil {
-			return fmt.Errorf("error retrieving event %s for aggregate %s of type %s for tenant %s:%w ", eventID, aggregateID, aggregateType, tenantID, err)
+			return err
 		}
-		if len(evts) == 0 {
-			return fmt.Errorf("could not find event %s for aggregate %s of type %s for tenant %s:%w ", eventID, aggregateID, aggregateType, tenantID, err)
-		}
-
-		evt := evts[0]
-		err = s.deleteEvent(txCtx, shared.AggregateID{
-			TenantID:      tenantID,
-			AggregateType: aggregateType,
-			AggregateID:   aggregateID,
-		}, evt)
 
+		err = s.deleteEvent(txCtx, id, evt)
 		if err != nil {
 			return fmt.Errorf("error deleting event %s for aggregate %s of type %s for tenant %s: %w", eventID, aggregateID, aggregateType, tenantID, err)
 		}
@@ -282,6 +372,128 @@ func (s *SaverService) DeleteEvent(ctx context.Context, tenantID, aggregateType,
 	return errTX
 }
 
+func (s *SaverService) getEvent(txCtx context.Context, id shared.AggregateID, eventID string) (event.PersistenceEvent, error) {
+	evts, _, err := s.aggregateRepository.GetAggregatesEvents(txCtx, id.TenantID, event.PageDTO{
+		PageSize:   1,
+		SortFields: nil,
+		SearchFields: []event.SearchField{
+			{
+				Name:     event.SearchAggregateID,
+				Value:    id.AggregateID,
+				Operator: event.SearchEqual,
+			},
+			{
+				Name:     event.SearchAggregateType,
+				Value:    id.AggregateType,
+				Operator: event.SearchEqual,
+			},
+			{
+				Name:     event.SearchAggregateEventID,
+				Value:    eventID,
+				Operator: event.SearchEqual,
+			},
+		},
+		Values:     nil,
+		IsBackward: false,
+	})
+	if err != nil {
+		return event.PersistenceEvent{}, fmt.Errorf("error retrieving event %s for aggregate %s of type %s for tenant %s:%w ", eventID, id.AggregateID, id.AggregateType, id.TenantID, err)
+	}
+	if len(evts) == 0 {
+		return event.PersistenceEvent{}, fmt.Errorf("could not find event %s for aggregate %s of type %s for tenant %s:%w ", eventID, id.AggregateID, id.AggregateType, id.TenantID, err)
+	}
+	return evts[0], nil
+}
+
+// revisionDeleteEvent appends a tombstone for the event to the aggregate stream. The tombstone is saved like any other
+// event, i.e. it gets a new version and is sent to the (consistent and eventual consistent) projections.
+func (s *SaverService) revisionDeleteEvent(ctx context.Context, id shared.AggregateID, eventID string) error {
+	var tombstone event.PersistenceEvent
+	var version int64
+	errTX := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		evt, err := s.getEvent(txCtx, id, eventID)
+		if err != nil {
+			return err
+		}
+
+		stream, err := s.aggregateRepository.GetAggregate(txCtx, id)
+		if err != nil {
+			return fmt.Errorf("get aggregate failed: %w", err)
+		}
+		if tombstone, err = stream.DeleteEvent(evt, event.GetUserID(txCtx)); err != nil {
+			return err
+		}
+		version = stream.CurrentVersion()
+		return nil
+	})
+	if errTX != nil {
+		return errTX
+	}
+
+	// the event is validated with the locked aggregate, so that it cannot be deleted (or the stream reopened) in between
+	_, err := s.saveWithRetry(ctx, id.TenantID, []event.PersistenceEvents{{Events: []event.PersistenceEvent{tombstone}, Version: int(version)}}, func(txCtx context.Context) error {
+		evt, err := s.getEvent(txCtx, id, eventID)
+		if err != nil {
+			return err
+		}
+		deleted, err := s.hasTombstone(txCtx, id, eventID)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			return fmt.Errorf("event %q is already deleted", eventID)
+		}
+		return s.rejectReopenedClose(txCtx, id, evt)
+	})
+	return err
+}
+
+// hasTombstone returns true, if the event is already deleted by a tombstone of the RevisionDelete strategy.
+func (s *SaverService) hasTombstone(txCtx context.Context, id shared.AggregateID, eventID string) (bool, error) {
+	tombstones, err := getAllAggregatesEvents(txCtx, s.aggregateRepository, id.TenantID,
+		event.SearchField{Name: event.SearchAggregateID, Value: id.AggregateID, Operator: event.SearchEqual},
+		event.SearchField{Name: event.SearchAggregateType, Value: id.AggregateType, Operator: event.SearchEqual},
+		event.SearchField{Name: event.SearchAggregateClass, Value: string(event.DeleteRevision), Operator: event.SearchEqual})
+	if err != nil {
+		return false, fmt.Errorf("error retrieving tombstones for aggregate %s of type %s for tenant %s:%w ", id.AggregateID, id.AggregateType, id.TenantID, err)
+	}
+	return aggregate.RevisionDeletedEventIDs(tombstones)[eventID], nil
+}
+
+// rejectReopenedClose returns an error, if the event is a close event, after which the stream was reopened. Only the
+// latest close event can be deleted, because it defines the close time of the stream.
+func (s *SaverService) rejectReopenedClose(txCtx context.Context, id shared.AggregateID, evt event.PersistenceEvent) error {
+	if evt.Class != event.CloseStreamEvent {
+		return nil
+	}
+	reopened, err := s.isReopenedAfter(txCtx, id, evt)
+	if err != nil {
+		return err
+	}
+	if reopened {
+		return fmt.Errorf("cannot delete close stream event %q, the stream was reopened afterward", evt.ID)
+	}
+	return nil
+}
+
+// isReopenedAfter returns true, if the stream was reopened after the close event. Deleting such a close event would
+// undo the close time of a later close event (see rejectReopenedClose).
+func (s *SaverService) isReopenedAfter(txCtx context.Context, id shared.AggregateID, closeEvt event.PersistenceEvent) (bool, error) {
+	evts, _, err := s.aggregateRepository.GetAggregatesEvents(txCtx, id.TenantID, event.PageDTO{
+		PageSize: 1,
+		SearchFields: []event.SearchField{
+			{Name: event.SearchAggregateID, Value: id.AggregateID, Operator: event.SearchEqual},
+			{Name: event.SearchAggregateType, Value: id.AggregateType, Operator: event.SearchEqual},
+			{Name: event.SearchAggregateClass, Value: string(event.ReopenStreamEvent), Operator: event.SearchEqual},
+			{Name: event.SearchAggregateVersion, Value: strconv.Itoa(closeEvt.Version), Operator: event.SearchGreaterThan},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("error retrieving reopen events after version %d for aggregate %s of type %s for tenant %s:%w ", closeEvt.Version, id.AggregateID, id.AggregateType, id.TenantID, err)
+	}
+	return len(evts) > 0, nil
+}
+
 func (s *SaverService) deleteEvent(txCtx context.Context, id shared.AggregateID, evt event.PersistenceEvent) error {
 	eventualConsistentProjIDs, consistentProjIDs, err := s.projectionRepository.GetProjectionIDsForEventTypes(id.TenantID, evt.Type)
 	if err != nil {
@@ -297,6 +509,9 @@ func (s *SaverService) deleteEvent(txCtx context.Context, id shared.AggregateID,
 
 	// Lock all projections
 	if err = s.projectionRepository.Lock(txCtx, allProjections...); err != nil {
+		if errUnLock := s.aggregateRepository.UnLock(txCtx, id); errUnLock != nil {
+			logger.Error(fmt.Errorf("unlocking of aggregates failed: %w", errUnLock))
+		}
 		return fmt.Errorf("locking of projections failed: %w", err)
 	}
 
@@ -337,6 +552,10 @@ func (s *SaverService) deleteEventFromAggregate(txCtx context.Context, id shared
 		return event.PersistenceEvent{}, fmt.Errorf("get aggregate failed: %w", err)
 	}
 
+	if err = s.rejectReopenedClose(txCtx, id, evt); err != nil {
+		return event.PersistenceEvent{}, err
+	}
+
 	var deletedEvt event.PersistenceEvent
 	deletedEvt, err = stream.DeleteEvent(evt, event.GetUserID(txCtx))
 	if err != nil {
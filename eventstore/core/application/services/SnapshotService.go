@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/registry"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"sync"
+	"time"
+)
+
+func NewSnapshotService(aggRepro repository.AggregateRepositoryInterface, saver *SaverService, transactor transactor2.Port, registries *registry.Registries) SnapshotService {
+	return SnapshotService{
+		aggregateRepository: aggRepro,
+		saver:               saver,
+		transactor:          transactor,
+		registries:          registries,
+		counters:            &snapshotCounters{inProgress: make(map[shared.AggregateID]bool), created: make(map[string]int), skipped: make(map[string]int)},
+		ctx:                 context.Background(),
+		running:             &sync.WaitGroup{},
+	}
+}
+
+type SnapshotService struct {
+	aggregateRepository repository.AggregateRepositoryInterface
+	saver               *SaverService
+	registries          *registry.Registries
+	counters            *snapshotCounters
+	ctx                 context.Context
+	running             *sync.WaitGroup
+
+	transactor transactor2.Port
+}
+
+// SetContext sets the context of the snapshots in the background. Once it is canceled, the running snapshots are
+// canceled and no new ones are started.
+func (s *SnapshotService) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// snapshotCounters tracks the snapshots of the snapshot policies in this instance (key: aggregate type).
+type snapshotCounters struct {
+	sync.Mutex
+	inProgress map[shared.AggregateID]bool
+	created    map[string]int
+	skipped    map[string]int
+}
+
+// CreateSnapshotsInBackground checks the snapshot policies of the saved aggregates and creates the due snapshots in
+// the background.
+func (s *SnapshotService) CreateSnapshotsInBackground(tenantID string, saved []event.PersistenceEvents) {
+	for _, id := range s.aggregatesWithSnapshotPolicy(tenantID, saved) {
+		if s.ctx.Err() != nil {
+			return
+		}
+		if !s.counters.start(id) {
+			continue
+		}
+
+		s.running.Add(1)
+		go func(id shared.AggregateID) {
+			defer s.running.Done()
+			defer s.counters.finish(id)
+			if err := s.CreateSnapshot(s.ctx, id); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Error(fmt.Errorf("snapshot of aggregate %q failed: %w", id, err))
+			}
+		}(id)
+	}
+}
+
+// WaitForSnapshotsInBackground waits until the snapshots in the background are finished or the context is done.
+func (s *SnapshotService) WaitForSnapshotsInBackground(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *SnapshotService) aggregatesWithSnapshotPolicy(tenantID string, saved []event.PersistenceEvents) []shared.AggregateID {
+	ids := make(map[shared.AggregateID]bool)
+	var result []shared.AggregateID
+	for _, stream := range saved {
+		for _, evt := range stream.Events {
+			// snapshots do not count for the policy
+			if evt.Class == event.SnapShot || evt.Class == event.HistoricalSnapShot {
+				continue
+			}
+			if s.registries.AggregateRegistry.Options(evt.AggregateType).Snapshotter == nil {
+				continue
+			}
+
+			id := shared.NewAggregateID(tenantID, evt.AggregateType, evt.AggregateID)
+			if !ids[id] {
+				ids[id] = true
+				result = append(result, id)
+			}
+		}
+	}
+	return result
+}
+
+// CreateSnapshot creates a snapshot of the aggregate, if its snapshot policy is due. The snapshot is written as
+// historical snapshot at the end of the latest patch-free period since the last snapshot.
+func (s *SnapshotService) CreateSnapshot(ctx context.Context, id shared.AggregateID) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "CreateSnapshot (service)", map[string]interface{}{"tenantID": id.TenantID, "aggregateType": id.AggregateType, "aggregateID": id.AggregateID})
+	defer endSpan()
+
+	opt := s.registries.AggregateRegistry.Options(id.AggregateType)
+	if opt.Snapshotter == nil {
+		return nil
+	}
+
+	now := time.Now()
+	var current event.PersistenceEvents
+	var periods []event.TimeInterval
+	errTx := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		if current, err = s.aggregateRepository.LoadAsAt(txCtx, id, now); err != nil || len(current.Events) == 0 {
+			return err
+		}
+		if !snapshotDue(opt.SnapshotPolicy, current.Events, now) {
+			current.Events = nil
+			return nil
+		}
+
+		periods, err = s.aggregateRepository.GetPatchFreePeriodsForInterval(txCtx, id.TenantID, id.AggregateType, id.AggregateID, current.Events[0].ValidTime, now)
+		return err
+	})
+	if errTx != nil {
+		return fmt.Errorf("check of snapshot policy failed: %w", errTx)
+	}
+	if len(current.Events) == 0 {
+		return nil
+	}
+
+	validTime, ok := latestEnd(periods)
+	if !ok {
+		s.counters.skip(id.AggregateType)
+		return nil
+	}
+
+	snapshot, err := s.snapshot(ctx, id, opt.Snapshotter, validTime)
+	if err != nil || snapshot == nil {
+		s.counters.skip(id.AggregateType)
+		return err
+	}
+
+	// the snapshot is only saved on top of the loaded version, also with the Ignore concurrency strategy, so that a
+	// snapshot of a stale load is never saved over newer events
+	errCh, err := s.saver.SaveWithRetry(ctx, id.TenantID, []event.PersistenceEvents{{Events: []event.PersistenceEvent{*snapshot}, Version: current.Version, Expect: event.ExpectVersion(current.Version)}})
+	var errExpectation *event.ErrorExpectationViolated
+	switch {
+	case errors.As(err, &errExpectation):
+		// a concurrent modification of the aggregate, its next save checks the policy again
+		s.counters.skip(id.AggregateType)
+		return nil
+	case err != nil:
+		s.counters.skip(id.AggregateType)
+		return fmt.Errorf("save of snapshot failed: %w", err)
+	}
+	s.counters.create(id.AggregateType)
+
+	return <-errCh
+}
+
+// snapshot returns the snapshot of the aggregate at the valid time or nil, if there are no events since the last
+// snapshot.
+func (s *SnapshotService) snapshot(ctx context.Context, id shared.AggregateID, snapshotter event.Snapshotter, validTime time.Time) (*event.PersistenceEvent, error) {
+	var asOf event.PersistenceEvents
+	errTx := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		asOf, err = s.aggregateRepository.LoadAsOf(txCtx, id, validTime)
+		return err
+	})
+	if errTx != nil {
+		return nil, fmt.Errorf("load of aggregate failed: %w", errTx)
+	}
+	if eventsSinceSnapshot(asOf.Events) == 0 {
+		return nil, nil
+	}
+
+	events := make([]event.IEvent, len(asOf.Events))
+	for i, evt := range asOf.Events {
+		var err error
+		if events[i], err = event.DeserializeEvent(evt); err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := snapshotter.Snapshot(ctx, id.TenantID, id.AggregateID, events)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotter failed: %w", err)
+	}
+
+	snapshot, err := event.NewPersistenceEvent(ctx, state, id.AggregateType)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.AggregateID = id.AggregateID
+	snapshot.TenantID = id.TenantID
+	snapshot.Class = event.HistoricalSnapShot
+	snapshot.ValidTime = validTime
+	snapshot.TransactionTime = time.Time{}
+	snapshot.FromMigration = false
+
+	return &snapshot, nil
+}
+
+// GetSnapshotCoverage returns the snapshot coverage of all aggregates of the aggregate type. The event streams are
+// streamed, so that large aggregate types are not loaded into memory at once.
+func (s *SnapshotService) GetSnapshotCoverage(ctx context.Context, tenantID, aggregateType string) (event.SnapshotCoverage, error) {
+	coverage := event.SnapshotCoverage{AggregateType: aggregateType}
+	errTx := s.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+		for stream, err := range s.aggregateRepository.StreamAllOfAggregateAsAt(txCtx, tenantID, aggregateType, time.Now()) {
+			if err != nil {
+				return err
+			}
+
+			coverage.Aggregates++
+			if len(stream.Events) > 0 && isSnapshot(stream.Events[0]) {
+				coverage.SnapshotAggregates++
+			}
+
+			count := eventsSinceSnapshot(stream.Events)
+			coverage.EventsSinceSnapshots += count
+			coverage.MaxEventsSinceSnapshot = max(coverage.MaxEventsSinceSnapshot, count)
+		}
+		return nil
+	})
+	if errTx != nil {
+		return event.SnapshotCoverage{}, fmt.Errorf("GetSnapshotCoverage failed for aggregate type %q:%w", aggregateType, errTx)
+	}
+	coverage.CreatedSnapshots, coverage.SkippedSnapshots = s.counters.get(aggregateType)
+
+	return coverage, nil
+}
+
+// snapshotDue checks the policy against the current event stream of the aggregate, which begins with its most recent
+// snapshot (if any).
+func snapshotDue(policy event.SnapshotPolicy, events []event.PersistenceEvent, now time.Time) bool {
+	count := eventsSinceSnapshot(events)
+	if count == 0 {
+		return false
+	}
+
+	if policy.EveryNEvents > 0 && count >= policy.EveryNEvents {
+		return true
+	}
+	// the transaction time of the first event is the time of the snapshot or the creation of the aggregate
+	if policy.Every > 0 && now.Sub(events[0].TransactionTime) >= policy.Every {
+		return true
+	}
+	if policy.OnClose {
+		for _, evt := range events {
+			if evt.Class == event.CloseStreamEvent {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func eventsSinceSnapshot(events []event.PersistenceEvent) int {
+	if len(events) > 0 && isSnapshot(events[0]) {
+		return len(events) - 1
+	}
+	return len(events)
+}
+
+func isSnapshot(evt event.PersistenceEvent) bool {
+	return evt.Class == event.SnapShot || evt.Class == event.HistoricalSnapShot
+}
+
+func latestEnd(periods []event.TimeInterval) (latest time.Time, ok bool) {
+	for _, period := range periods {
+		if period.End.After(latest) {
+			latest = period.End
+		}
+	}
+	return latest, !latest.IsZero()
+}
+
+func (c *snapshotCounters) start(id shared.AggregateID) bool {
+	c.Lock()
+	defer c.Unlock()
+	if c.inProgress[id] {
+		return false
+	}
+	c.inProgress[id] = true
+	return true
+}
+
+func (c *snapshotCounters) finish(id shared.AggregateID) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.inProgress, id)
+}
+
+func (c *snapshotCounters) create(aggregateType string) {
+	c.Lock()
+	defer c.Unlock()
+	c.created[aggregateType]++
+}
+
+func (c *snapshotCounters) skip(aggregateType string) {
+	c.Lock()
+	defer c.Unlock()
+	c.skipped[aggregateType]++
+}
+
+func (c *snapshotCounters) get(aggregateType string) (created, skipped int) {
+	c.Lock()
+	defer c.Unlock()
+	return c.created[aggregateType], c.skipped[aggregateType]
+}
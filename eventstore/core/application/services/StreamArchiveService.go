@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/local/commandBus"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/local/commandBus/commands"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/archive"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"time"
+)
+
+// archiveBatchSize is the number of archivable streams, which are fetched at once during ArchiveClosedStreams
+const archiveBatchSize = 100
+
+func NewStreamArchiveService(aggregatePort aggregate.Port, transactor transactor2.Port, cmdBus *commandBus.CommandPublisher) StreamArchiveService {
+	srv := StreamArchiveService{
+		aggregates: aggregatePort,
+		transactor: transactor,
+		settings:   &streamArchiveSettings{strategy: event.RehydrateArchivedStreams},
+	}
+	cmdBus.Subscribe(&srv, commands.PurgeTenant{})
+	return srv
+}
+
+// StreamArchiveService moves the events and snapshots of closed streams into the archive and back (see
+// event.StreamArchiveManagement). The loader and saver use it to rehydrate archived streams.
+type StreamArchiveService struct {
+	aggregates aggregate.Port
+	transactor transactor2.Port
+
+	// the settings are shared by all copies of the service (e.g. in the loader and saver), because the archive is
+	// configured after their creation (see eventstore.WithStreamArchive)
+	settings *streamArchiveSettings
+}
+
+type streamArchiveSettings struct {
+	archive   archive.Port
+	closedFor time.Duration
+	strategy  event.ArchivedStreamStrategy
+}
+
+func (s StreamArchiveService) SetArchive(archivePort archive.Port, closedFor time.Duration, strategy event.ArchivedStreamStrategy) {
+	s.settings.archive = archivePort
+	s.settings.closedFor = closedFor
+	s.settings.strategy = strategy
+}
+
+func (s StreamArchiveService) Execute(ctx context.Context, cmd commandBus.Command) error {
+	switch actCMD := cmd.(type) {
+	case commands.PurgeTenant:
+		if s.settings.archive == nil {
+			return nil
+		}
+		return s.settings.archive.DeleteTenant(ctx, actCMD.ID())
+	default:
+		return fmt.Errorf("command %q not found", cmd.Name())
+	}
+}
+
+func (s StreamArchiveService) ArchiveClosedStreams(ctx context.Context) (int, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "ArchiveClosedStreams (service)", nil)
+	defer endSpan()
+
+	if s.settings.archive == nil {
+		return 0, fmt.Errorf("ArchiveClosedStreams failed: no stream archive configured")
+	}
+
+	closedBefore := time.Now().Add(-s.settings.closedFor)
+	archived := 0
+	for {
+		var ids []shared.AggregateID
+		errTrans := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+			ids, err = s.aggregates.GetArchivableStreams(txCtx, closedBefore, archiveBatchSize)
+			return err
+		})
+		if errTrans != nil {
+			return archived, fmt.Errorf("ArchiveClosedStreams failed:%w", errTrans)
+		}
+
+		archivedOfBatch := 0
+		for _, id := range ids {
+			ok, err := s.archiveStream(ctx, id, closedBefore)
+			if err != nil {
+				return archived, fmt.Errorf("ArchiveClosedStreams failed for tenant %q and aggregate %q:%w", id.TenantID, id.AggregateID, err)
+			}
+			if ok {
+				archivedOfBatch++
+			}
+		}
+		archived += archivedOfBatch
+
+		// streams, which are skipped, are returned again, so that the loop ends if a batch archives nothing
+		if len(ids) < archiveBatchSize || archivedOfBatch == 0 {
+			return archived, nil
+		}
+	}
+}
+
+// archiveStream archives the stream, if it is still closed, not archived and not locked by a concurrent write. The
+// archive is written within the transaction, so that the events are only deleted if they are stored in the archive.
+func (s StreamArchiveService) archiveStream(ctx context.Context, id shared.AggregateID, closedBefore time.Time) (archived bool, err error) {
+	err = s.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		if err := s.aggregates.Lock(txCtx, id); err != nil {
+			var errConcurrent *event.ErrorConcurrentAggregateAccess
+			if errors.As(err, &errConcurrent) {
+				logger.Info("archiving of stream %q skipped: %s", id, err)
+				return nil
+			}
+			return err
+		}
+		defer func() {
+			if errUnLock := s.aggregates.UnLock(txCtx, id); errUnLock != nil {
+				logger.Error(fmt.Errorf("unlocking of aggregate failed: %w", errUnLock))
+			}
+		}()
+
+		states, _, err := s.aggregates.Get(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if len(states) == 0 || states[0].CloseTime.IsZero() || !states[0].CloseTime.Before(closedBefore) {
+			return nil
+		}
+		archiveTime, err := s.aggregates.GetArchiveTime(txCtx, id)
+		if err != nil || !archiveTime.IsZero() {
+			return err
+		}
+
+		events, snapShots, err := s.aggregates.ArchiveStream(txCtx, id, time.Now())
+		if err != nil {
+			return err
+		}
+		if err = s.settings.archive.Store(txCtx, id.TenantID, id.AggregateType, id.AggregateID, archive.Stream{Events: events, SnapShots: snapShots}); err != nil {
+			return fmt.Errorf("storing in archive failed: %w", err)
+		}
+		archived = true
+		return nil
+	})
+	return archived, err
+}
+
+func (s StreamArchiveService) RestoreArchivedStream(ctx context.Context, tenantID, aggregateType, aggregateID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "RestoreArchivedStream (service)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "aggregateID": aggregateID})
+	defer endSpan()
+
+	if s.settings.archive == nil {
+		return fmt.Errorf("RestoreArchivedStream failed: no stream archive configured")
+	}
+	if err := s.restore(ctx, shared.NewAggregateID(tenantID, aggregateType, aggregateID)); err != nil {
+		return fmt.Errorf("RestoreArchivedStream failed for tenant %q and aggregate %q:%w", tenantID, aggregateID, err)
+	}
+	return nil
+}
+
+// RehydrateTenant restores all archived streams of the tenant, e.g. before its export or the rebuild of its
+// projections, which read the events of the event store only. With the RejectArchivedStreams strategy (or without
+// configured archive), it returns ErrorStreamArchived for the first archived stream instead.
+func (s StreamArchiveService) RehydrateTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "RehydrateTenant (service)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	var ids []shared.AggregateID
+	errTrans := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		ids, err = s.aggregates.GetArchivedStreams(txCtx, tenantID)
+		return err
+	})
+	if errTrans != nil {
+		return fmt.Errorf("RehydrateTenant failed for tenant %q:%w", tenantID, errTrans)
+	}
+
+	for _, id := range ids {
+		if s.settings.archive == nil {
+			errTrans = s.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+				archiveTime, err := s.aggregates.GetArchiveTime(txCtx, id)
+				if err != nil {
+					return err
+				}
+				return s.errorStreamArchived(id, archiveTime)
+			})
+		} else {
+			_, errTrans = s.rehydrate(ctx, id)
+		}
+		if errTrans != nil {
+			return fmt.Errorf("RehydrateTenant failed for tenant %q and aggregate %q:%w", tenantID, id.AggregateID, errTrans)
+		}
+	}
+	return nil
+}
+
+// rehydrate restores the stream, if it is archived, and returns true in that case. With the RejectArchivedStreams
+// strategy, it returns ErrorStreamArchived instead.
+func (s StreamArchiveService) rehydrate(ctx context.Context, id shared.AggregateID) (bool, error) {
+	if s.settings.archive == nil {
+		return false, nil
+	}
+
+	// the (cheap) check without lock comes first, since most streams are not archived
+	var archiveTime time.Time
+	errTrans := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		archiveTime, err = s.aggregates.GetArchiveTime(txCtx, id)
+		return err
+	})
+	if errTrans != nil || archiveTime.IsZero() {
+		return false, errTrans
+	}
+	if s.settings.strategy == event.RejectArchivedStreams {
+		return false, s.errorStreamArchived(id, archiveTime)
+	}
+
+	return true, s.restore(ctx, id)
+}
+
+// rehydrateTX is the counterpart of rehydrate within a transaction, in which the streams are already locked.
+func (s StreamArchiveService) rehydrateTX(txCtx context.Context, ids ...shared.AggregateID) error {
+	if s.settings.archive == nil {
+		return nil
+	}
+
+	for _, id := range ids {
+		archiveTime, err := s.aggregates.GetArchiveTime(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if archiveTime.IsZero() {
+			continue
+		}
+		if s.settings.strategy == event.RejectArchivedStreams {
+			return s.errorStreamArchived(id, archiveTime)
+		}
+		if err = s.restoreTX(txCtx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s StreamArchiveService) restore(ctx context.Context, id shared.AggregateID) error {
+	return s.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		if err := s.aggregates.Lock(txCtx, id); err != nil {
+			return err
+		}
+		defer func() {
+			if errUnLock := s.aggregates.UnLock(txCtx, id); errUnLock != nil {
+				logger.Error(fmt.Errorf("unlocking of aggregate failed: %w", errUnLock))
+			}
+		}()
+
+		archiveTime, err := s.aggregates.GetArchiveTime(txCtx, id)
+		if err != nil || archiveTime.IsZero() {
+			return err
+		}
+		return s.restoreTX(txCtx, id)
+	})
+}
+
+func (s StreamArchiveService) restoreTX(txCtx context.Context, id shared.AggregateID) error {
+	stream, err := s.settings.archive.Load(txCtx, id.TenantID, id.AggregateType, id.AggregateID)
+	if err != nil {
+		return fmt.Errorf("loading from archive failed: %w", err)
+	}
+	return s.aggregates.RestoreStream(txCtx, id, stream.Events, stream.SnapShots)
+}
+
+func (s StreamArchiveService) errorStreamArchived(id shared.AggregateID, archiveTime time.Time) error {
+	return &event.ErrorStreamArchived{
+		TenantID:      id.TenantID,
+		AggregateType: id.AggregateType,
+		AggregateID:   id.AggregateID,
+		ArchivedAt:    archiveTime,
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"time"
+)
+
+const (
+	defaultSubscriptionPollInterval = 500 * time.Millisecond
+	subscriptionBatchSize           = 100
+)
+
+func NewSubscriptionService(aggRepro repository.AggregateRepositoryInterface, subscriptionPort subscription.Port, transactor transactor2.Port) SubscriptionService {
+	return SubscriptionService{
+		aggregateRepository: aggRepro,
+		subscriptions:       subscriptionPort,
+		pollInterval:        defaultSubscriptionPollInterval,
+		transactor:          transactor,
+	}
+}
+
+type SubscriptionService struct {
+	aggregateRepository repository.AggregateRepositoryInterface
+	subscriptions       subscription.Port
+
+	pollInterval time.Duration
+	transactor   transactor2.Port
+}
+
+func (s *SubscriptionService) SetPollInterval(interval time.Duration) {
+	s.pollInterval = interval
+}
+
+func (s *SubscriptionService) Subscribe(ctx context.Context, tenantID, subscriptionID string, from int64, handler event.SubscriptionHandler, eventTypes ...string) error {
+	position, err := s.startPosition(ctx, tenantID, subscriptionID, from)
+	if err != nil {
+		return fmt.Errorf("Subscribe failed for tenant %q and subscription %q:%w", tenantID, subscriptionID, err)
+	}
+
+	filter := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		filter[eventType] = true
+	}
+
+	for {
+		var events []event.PersistenceEvent
+		errTx := s.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+			events, err = s.aggregateRepository.LoadFromPosition(txCtx, tenantID, position, subscriptionBatchSize)
+			return err
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errTx != nil {
+			return fmt.Errorf("Subscribe failed for tenant %q and subscription %q:%w", tenantID, subscriptionID, errTx)
+		}
+
+		position, err = s.handle(ctx, tenantID, subscriptionID, position, events, handler, filter)
+		if err != nil {
+			return fmt.Errorf("Subscribe failed for tenant %q and subscription %q:%w", tenantID, subscriptionID, err)
+		}
+
+		if len(events) < subscriptionBatchSize {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.pollInterval):
+			}
+		}
+	}
+}
+
+// startPosition returns the checkpoint of the subscription, or the given position if there is no checkpoint yet.
+func (s *SubscriptionService) startPosition(ctx context.Context, tenantID, subscriptionID string, from int64) (position int64, err error) {
+	errTx := s.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+		checkpoint, found, err := s.subscriptions.GetCheckpoint(txCtx, tenantID, subscriptionID)
+		if err != nil {
+			return err
+		}
+
+		position = from
+		if found {
+			position = checkpoint.Position
+		}
+		return nil
+	})
+
+	return position, errTx
+}
+
+// handle passes the events of the filter to the handler and saves the position of the last handled event as
+// checkpoint. Events which do not pass the filter move the checkpoint, too. If the context is canceled, the remaining
+// events are not handled anymore.
+func (s *SubscriptionService) handle(ctx context.Context, tenantID, subscriptionID string, position int64, events []event.PersistenceEvent, handler event.SubscriptionHandler, filter map[string]bool) (int64, error) {
+	last := position
+	for _, evt := range events {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(filter) == 0 || filter[evt.Type] {
+			if err := handler(ctx, evt); err != nil {
+				if errSave := s.saveCheckpoint(ctx, tenantID, subscriptionID, position, last); errSave != nil {
+					return last, fmt.Errorf("handler failed for event %q: %w (%w)", evt.ID, err, errSave)
+				}
+				return last, fmt.Errorf("handler failed for event %q: %w", evt.ID, err)
+			}
+		}
+		last = evt.Position
+	}
+
+	return last, s.saveCheckpoint(ctx, tenantID, subscriptionID, position, last)
+}
+
+func (s *SubscriptionService) saveCheckpoint(ctx context.Context, tenantID, subscriptionID string, previous, position int64) error {
+	if position == previous {
+		return nil
+	}
+
+	// the checkpoint of already handled events is saved, even if the subscription is canceled
+	return s.transactor.WithinTX(context.WithoutCancel(ctx), func(txCtx context.Context) error {
+		return s.subscriptions.SaveCheckpoint(txCtx, subscription.Checkpoint{
+			TenantID:       tenantID,
+			SubscriptionID: subscriptionID,
+			Position:       position,
+		})
+	})
+}
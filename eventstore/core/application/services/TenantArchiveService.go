@@ -0,0 +1,294 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/registry"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"io"
+	"time"
+)
+
+// importBatchSize is the number of aggregates, events and snapshots, which are written at once during an import
+const importBatchSize = 1000
+
+func NewTenantArchiveService(aggregatePort aggregate.Port, projectionPort projection.Port, transactor transactor2.Port, tenants TenantService, streamArchiver StreamArchiveService, registries *registry.Registries) TenantArchiveService {
+	return TenantArchiveService{
+		aggregates:     aggregatePort,
+		projections:    projectionPort,
+		transactor:     transactor,
+		tenants:        tenants,
+		streamArchiver: streamArchiver,
+		registries:     registries,
+	}
+}
+
+// TenantArchiveService exports and imports the raw data of a tenant (see event.TenantArchiveFormat). The archived
+// streams of the tenant are restored before the export (see StreamArchiveService.RehydrateTenant), so that the archive
+// contains all events.
+type TenantArchiveService struct {
+	aggregates     aggregate.Port
+	projections    projection.Port
+	transactor     transactor2.Port
+	tenants        TenantService
+	streamArchiver StreamArchiveService
+	registries     *registry.Registries
+}
+
+func (t TenantArchiveService) ExportTenant(ctx context.Context, tenantID string, w io.Writer) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "ExportTenant (service)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	if err := t.streamArchiver.RehydrateTenant(ctx, tenantID); err != nil {
+		return fmt.Errorf("ExportTenant failed for tenant %q:%w", tenantID, err)
+	}
+
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	encoder.SetEscapeHTML(false)
+
+	errTrans := t.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+		if err := encoder.Encode(event.TenantArchiveRecord{Kind: event.TenantArchiveHeader, Header: &event.ArchiveHeader{
+			Format:     event.TenantArchiveFormat,
+			Version:    event.TenantArchiveVersion,
+			TenantID:   tenantID,
+			ExportedAt: time.Now(),
+		}}); err != nil {
+			return err
+		}
+
+		var trailer event.ArchiveTrailer
+		for state, err := range t.aggregates.ExportAggregates(txCtx, tenantID) {
+			if err != nil {
+				return err
+			}
+			if err = encoder.Encode(event.TenantArchiveRecord{Kind: event.TenantArchiveAggregate, Aggregate: toArchivedAggregate(state)}); err != nil {
+				return err
+			}
+			trailer.Aggregates++
+		}
+		for evt, err := range t.aggregates.ExportEvents(txCtx, tenantID) {
+			if err != nil {
+				return err
+			}
+			if err = encoder.Encode(event.TenantArchiveRecord{Kind: event.TenantArchiveEvent, Event: &evt}); err != nil {
+				return err
+			}
+			trailer.Events++
+		}
+		for snapShot, err := range t.aggregates.ExportSnapShots(txCtx, tenantID) {
+			if err != nil {
+				return err
+			}
+			if err = encoder.Encode(event.TenantArchiveRecord{Kind: event.TenantArchiveSnapshot, Snapshot: &snapShot}); err != nil {
+				return err
+			}
+			trailer.Snapshots++
+		}
+
+		projections, err := t.projections.GetAllForTenant(txCtx, tenantID)
+		if err != nil {
+			return err
+		}
+		for _, proj := range projections {
+			if err = encoder.Encode(event.TenantArchiveRecord{Kind: event.TenantArchiveProjection, Projection: toArchivedProjection(proj)}); err != nil {
+				return err
+			}
+			trailer.Projections++
+		}
+
+		return encoder.Encode(event.TenantArchiveRecord{Kind: event.TenantArchiveTrailer, Trailer: &trailer})
+	})
+	if errTrans != nil {
+		return fmt.Errorf("ExportTenant failed for tenant %q:%w", tenantID, errTrans)
+	}
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("ExportTenant failed for tenant %q:%w", tenantID, err)
+	}
+	return nil
+}
+
+func (t TenantArchiveService) ImportTenant(ctx context.Context, r io.Reader) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "ImportTenant (service)", nil)
+	defer endSpan()
+
+	decoder, err := newArchiveDecoder(r)
+	if err != nil {
+		return fmt.Errorf("ImportTenant failed:%w", err)
+	}
+
+	var header event.TenantArchiveRecord
+	if err = decoder.Decode(&header); err != nil {
+		return fmt.Errorf("ImportTenant failed: could not read header:%w", err)
+	}
+	if header.Kind != event.TenantArchiveHeader || header.Header == nil {
+		return fmt.Errorf("ImportTenant failed: archive starts with %q instead of a header", header.Kind)
+	}
+	if err = header.Header.Validate(); err != nil {
+		return fmt.Errorf("ImportTenant failed:%w", err)
+	}
+	tenantID := header.Header.TenantID
+
+	errTrans := t.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		for _, err := range t.aggregates.ExportAggregates(txCtx, tenantID) {
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("tenant already contains aggregates")
+		}
+		return t.importRecords(txCtx, tenantID, decoder)
+	})
+	if errTrans != nil {
+		return fmt.Errorf("ImportTenant failed for tenant %q:%w", tenantID, errTrans)
+	}
+
+	// the projection states of the archive already exist, so only the missing ones are created
+	if !t.registries.TenantRegistry.Exists(tenantID) {
+		if err = t.tenants.registerAndInitNewTenant(ctx, tenantID); err != nil {
+			return fmt.Errorf("ImportTenant failed for tenant %q:%w", tenantID, err)
+		}
+	}
+	return nil
+}
+
+func (t TenantArchiveService) importRecords(txCtx context.Context, tenantID string, decoder *json.Decoder) error {
+	var states []aggregate.DTO
+	var events, snapShots []event.PersistenceEvent
+	var projections []projection.DTO
+	var counted event.ArchiveTrailer
+
+	flush := func() error {
+		err := t.aggregates.Import(txCtx, states, events, snapShots)
+		states, events, snapShots = nil, nil, nil
+		return err
+	}
+
+	for {
+		var record event.TenantArchiveRecord
+		if err := decoder.Decode(&record); errors.Is(err, io.EOF) {
+			return fmt.Errorf("incomplete archive: missing trailer")
+		} else if err != nil {
+			return fmt.Errorf("could not read record: %w", err)
+		}
+
+		switch {
+		case record.Kind == event.TenantArchiveAggregate && record.Aggregate != nil:
+			states = append(states, fromArchivedAggregate(tenantID, *record.Aggregate))
+			counted.Aggregates++
+		case record.Kind == event.TenantArchiveEvent && record.Event != nil:
+			evt, err := fromArchivedEvent(tenantID, *record.Event)
+			if err != nil {
+				return err
+			}
+			events = append(events, evt)
+			counted.Events++
+		case record.Kind == event.TenantArchiveSnapshot && record.Snapshot != nil:
+			snapShot, err := fromArchivedEvent(tenantID, *record.Snapshot)
+			if err != nil {
+				return err
+			}
+			snapShots = append(snapShots, snapShot)
+			counted.Snapshots++
+		case record.Kind == event.TenantArchiveProjection && record.Projection != nil:
+			projections = append(projections, fromArchivedProjection(tenantID, *record.Projection))
+			counted.Projections++
+		case record.Kind == event.TenantArchiveTrailer && record.Trailer != nil:
+			if *record.Trailer != counted {
+				return fmt.Errorf("incomplete archive: trailer expects %+v, but archive contains %+v", *record.Trailer, counted)
+			}
+			if decoder.More() {
+				return fmt.Errorf("invalid archive: records after trailer")
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+			return t.projections.SaveStates(txCtx, projections...)
+		default:
+			return fmt.Errorf("invalid archive: unknown or empty record of kind %q", record.Kind)
+		}
+
+		if len(states)+len(events)+len(snapShots) >= importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// newArchiveDecoder detects gzip compressed archives by their magic number.
+func newArchiveDecoder(r io.Reader) (*json.Decoder, error) {
+	buffered := bufio.NewReader(r)
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		unzipped, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress archive: %w", err)
+		}
+		return json.NewDecoder(unzipped), nil
+	}
+	return json.NewDecoder(buffered), nil
+}
+
+func toArchivedAggregate(state aggregate.DTO) *event.ArchivedAggregate {
+	return &event.ArchivedAggregate{
+		AggregateType:       state.AggregateType,
+		AggregateID:         state.AggregateID,
+		CurrentVersion:      state.CurrentVersion,
+		LastTransactionTime: state.LastTransactionTime,
+		LatestValidTime:     state.LatestValidTime,
+		CreateTime:          state.CreateTime,
+		CloseTime:           state.CloseTime,
+	}
+}
+
+func fromArchivedAggregate(tenantID string, state event.ArchivedAggregate) aggregate.DTO {
+	return aggregate.DTO{
+		TenantID:            tenantID,
+		AggregateType:       state.AggregateType,
+		AggregateID:         state.AggregateID,
+		CurrentVersion:      state.CurrentVersion,
+		LastTransactionTime: state.LastTransactionTime,
+		LatestValidTime:     state.LatestValidTime,
+		CreateTime:          state.CreateTime,
+		CloseTime:           state.CloseTime,
+	}
+}
+
+func fromArchivedEvent(tenantID string, evt event.PersistenceEvent) (event.PersistenceEvent, error) {
+	if evt.TenantID != tenantID {
+		return event.PersistenceEvent{}, fmt.Errorf("invalid archive: event %q belongs to tenant %q instead of %q", evt.ID, evt.TenantID, tenantID)
+	}
+	// events without data are written as JSON null
+	if bytes.Equal(evt.Data, []byte("null")) {
+		evt.Data = nil
+	}
+	evt.FromMigration = true
+	return evt, nil
+}
+
+func toArchivedProjection(proj projection.DTO) *event.ArchivedProjection {
+	return &event.ArchivedProjection{
+		ProjectionID: proj.ProjectionID,
+		State:        proj.State,
+		Generation:   proj.Generation,
+		UpdatedAt:    proj.UpdatedAt,
+	}
+}
+
+func fromArchivedProjection(tenantID string, proj event.ArchivedProjection) projection.DTO {
+	return projection.DTO{
+		TenantID:     tenantID,
+		ProjectionID: proj.ProjectionID,
+		State:        proj.State,
+		Generation:   proj.Generation,
+		UpdatedAt:    proj.UpdatedAt,
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/local/commandBus"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/local/commandBus/commands"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/registry"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"time"
+)
+
+func NewTenantService(tenantPort tenant.Port, transactor transactor2.Port, cmdBus *commandBus.CommandPublisher, registries *registry.Registries) TenantService {
+	return TenantService{
+		tenants:    tenantPort,
+		transactor: transactor,
+		cmdBus:     cmdBus,
+		registries: registries,
+	}
+}
+
+// TenantService manages the lifecycle of the tenants (see event.TenantManagement).
+type TenantService struct {
+	tenants    tenant.Port
+	transactor transactor2.Port
+	cmdBus     *commandBus.CommandPublisher
+	registries *registry.Registries
+}
+
+func (t TenantService) ListTenants(ctx context.Context) ([]event.Tenant, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "ListTenants (service)", nil)
+	defer endSpan()
+
+	var tenants []tenant.DTO
+	errTrans := t.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		tenants, err = t.tenants.GetAll(txCtx)
+		return err
+	})
+	if errTrans != nil {
+		return nil, fmt.Errorf("ListTenants failed:%w", errTrans)
+	}
+
+	result := make([]event.Tenant, len(tenants))
+	for i, tnt := range tenants {
+		result[i] = event.Tenant(tnt)
+	}
+	return result, nil
+}
+
+func (t TenantService) SuspendTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "SuspendTenant (service)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	if err := t.setStatus(ctx, tenantID, event.TenantSuspended); err != nil {
+		return fmt.Errorf("SuspendTenant failed for tenant %q:%w", tenantID, err)
+	}
+	if err := t.cmdBus.Execute(ctx, commands.CmdStopTenant(tenantID)); err != nil {
+		return fmt.Errorf("SuspendTenant failed for tenant %q:%w", tenantID, err)
+	}
+	return nil
+}
+
+func (t TenantService) ResumeTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "ResumeTenant (service)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	if err := t.setStatus(ctx, tenantID, event.TenantActive); err != nil {
+		return fmt.Errorf("ResumeTenant failed for tenant %q:%w", tenantID, err)
+	}
+	if !t.registries.TenantRegistry.Exists(tenantID) {
+		if err := t.registerAndInitNewTenant(ctx, tenantID); err != nil {
+			return fmt.Errorf("ResumeTenant failed for tenant %q:%w", tenantID, err)
+		}
+	}
+	if err := t.cmdBus.Execute(ctx, commands.CmdResumeTenant(tenantID)); err != nil {
+		return fmt.Errorf("ResumeTenant failed for tenant %q:%w", tenantID, err)
+	}
+	return nil
+}
+
+// PurgeTenant deletes the data of a suspended tenant. A tenant, which does not exist (anymore), is accepted, so that
+// a failed purge of the read models can be repeated.
+func (t TenantService) PurgeTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "PurgeTenant (service)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	errTrans := t.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		tnt, found, err := t.tenants.Get(txCtx, tenantID)
+		if err != nil {
+			return err
+		}
+		if found && tnt.Status != event.TenantSuspended {
+			return fmt.Errorf("tenant must be suspended before it is purged (status %q)", tnt.Status)
+		}
+		return t.tenants.Purge(txCtx, tenantID)
+	})
+	if errTrans != nil {
+		return fmt.Errorf("PurgeTenant failed for tenant %q:%w", tenantID, errTrans)
+	}
+
+	if err := t.cmdBus.Execute(ctx, commands.CmdPurgeTenant(tenantID)); err != nil {
+		return fmt.Errorf("PurgeTenant failed for tenant %q:%w", tenantID, err)
+	}
+	return nil
+}
+
+func (t TenantService) setStatus(ctx context.Context, tenantID string, status event.TenantStatus) error {
+	return t.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		if _, found, err := t.tenants.Get(txCtx, tenantID); err != nil {
+			return err
+		} else if !found {
+			return fmt.Errorf("tenant not found")
+		}
+		return t.tenants.SetStatus(txCtx, tenantID, status, time.Now())
+	})
+}
+
+// registerAndInitNewTenant registers the tenant in the database (if it is new) and initializes it in this instance.
+// Suspended tenants are rejected with event.ErrorTenantSuspended.
+func (t TenantService) registerAndInitNewTenant(ctx context.Context, tenantID string) error {
+	var registered tenant.DTO
+	errTrans := t.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
+		registered, err = t.tenants.Register(txCtx, tenantID, time.Now())
+		return err
+	})
+	if errTrans != nil {
+		return fmt.Errorf("could not register and init new tenant %q: %w", tenantID, errTrans)
+	}
+	if registered.Status == event.TenantSuspended {
+		return &event.ErrorTenantSuspended{TenantID: tenantID}
+	}
+
+	if err := t.registries.TenantRegistry.Register(tenantID); err != nil {
+		return fmt.Errorf("could not register and init new tenant %q: %w", tenantID, err)
+	}
+	if err := t.cmdBus.Execute(ctx, commands.CmdCreateTenant(tenantID)); err != nil {
+		return fmt.Errorf("could not register and init new tenant %q: %w", tenantID, err)
+	}
+	return nil
+}
+
+// rejectSuspendedTenant returns event.ErrorTenantSuspended, if the tenant is suspended. Within a transaction, the
+// tenant cannot be suspended until the end of the transaction.
+func (t TenantService) rejectSuspendedTenant(txCtx context.Context, tenantID string) error {
+	tnt, found, err := t.tenants.Get(txCtx, tenantID)
+	if err != nil {
+		return err
+	}
+	if found && tnt.Status == event.TenantSuspended {
+		return &event.ErrorTenantSuspended{TenantID: tenantID}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package projection
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/local/commandBus"
@@ -12,32 +13,41 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/services/projection/executors"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
 	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared/rateWorker"
-	scheduler2 "github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/scheduler"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
 	"time"
 )
 
-func NewProjectionService(projRepro repository.ProjectionRepositoryInterface, transactor transactor2.Port, evtBus *eventBus.EventPublisher, cmdBus *commandBus.CommandPublisher, registries *registry.Registries) ProjectionService {
+func NewProjectionService(projRepro repository.ProjectionRepositoryInterface, schedulerPort scheduler.Port, notifierPort notifier.Port, tenantPort tenant.Port, transactor transactor2.Port, evtBus *eventBus.EventPublisher, cmdBus *commandBus.CommandPublisher, registries *registry.Registries) ProjectionService {
 	srv := ProjectionService{
 		projectionRepository: projRepro,
 		transactor:           transactor,
-		scheduler:            scheduler2.NewAdapter(),
+		scheduler:            schedulerPort,
+		timers:               newScheduledTimers(),
+		notifier:             notifierPort,
+		tenants:              tenantPort,
+		listener:             &notificationListener{},
 		registries:           registries,
 	}
 
 	evtBus.Subscribe(&srv, domainEvents2.ProjectionSaved{}, domainEvents2.StoreStarted{}, domainEvents2.FuturePatchSaved{})
-	cmdBus.Subscribe(&srv, commands.CreateTenant{}, commands.ExecuteProjection{}, commands.DeleteEvent{})
+	cmdBus.Subscribe(&srv, commands.CreateTenant{}, commands.StopTenant{}, commands.ResumeTenant{}, commands.PurgeTenant{}, commands.ExecuteProjection{}, commands.DeleteEvent{})
 	return srv
 }
 
 type ProjectionService struct {
 	projectionRepository repository.ProjectionRepositoryInterface
 	scheduler            scheduler.Port
+	timers               *scheduledTimers
+	notifier             notifier.Port
+	tenants              tenant.Port
+	listener             *notificationListener
 	transactor           transactor2.Port
 	registries           *registry.Registries
 
@@ -52,6 +62,12 @@ func (p *ProjectionService) Execute(ctx context.Context, cmd commandBus.Command)
 		return p.ConsistentProjection(ctx, actCMD.Streams())
 	case commands.CreateTenant:
 		return p.InitProjectionServiceForNewTenant(ctx, actCMD.ID())
+	case commands.StopTenant:
+		return p.StopProjectionServiceForTenant(ctx, actCMD.ID())
+	case commands.ResumeTenant:
+		return p.ResumeProjectionServiceForTenant(ctx, actCMD.ID())
+	case commands.PurgeTenant:
+		return p.PurgeProjectionsOfTenant(ctx, actCMD.ID())
 	case commands.DeleteEvent:
 		return p.deleteEvent(ctx, actCMD.Streams())
 	default:
@@ -99,6 +115,58 @@ func (p *ProjectionService) InitProjectionServiceForNewTenant(ctx context.Contex
 	return nil
 }
 
+// StopProjectionServiceForTenant stops the workers and scheduled tasks of a suspended tenant in this instance only,
+// other instances keep running them. The tenant is initialized again with InitProjectionServiceForNewTenant and
+// ResumeProjectionServiceForTenant, once it is resumed.
+func (p *ProjectionService) StopProjectionServiceForTenant(_ context.Context, tenantID string) error {
+	if err := p.registries.TenantRegistry.Suspend(tenantID); err != nil {
+		return fmt.Errorf("suspend tenant %q failed:%w", tenantID, err)
+	}
+	p.timers.removeTenant(tenantID)
+
+	for _, proj := range p.registries.ProjectionRegistry.All() {
+		id := shared.NewProjectionID(tenantID, proj.ID())
+		if !p.registries.WorkerRegistry.Exists(id) {
+			continue
+		}
+		if err := p.registries.WorkerRegistry.Shutdown(id, p.registries.ProjectionRegistry.Options(proj.ID()).QueueLanes()); err != nil {
+			return fmt.Errorf("stop worker failed for %v: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ResumeProjectionServiceForTenant re-arms the scheduled tasks of a resumed tenant, which were stopped by
+// StopProjectionServiceForTenant. Its workers are already initialized again with InitProjectionServiceForNewTenant.
+func (p *ProjectionService) ResumeProjectionServiceForTenant(ctx context.Context, tenantID string) error {
+	var ids []shared.ProjectionID
+	for _, proj := range p.registries.ProjectionRegistry.All() {
+		ids = append(ids, shared.NewProjectionID(tenantID, proj.ID()))
+	}
+	if err := p.restartScheduledTasks(ctx, ids...); err != nil {
+		return fmt.Errorf("restart of scheduled tasks failed for tenant %q:%w", tenantID, err)
+	}
+	return nil
+}
+
+// PurgeProjectionsOfTenant stops the projection service for a purged tenant and purges the read models of the
+// projections, which implement event.ProjectionPurge.
+func (p *ProjectionService) PurgeProjectionsOfTenant(ctx context.Context, tenantID string) error {
+	if err := p.StopProjectionServiceForTenant(ctx, tenantID); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, proj := range p.registries.ProjectionRegistry.All() {
+		if purge, ok := proj.(event.ProjectionPurge); ok {
+			if err := purge.PurgeTenant(ctx, tenantID); err != nil {
+				errs = append(errs, fmt.Errorf("purge of projection %q failed: %w", proj.ID(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (p *ProjectionService) registerTenant(_ context.Context, tenantID string) error {
 	if p.registries.TenantRegistry.Exists(tenantID) {
 		return nil // tenant already registered
@@ -126,11 +194,19 @@ func (p *ProjectionService) initProjectionWorkers(tenantID string) ([]shared.Pro
 
 func (p *ProjectionService) initProjectionWorker(tenantID string, projectionID string) (projID shared.ProjectionID, err error) {
 	sharedProjID := shared.ProjectionID{TenantID: tenantID, ProjectionID: projectionID}
+	opt := p.registries.ProjectionRegistry.Options(projectionID)
 
-	if err = p.registries.WorkerRegistry.CreateAndStart(sharedProjID, p.registries.ProjectionRegistry.Options(projectionID).InputQueueLength); err != nil {
+	if err = p.registries.WorkerRegistry.CreateAndStart(sharedProjID, opt.InputQueueLength); err != nil {
 		return shared.ProjectionID{}, fmt.Errorf("start worker failed for %v: %w", sharedProjID, err)
 	}
 
+	// projections partitioned into lanes have an additional worker per lane (see EventualConsistentProjection)
+	for lane := 0; opt.QueueLanes() > 1 && lane < opt.QueueLanes(); lane++ {
+		if err = p.registries.WorkerRegistry.CreateAndStartLane(sharedProjID, lane, opt.InputQueueLength); err != nil {
+			return shared.ProjectionID{}, fmt.Errorf("start worker failed for lane %d of %v: %w", lane, sharedProjID, err)
+		}
+	}
+
 	return sharedProjID, nil
 }
 
@@ -142,14 +218,24 @@ func (p *ProjectionService) initProjectionWorker(tenantID string, projectionID s
 // If after finishing the first request and before finishing the last request, we get a new request, the worker will execute them
 // after he finished the last request.
 func (p *ProjectionService) rateLimitedProjectionExecution(ctx context.Context, id shared.ProjectionID, execute func(ctx context.Context, id shared.ProjectionID) error) chan error {
-	errCh := make(chan error, 1)
 	queue, err := p.registries.WorkerRegistry.Queue(id)
 	if err != nil {
-		errCh <- err
-		close(errCh)
-		return errCh
+		return errorChannel(err)
+	}
+	return enqueueProjectionExecution(ctx, id, queue, execute)
+}
+
+// rateLimitedLaneExecution: same as rateLimitedProjectionExecution, but with the worker of a single lane of the projection.
+func (p *ProjectionService) rateLimitedLaneExecution(ctx context.Context, id shared.ProjectionID, lane int, execute func(ctx context.Context, id shared.ProjectionID) error) chan error {
+	queue, err := p.registries.WorkerRegistry.LaneQueue(id, lane)
+	if err != nil {
+		return errorChannel(err)
 	}
+	return enqueueProjectionExecution(ctx, id, queue, execute)
+}
 
+func enqueueProjectionExecution(ctx context.Context, id shared.ProjectionID, queue chan rateWorker.ExecutionParams[error], execute func(ctx context.Context, id shared.ProjectionID) error) chan error {
+	errCh := make(chan error, 1)
 	executionParams := rateWorker.ExecutionParams[error]{
 		Ctx:      ctx,
 		ResultCh: errCh,
@@ -159,6 +245,13 @@ func (p *ProjectionService) rateLimitedProjectionExecution(ctx context.Context,
 	return errCh
 }
 
+func errorChannel(err error) chan error {
+	errCh := make(chan error, 1)
+	errCh <- err
+	close(errCh)
+	return errCh
+}
+
 func (p *ProjectionService) createProjection(ctx context.Context, projIDs ...shared.ProjectionID) error {
 	errTx := p.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
 		// in init case, due to concurrency, we have to lock the projectionsIDs,
@@ -215,6 +308,12 @@ func (p *ProjectionService) EventualConsistentProjection(ctx context.Context, id
 	ctx, endSpan := metrics.StartSpan(ctx, "EventualConsistentProjection", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID, "hPatch": hPatch})
 	defer endSpan()
 
+	// Without historical patches, the lanes of a partitioned projection are executed in parallel by their own workers.
+	// Historical patches affect the whole projection and are therefore still executed by the worker of the projection.
+	if lanes := p.registries.ProjectionRegistry.Options(id.ProjectionID).QueueLanes(); lanes > 1 && hPatch.IsZero() {
+		return p.eventualConsistentProjectionOfLanes(ctx, id, lanes)
+	}
+
 	return p.rateLimitedProjectionExecution(ctx, id, func(ctx context.Context, id shared.ProjectionID) error {
 		opt := p.registries.ProjectionRegistry.Options(id.ProjectionID)
 
@@ -222,6 +321,30 @@ func (p *ProjectionService) EventualConsistentProjection(ctx context.Context, id
 	})
 }
 
+// eventualConsistentProjectionOfLanes executes all lanes of the projection and merges their results into a single
+// channel, which is closed after all lanes are finished.
+func (p *ProjectionService) eventualConsistentProjectionOfLanes(ctx context.Context, id shared.ProjectionID, lanes int) chan error {
+	laneErrChs := make([]chan error, lanes)
+	for lane := range laneErrChs {
+		laneErrChs[lane] = p.rateLimitedLaneExecution(ctx, id, lane, func(ctx context.Context, id shared.ProjectionID) error {
+			opt := p.registries.ProjectionRegistry.Options(id.ProjectionID)
+
+			return p.executeProjection(ctx, executors.NewEventualConsistentProjectionLaneExecutor(p.transactor, p.projectionRepository, id, lane, opt))
+		})
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var errs []error
+		for _, laneErrCh := range laneErrChs {
+			errs = append(errs, <-laneErrCh)
+		}
+		errCh <- errors.Join(errs...)
+		close(errCh)
+	}()
+	return errCh
+}
+
 func (p *ProjectionService) executeProjection(ctx context.Context, executor executors.IExecuter) error {
 	if !executor.HasHPatch() {
 		return executor.Run(ctx)
@@ -270,6 +393,49 @@ func (p *ProjectionService) Rebuild(ctx context.Context, id shared.ProjectionID,
 	return p.EventualConsistentProjection(ctx, id, time.Time{})
 }
 
+// RebuildBlueGreen rebuilds a projection into the shadow read model of its next generation and switches to it, once
+// the shadow caught up with the live projection (see executors.BlueGreenProjectionExecutor). In contrast to Rebuild,
+// the live projection is still executed during the rebuild, i.e. new events can be stored for consistent projections
+// as well. The rebuild is executed asynchronously by the worker of the projection, i.e. never in parallel to another
+// rebuild or execution of the projection by this worker.
+func (p *ProjectionService) RebuildBlueGreen(ctx context.Context, id shared.ProjectionID) chan error {
+	ctx, endSpan := metrics.StartSpan(ctx, "RebuildBlueGreen", map[string]interface{}{"tenantID": id.TenantID, "projectionID": id.ProjectionID})
+	defer endSpan()
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+
+		live, err := p.rebuildBlueGreen(ctx, id)
+		if err != nil {
+			errCh <- fmt.Errorf("blue/green rebuild failed for projection %s of tenant %s: %w", id.ProjectionID, id.TenantID, err)
+			return
+		}
+
+		// execute the events, which the live projection has not executed before the switch, with the new generation (a
+		// stopped projection executes them, once it is started again)
+		if live.State() != projection.Running {
+			errCh <- nil
+			return
+		}
+		errCh <- <-p.EventualConsistentProjection(ctx, id, time.Time{})
+	}()
+	return errCh
+}
+
+// rebuildBlueGreen queues the rebuild again, as long as the worker skipped it due to its rate limit and answered it
+// with the result of another (successful) execution (see rateWorker.RateLimitedWorker).
+func (p *ProjectionService) rebuildBlueGreen(ctx context.Context, id shared.ProjectionID) (live projection.Stream, err error) {
+	for executed := false; !executed && err == nil; {
+		err = <-p.rateLimitedProjectionExecution(ctx, id, func(ctx context.Context, id shared.ProjectionID) (errRebuild error) {
+			executed = true
+			live, errRebuild = executors.NewBlueGreenProjectionExecutor(p.transactor, p.projectionRepository, id).Rebuild(ctx)
+			return errRebuild
+		})
+	}
+	return live, err
+}
+
 /// -------------------------------------------------HardDelete Event-------------------------------------------------------
 
 // deleteEvent deletes an event from the projection. This is always done in a consistent projection execution.
@@ -299,16 +465,3 @@ func (p *ProjectionService) executeProjectionsToDeleteEvent(ctx context.Context,
 		return fmt.Errorf("delete patch strategy %q not found", executor.GetOptions().DPatchStrategy)
 	}
 }
-
-/// ---------------------------------------------------- Scheduler -----------------------------------------------------
-
-func (p *ProjectionService) AddTaskToScheduler(ctx context.Context, task scheduler.ScheduledProjectionTask) chan error {
-	resCh := make(chan error, 1)
-	go func(resCh chan error) {
-		if err := p.scheduler.AddTasks(ctx, task); err != nil {
-			resCh <- fmt.Errorf("AddTaskToScheduler() failed for %q:%w", task, err)
-		}
-		close(resCh)
-	}(resCh)
-	return resCh
-}
@@ -0,0 +1,67 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
+	projPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/samber/lo"
+)
+
+// ----------- Projection-Health ---------------------------------------------------------------------------------------
+
+// GetProjectionHealth returns the health of the projections of a tenant
+func (p *ProjectionService) GetProjectionHealth(ctx context.Context, tenantID string, projectionIDs ...string) ([]event.ProjectionHealth, error) {
+	health, err := p.getProjectionHealth(ctx, func(txCtx context.Context) ([]projection.Stream, error) {
+		return p.projectionRepository.GetProjections(txCtx, shared.NewProjectionIDs(tenantID, projectionIDs...)...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetProjectionHealth failed for projections %q:%w", projectionIDs, err)
+	}
+
+	return health, nil
+}
+
+// GetAllProjectionHealth returns the health of all projections of a tenant
+func (p *ProjectionService) GetAllProjectionHealth(ctx context.Context, tenantID string) ([]event.ProjectionHealth, error) {
+	health, err := p.getProjectionHealth(ctx, func(txCtx context.Context) ([]projection.Stream, error) {
+		return p.projectionRepository.GetAllForTenant(txCtx, tenantID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetAllProjectionHealth failed for tenant %q:%w", tenantID, err)
+	}
+
+	return health, nil
+}
+
+func (p *ProjectionService) getProjectionHealth(ctx context.Context, getStreams func(txCtx context.Context) ([]projection.Stream, error)) ([]event.ProjectionHealth, error) {
+	var streams []projection.Stream
+	var statistics []projPort.QueueStatistics
+	errTx := p.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		if streams, err = getStreams(txCtx); err != nil {
+			return err
+		}
+
+		statistics, err = p.projectionRepository.GetQueueStatistics(txCtx, lo.Map(streams, func(stream projection.Stream, _ int) shared.ProjectionID {
+			return stream.ID()
+		})...)
+		return err
+	})
+	if errTx != nil {
+		return nil, errTx
+	}
+
+	statisticsByID := lo.KeyBy(statistics, func(statistic projPort.QueueStatistics) shared.ProjectionID {
+		return statistic.ID
+	})
+
+	var health []event.ProjectionHealth
+	for _, stream := range streams {
+		fill, length := p.registries.WorkerRegistry.QueueFill(stream.ID())
+		health = append(health, mapper.MapStreamToProjectionHealth(stream, statisticsByID[stream.ID()], fill, length))
+	}
+	return health, nil
+}
@@ -161,6 +161,16 @@ func (p *ProjectionService) RestartProjectionService(ctx context.Context) (errCh
 		return errCh
 	}
 
+	// suspended tenants are not restarted (see StopProjectionServiceForTenant)
+	if err = p.loadSuspendedTenants(ctx); err != nil {
+		errCh <- fmt.Errorf("retrieval of suspended tenants failed:%w", err)
+		close(errCh)
+		return errCh
+	}
+	storedProjections = slices.DeleteFunc(storedProjections, func(i projection.Stream) bool {
+		return p.registries.TenantRegistry.IsSuspended(i.ID().TenantID)
+	})
+
 	storedProjectionsMap := lo.GroupBy(storedProjections, func(i projection.Stream) string {
 		return i.ID().TenantID
 	})
@@ -193,6 +203,12 @@ func (p *ProjectionService) RestartProjectionService(ctx context.Context) (errCh
 	if err = p.ExecuteAllProjections(ctx); err != nil {
 		errCh <- fmt.Errorf("initial execute of projections failed:%w", err)
 	}
+	// re-arm the scheduled tasks (e.g. future patches) of all stored projections
+	if err = p.restartScheduledTasks(ctx, lo.Map(storedProjections, func(i projection.Stream, _ int) shared.ProjectionID { return i.ID() })...); err != nil {
+		errCh <- fmt.Errorf("restart of scheduled tasks failed:%w", err)
+	}
+	// wake up projections on notifications of other instances (if enabled)
+	p.startNotificationListener()
 	close(errCh)
 	return errCh
 }
@@ -214,9 +230,32 @@ func (p *ProjectionService) ExecuteAllProjections(ctx context.Context, projectio
 		})
 	}
 
+	// suspended tenants have no workers in this instance
+	storedProjectionsOfAllTenants = slices.DeleteFunc(storedProjectionsOfAllTenants, func(i projection.Stream) bool {
+		return p.registries.TenantRegistry.IsSuspended(i.ID().TenantID)
+	})
+
 	return p.executeProjections(ctx, storedProjectionsOfAllTenants)
 }
 
+func (p *ProjectionService) loadSuspendedTenants(ctx context.Context) error {
+	return p.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+		tenants, err := p.tenants.GetAll(txCtx)
+		if err != nil {
+			return fmt.Errorf("GetAll() failed:%w", err)
+		}
+		for _, tnt := range tenants {
+			if tnt.Status != event.TenantSuspended {
+				continue
+			}
+			if err = p.registries.TenantRegistry.Suspend(tnt.TenantID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (p *ProjectionService) getAllStoredProjections(ctx context.Context) (allProjections []projection.Stream, err error) {
 	errTx := p.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
 		allProjections, err = p.projectionRepository.GetAllForAllTenants(txCtx)
@@ -0,0 +1,100 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"sync"
+	"time"
+)
+
+var notificationRetryDurations = []time.Duration{5, 10, 100, 385, 500}
+
+// ----------- Notifications -------------------------------------------------------------------------------------------
+//
+// Eventual consistent projections are executed by the instance (pod) which saved the events (see ProjectionSaved).
+// Other instances of the event store are woken up via notifications of the persistence adapter (e.g. LISTEN/NOTIFY in
+// postgres), so that a projection whose worker lives in another instance picks up new events immediately. Listening is
+// optional (see eventstore.WithProjectionNotifications) and starts together with the projection service.
+
+type notificationListener struct {
+	sync.Mutex
+	ctx     context.Context
+	started bool
+}
+
+// SetNotificationContext enables the listening for notifications of other instances. The listener runs until the
+// given context is canceled.
+func (p *ProjectionService) SetNotificationContext(ctx context.Context) {
+	p.listener.Lock()
+	defer p.listener.Unlock()
+
+	p.listener.ctx = ctx
+}
+
+// startNotificationListener starts the listener (once), if listening is enabled.
+func (p *ProjectionService) startNotificationListener() {
+	p.listener.Lock()
+	defer p.listener.Unlock()
+
+	if p.listener.ctx == nil || p.listener.started {
+		return
+	}
+	p.listener.started = true
+
+	go func(ctx context.Context) {
+		if err := p.notifier.Listen(ctx, p.receiveNotification); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error(fmt.Errorf("listening for projection notifications failed: %w", err))
+		}
+	}(p.listener.ctx)
+}
+
+func (p *ProjectionService) receiveNotification(ctx context.Context, notification notifier.Notification) {
+	// the own instance already executed the projection (see ProjectionSaved)
+	if notification.Origin == p.registries.InstanceID {
+		return
+	}
+	if _, err := p.registries.ProjectionRegistry.Projection(notification.Id.ProjectionID); err != nil {
+		return // projection is not registered in this instance
+	}
+	if projType := p.registries.ProjectionRegistry.Options(notification.Id.ProjectionID).ProjectionType; projType != event.ESS && projType != event.ECS {
+		return
+	}
+
+	if exists := p.registries.TenantRegistry.Exists(notification.Id.TenantID); !exists {
+		if err := p.InitProjectionServiceForNewTenant(ctx, notification.Id.TenantID); err != nil {
+			logger.Error(fmt.Errorf("init projection service for notification %v failed: %w", notification.Id, err))
+			return
+		}
+	}
+
+	// the listener must not wait for the execution of the projection
+	go p.executeAfterNotification(ctx, notification)
+}
+
+// executeAfterNotification retries the execution, because it can be rejected by a concurrent execution of the same
+// projection (e.g. by the instance which saved the events). Otherwise, the wake-up would be lost.
+func (p *ProjectionService) executeAfterNotification(ctx context.Context, notification notifier.Notification) {
+	var err error
+	for _, retryDuration := range notificationRetryDurations {
+		if err = p.executeOnce(ctx, notification); err == nil || ctx.Err() != nil {
+			return
+		}
+		time.Sleep(retryDuration * time.Millisecond)
+	}
+	if err = p.executeOnce(ctx, notification); err != nil && ctx.Err() == nil {
+		logger.Error(fmt.Errorf("execution of projection %v after notification failed: %w", notification.Id, err))
+	}
+}
+
+func (p *ProjectionService) executeOnce(ctx context.Context, notification notifier.Notification) (err error) {
+	for errExec := range p.EventualConsistentProjection(ctx, notification.Id, notification.EarliestHPatch) {
+		if errExec != nil {
+			err = errExec
+		}
+	}
+	return err
+}
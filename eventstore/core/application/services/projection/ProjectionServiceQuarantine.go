@@ -0,0 +1,84 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	projPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/samber/lo"
+	"time"
+)
+
+// ----------- Projection-Quarantine -----------------------------------------------------------------------------------
+
+func (p *ProjectionService) GetQuarantinedEvents(ctx context.Context, id shared.ProjectionID) ([]event.QuarantinedEvent, error) {
+	var quarantined []projPort.QuarantinedEvent
+	errTx := p.transactor.WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		quarantined, err = p.projectionRepository.GetQuarantinedEvents(txCtx, id)
+		return err
+	})
+	if errTx != nil {
+		return nil, fmt.Errorf("GetQuarantinedEvents failed for projection %q:%w", id.ProjectionID, errTx)
+	}
+
+	return lo.Map(quarantined, func(evt projPort.QuarantinedEvent, _ int) event.QuarantinedEvent {
+		return event.QuarantinedEvent{ProjectionID: id.ProjectionID, Event: evt.Event, LastError: evt.LastError, QuarantinedAt: evt.QuarantinedAt}
+	}), nil
+}
+
+// ReplayQuarantinedEvents moves the quarantined events back into the queue of the projection and triggers its execution.
+func (p *ProjectionService) ReplayQuarantinedEvents(ctx context.Context, id shared.ProjectionID, eventIDs ...string) (chan error, error) {
+	errTx := p.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		replay, err := p.getQuarantinedEvents(txCtx, id, eventIDs...)
+		if err != nil {
+			return err
+		}
+
+		stream, err := p.projectionRepository.Get(txCtx, id)
+		if err != nil {
+			return fmt.Errorf("retrieval of projection failed: %w", err)
+		}
+		if err = stream.AddEvents(lo.Map(replay, func(evt projPort.QuarantinedEvent, _ int) event.PersistenceEvent { return evt.Event })...); err != nil {
+			return err
+		}
+		if err = p.projectionRepository.SaveEvents(txCtx, stream); err != nil {
+			return err
+		}
+
+		return p.projectionRepository.DelQuarantinedEvents(txCtx, id, eventIDs...)
+	})
+	if errTx != nil {
+		return nil, fmt.Errorf("ReplayQuarantinedEvents failed for projection %q:%w", id.ProjectionID, errTx)
+	}
+
+	return p.EventualConsistentProjection(ctx, id, time.Time{}), nil
+}
+
+func (p *ProjectionService) DiscardQuarantinedEvents(ctx context.Context, id shared.ProjectionID, eventIDs ...string) error {
+	errTx := p.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		if _, err := p.getQuarantinedEvents(txCtx, id, eventIDs...); err != nil {
+			return err
+		}
+		return p.projectionRepository.DelQuarantinedEvents(txCtx, id, eventIDs...)
+	})
+	if errTx != nil {
+		return fmt.Errorf("DiscardQuarantinedEvents failed for projection %q:%w", id.ProjectionID, errTx)
+	}
+	return nil
+}
+
+func (p *ProjectionService) getQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, eventIDs ...string) ([]projPort.QuarantinedEvent, error) {
+	quarantined, err := p.projectionRepository.GetQuarantinedEvents(txCtx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := lo.Filter(quarantined, func(evt projPort.QuarantinedEvent, _ int) bool {
+		return lo.Contains(eventIDs, evt.Event.ID)
+	})
+	if len(result) != len(lo.Uniq(eventIDs)) {
+		return nil, fmt.Errorf("quarantined events %v not found", lo.Without(eventIDs, lo.Map(result, func(evt projPort.QuarantinedEvent, _ int) string { return evt.Event.ID })...))
+	}
+	return result, nil
+}
@@ -0,0 +1,137 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"github.com/samber/lo"
+	"sync"
+	"time"
+)
+
+// ----------- Scheduler -----------------------------------------------------------------------------------------------
+//
+// Future patches are stored in the projection queue, but they are only projected after their valid time is reached.
+// Without any further events, a projection would not be executed again. Therefore, each future patch creates a
+// scheduled task (persisted together with the events, see SaverService), which wakes up the projection at the valid
+// time of the patch. The tasks are held in process via timers and are re-armed from the database during
+// RestartProjectionService.
+
+type timerKey struct {
+	id        shared.ProjectionID
+	validTime int64
+}
+
+func newScheduledTimers() *scheduledTimers {
+	return &scheduledTimers{timers: make(map[timerKey]*time.Timer)}
+}
+
+type scheduledTimers struct {
+	sync.Mutex
+	timers map[timerKey]*time.Timer
+}
+
+// add starts a timer for the given task, if there is no timer for the projection and valid time yet.
+func (s *scheduledTimers) add(task scheduler.ScheduledProjectionTask, execute func(task scheduler.ScheduledProjectionTask)) {
+	s.Lock()
+	defer s.Unlock()
+
+	key := timerKey{id: task.Id, validTime: task.Time.UnixNano()}
+	if _, exists := s.timers[key]; exists {
+		return
+	}
+
+	s.timers[key] = time.AfterFunc(time.Until(task.Time), func() {
+		s.remove(key)
+		execute(task)
+	})
+}
+
+func (s *scheduledTimers) remove(key timerKey) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.timers, key)
+}
+
+// removeTenant stops all timers of the tenant. Its tasks remain persisted.
+func (s *scheduledTimers) removeTenant(tenantID string) {
+	s.Lock()
+	defer s.Unlock()
+
+	for key, timer := range s.timers {
+		if key.id.TenantID == tenantID {
+			timer.Stop()
+			delete(s.timers, key)
+		}
+	}
+}
+
+// AddTaskToScheduler starts a timer for the (already persisted) task, which executes the projection as soon as the
+// valid time of the task is reached.
+func (p *ProjectionService) AddTaskToScheduler(_ context.Context, task scheduler.ScheduledProjectionTask) chan error {
+	resCh := make(chan error, 1)
+	p.timers.add(task, p.executeScheduledTask)
+	close(resCh)
+	return resCh
+}
+
+// executeScheduledTask executes the eventual consistent projection of the task and removes all due tasks of the
+// projection afterward. If the execution fails, the tasks remain in the database and are executed again with the
+// next restart of the projection service.
+func (p *ProjectionService) executeScheduledTask(task scheduler.ScheduledProjectionTask) {
+	// timers are running detached from any request, so we need a new context here
+	ctx, endSpan := metrics.StartSpan(context.Background(), "executeScheduledTask", map[string]interface{}{"tenantID": task.Id.TenantID, "projectionID": task.Id.ProjectionID, "validTime": task.Time})
+	defer endSpan()
+
+	for err := range p.EventualConsistentProjection(ctx, task.Id, time.Time{}) {
+		if err != nil {
+			logger.Error(fmt.Errorf("execution of scheduled task %v failed: %w", task, err))
+			return
+		}
+	}
+
+	if err := p.delDueTasks(ctx, task.Id, time.Now()); err != nil {
+		logger.Error(fmt.Errorf("deletion of scheduled tasks of projection %v failed: %w", task.Id, err))
+	}
+}
+
+func (p *ProjectionService) delDueTasks(ctx context.Context, id shared.ProjectionID, until time.Time) error {
+	return p.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		tasks, err := p.scheduler.GetOpenTasks(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		return p.scheduler.DelTasks(txCtx, lo.Filter(tasks, func(task scheduler.ScheduledProjectionTask, _ int) bool {
+			return !task.Time.After(until)
+		})...)
+	})
+}
+
+// restartScheduledTasks re-arms the timers of all persisted tasks of the given projections.
+func (p *ProjectionService) restartScheduledTasks(ctx context.Context, ids ...shared.ProjectionID) error {
+	var tasks []scheduler.ScheduledProjectionTask
+	errTx := p.transactor.WithoutTX(ctx, func(txCtx context.Context) error {
+		for _, id := range ids {
+			openTasks, err := p.scheduler.GetOpenTasks(txCtx, id)
+			if err != nil {
+				return fmt.Errorf("GetOpenTasks() failed for %v:%w", id, err)
+			}
+			tasks = append(tasks, openTasks...)
+		}
+		return nil
+	})
+	if errTx != nil {
+		return errTx
+	}
+
+	for _, task := range tasks {
+		p.timers.add(task, p.executeScheduledTask)
+	}
+
+	return nil
+}
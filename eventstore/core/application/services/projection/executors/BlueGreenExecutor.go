@@ -0,0 +1,184 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
+	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	helper "github.com/global-soft-ba/go-eventstore/helper"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"time"
+)
+
+func NewBlueGreenProjectionExecutor(trans transactor2.Port, repro repository.ProjectionRepositoryInterface, id shared.ProjectionID) BlueGreenProjectionExecutor {
+	return BlueGreenProjectionExecutor{commonExecutor{trans, repro}, id}
+}
+
+// BlueGreenProjectionExecutor rebuilds a projection into the shadow read model of its next generation (see
+// event.ProjectionGenerations), while the live read model is still projected:
+//
+//	1.) The shadow gets its own state and queue, which is filled with all events, that the live projection already
+//	    executed. The events, which the live projection executes from now on, are added to the queue of the shadow
+//	    afterward (see ProjectionRepositoryInterface.SaveEventsToShadow).
+//
+//	2.) The shadow is executed chunk-wise in separate transactions, which only lock the shadow.
+//
+//	3.) Once the shadow caught up, the live projection is locked for a last transaction, in which the remaining events
+//	    of the shadow are executed and the live projection switches to the generation of the shadow.
+//
+// A failing rebuild removes the shadow, but does not affect the live projection.
+type BlueGreenProjectionExecutor struct {
+	commonExecutor
+	id shared.ProjectionID
+}
+
+// Rebuild returns the live projection stream after the switch to the generation of the shadow.
+func (b BlueGreenProjectionExecutor) Rebuild(ctx context.Context) (projection.Stream, error) {
+	shadow, err := b.prepareShadowWithTX(ctx)
+	if err != nil {
+		return projection.Stream{}, fmt.Errorf("prepare blue/green rebuild of projection %q failed: %w", b.id, err)
+	}
+
+	if err = b.executeShadowWithTX(ctx, shadow); err != nil {
+		b.deleteShadowWithTX(shadow)
+		return projection.Stream{}, fmt.Errorf("execute blue/green rebuild of projection %q failed: %w", b.id, err)
+	}
+
+	live, err := b.switchToShadowWithTX(ctx, shadow)
+	if err != nil {
+		b.deleteShadowWithTX(shadow)
+		return projection.Stream{}, fmt.Errorf("switch blue/green rebuild of projection %q failed: %w", b.id, err)
+	}
+
+	return live, nil
+}
+
+func (b BlueGreenProjectionExecutor) shadowID() shared.ProjectionID {
+	return shared.NewProjectionID(b.id.TenantID, projection.ShadowID(b.id.ProjectionID))
+}
+
+func (b BlueGreenProjectionExecutor) prepareShadowWithTX(ctx context.Context) (projection.Stream, error) {
+	var shadow projection.Stream
+	errTx := b.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
+		defer b.unLock(txCtx, b.id, b.shadowID())
+		if err = b.lock(txCtx, b.id, b.shadowID()); err != nil {
+			return err
+		}
+
+		live, err := b.projectionRepository.Get(txCtx, b.id)
+		if err != nil {
+			return fmt.Errorf("retrieval of stream for projection %q of tenant %q failed: %w", b.id.ProjectionID, b.id.TenantID, err)
+		}
+		if !live.SupportsGenerations() {
+			return fmt.Errorf("projection %q does not implement event.ProjectionGenerations", b.id.ProjectionID)
+		}
+		if live.State() == projection.Rebuilding || live.State() == projection.Erroneous {
+			return event.NewErrorProjectionInWrongState(nil, string(live.State()), string(projection.Running), b.id)
+		}
+
+		// the shadow of a failed blue/green rebuild is replaced
+		if err = b.projectionRepository.Delete(txCtx, b.shadowID()); err != nil {
+			return fmt.Errorf("delete of previous shadow of projection %q failed: %w", b.id, err)
+		}
+
+		shadow = live.NewShadow()
+		if err = b.projectionRepository.SaveStates(txCtx, shadow); err != nil {
+			return fmt.Errorf("save of shadow of projection %q failed: %w", b.id, err)
+		}
+
+		if err = b.projectionRepository.Fork(txCtx, live.ID(), shadow.ID(), time.Time{}, event.HistoricalEventTypes(live.EventTypes()...)...); err != nil {
+			return fmt.Errorf("fork of queue of projection %q failed: %w", b.id, err)
+		}
+
+		return shadow.PrepareGeneration(txCtx, live.Options().PreparationTimeOut)
+	})
+
+	return shadow, errTx
+}
+
+// executeShadowWithTX executes the queue of the shadow until it caught up with the live projection. Each chunk is
+// executed in a separate transaction to keep the transactions short.
+func (b BlueGreenProjectionExecutor) executeShadowWithTX(ctx context.Context, shadow projection.Stream) error {
+	return helper.ExecuteFunctionChunkWise(func() (bool, error) {
+		var executed int
+		errTx := b.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
+			defer b.unLock(txCtx, shadow.ID())
+			if err = b.lock(txCtx, shadow.ID()); err != nil {
+				return err
+			}
+
+			executed, err = b.execute(txCtx, shadow, shadow.Options().RebuildExecutionTimeOut, projection.Rebuilding)
+			return err
+		})
+		return executed == shadow.ChunkSize(), errTx
+	})
+}
+
+func (b BlueGreenProjectionExecutor) switchToShadowWithTX(ctx context.Context, shadow projection.Stream) (projection.Stream, error) {
+	var live projection.Stream
+	var switched bool
+	errTx := b.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
+		defer b.unLock(txCtx, b.id, shadow.ID())
+		if err = b.lock(txCtx, b.id, shadow.ID()); err != nil {
+			return err
+		}
+
+		if live, err = b.projectionRepository.Get(txCtx, b.id); err != nil {
+			return fmt.Errorf("retrieval of stream for projection %q of tenant %q failed: %w", b.id.ProjectionID, b.id.TenantID, err)
+		}
+
+		// execute the events, which the live projection executed since the last chunk of the shadow
+		if err = helper.ExecuteFunctionChunkWise(func() (bool, error) {
+			executed, errExecute := b.execute(txCtx, shadow, shadow.Options().RebuildExecutionTimeOut, projection.Rebuilding)
+			return executed == shadow.ChunkSize(), errExecute
+		}); err != nil {
+			return err
+		}
+
+		if err = live.SwitchGeneration(txCtx, shadow, live.Options().FinishingTimeOut); err != nil {
+			return err
+		}
+		switched = true
+
+		if err = b.projectionRepository.SaveStates(txCtx, live); err != nil {
+			return fmt.Errorf("save of generation of projection %q failed: %w", b.id, err)
+		}
+
+		return b.projectionRepository.Delete(txCtx, shadow.ID())
+	})
+
+	// The projection switched its read model, but the eventStore still writes the previous generation.
+	if errTx != nil && switched {
+		errTx = event.NewErrorProjectionOutOfSync(errTx, b.id)
+		logger.Error(errTx)
+		if errState := b.updateStreamStateWithTx(live, projection.Erroneous); errState != nil {
+			logger.Error(errState)
+		}
+	}
+	return live, errTx
+}
+
+func (b BlueGreenProjectionExecutor) deleteShadowWithTX(shadow projection.Stream) {
+	errTx := b.transactor.WithinTX(context.Background(), func(txCtx context.Context) error {
+		return b.projectionRepository.Delete(txCtx, shadow.ID())
+	})
+	if errTx != nil {
+		logger.Error(fmt.Errorf("delete of shadow of projection %q failed: %w", b.id, errTx))
+	}
+}
+
+func (b BlueGreenProjectionExecutor) lock(txCtx context.Context, ids ...shared.ProjectionID) error {
+	if err := b.projectionRepository.Lock(txCtx, ids...); err != nil {
+		return fmt.Errorf("lock of projection %q of tenant %q failed: %w", b.id.ProjectionID, b.id.TenantID, err)
+	}
+	return nil
+}
+
+func (b BlueGreenProjectionExecutor) unLock(txCtx context.Context, ids ...shared.ProjectionID) {
+	if err := b.projectionRepository.UnLock(txCtx, ids...); err != nil {
+		logger.Error(fmt.Errorf("unlock of projection %q of tenant %q failed: %w", b.id.ProjectionID, b.id.TenantID, err))
+	}
+}
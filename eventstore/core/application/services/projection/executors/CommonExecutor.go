@@ -3,6 +3,7 @@ package executors
 import (
 	"context"
 	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
 	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
@@ -36,15 +37,19 @@ func (e commonExecutor) execute(txCtx context.Context, stream projection.Stream,
 		return 0, fmt.Errorf("get new events since last failed for projection %q failed: %w", stream.ID(), err)
 	}
 
+	return e.executeNewEvents(txCtx, streamWithNewEvents, timeout, initState)
+}
+
+func (e commonExecutor) executeNewEvents(txCtx context.Context, streamWithNewEvents projection.Stream, timeout time.Duration, initState projection.State) (int, error) {
 	executed, err := streamWithNewEvents.ExecuteWithTimeOut(txCtx, timeout, initState)
 	if err != nil {
-		return executed, fmt.Errorf("execution of projection %q failed: %w", stream.ID(), err)
+		return executed, fmt.Errorf("execution of projection %q failed: %w", streamWithNewEvents.ID(), err)
 	}
 	return executed, err
 }
 
 func (e commonExecutor) prepareRebuild(txCtx context.Context, stream projection.Stream, since time.Time) error {
-	if err := e.projectionRepository.Reset(txCtx, stream.ID(), stream.MinimumProjectionSinceTime(since), stream.EventTypes()...); err != nil {
+	if err := e.projectionRepository.Reset(txCtx, stream.ID(), stream.MinimumProjectionSinceTime(since), event.HistoricalEventTypes(stream.EventTypes()...)...); err != nil {
 		return fmt.Errorf("reset of projection %q id failed: %w", stream.ID(), err)
 	}
 
@@ -47,8 +47,12 @@ func (c ConsistentProjectionExecutor) Run(txCtx context.Context) error {
 	// For eventual consistent projection this is done during the retrieval events from the projection queue
 	c.stream.SortByValidTimeByAggregateIdByVersion()
 
-	_, err := c.stream.ExecuteWithTimeOut(txCtx, c.stream.Options().ExecutionTimeOut, projection.Running)
-	return c.handleErrorsDuringProjection(err)
+	if _, err := c.stream.ExecuteWithTimeOut(txCtx, c.stream.Options().ExecutionTimeOut, projection.Running); err != nil {
+		return c.handleErrorsDuringProjection(err)
+	}
+
+	// a running blue/green rebuild projects the executed events as well
+	return c.projectionRepository.SaveEventsToShadow(txCtx, c.stream)
 }
 
 func (c ConsistentProjectionExecutor) Projected(ctx context.Context) error {
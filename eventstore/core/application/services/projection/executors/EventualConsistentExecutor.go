@@ -7,6 +7,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
+	projPort "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
 	transactor2 "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	helper "github.com/global-soft-ba/go-eventstore/helper"
@@ -14,13 +15,23 @@ import (
 	"time"
 )
 
+// noLane marks an executor, which executes the whole queue of the projection.
+const noLane = -1
+
 func NewEventualConsistentProjectionExecutor(trans transactor2.Port, repro repository.ProjectionRepositoryInterface, id shared.ProjectionID, opt projection.Options, earliestHPatch time.Time) EventualConsistentProjectionExecutor {
-	return EventualConsistentProjectionExecutor{commonExecutor{trans, repro}, id, opt, earliestHPatch}
+	return EventualConsistentProjectionExecutor{commonExecutor{trans, repro}, id, noLane, opt, earliestHPatch}
+}
+
+// NewEventualConsistentProjectionLaneExecutor creates an executor for a single lane of a projection, whose queue is
+// partitioned into lanes (see projection.Options.QueueLanes). It only locks and executes the events of its lane.
+func NewEventualConsistentProjectionLaneExecutor(trans transactor2.Port, repro repository.ProjectionRepositoryInterface, id shared.ProjectionID, lane int, opt projection.Options) EventualConsistentProjectionExecutor {
+	return EventualConsistentProjectionExecutor{commonExecutor{trans, repro}, id, lane, opt, time.Time{}}
 }
 
 type EventualConsistentProjectionExecutor struct {
 	commonExecutor
 	id             shared.ProjectionID
+	lane           int
 	opt            projection.Options
 	earliestHPatch time.Time
 }
@@ -60,30 +71,160 @@ func (e EventualConsistentProjectionExecutor) Run(ctx context.Context) error {
 }
 
 func (e EventualConsistentProjectionExecutor) run(ctx context.Context, stream projection.Stream) (bool, error) {
+	executed, err := e.runChunk(ctx, stream)
+	// failed executions are retried with backoff (see eventstore.WithProjectionRetry)
+	for _, retryDuration := range stream.Options().RetryDurations {
+		if !isRetryableExecutionError(err) {
+			break
+		}
+		// a shutdown or stop of the tenant does not wait for the backoff
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(retryDuration):
+		}
+		executed, err = e.runChunk(ctx, stream)
+	}
+
+	if isRetryableExecutionError(err) {
+		e.saveFailedExecution(ctx, err)
+	}
+
+	if isRetryableExecutionError(err) && stream.Options().Quarantine {
+		executed, err = e.quarantineChunk(ctx, stream)
+	}
+
+	return executed == stream.ChunkSize(), err
+}
+
+func (e EventualConsistentProjectionExecutor) runChunk(ctx context.Context, stream projection.Stream) (int, error) {
 	var executed int
 	errTx := e.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
 		// We lock/unlock the projection during each single chunks execution and not for the whole projection execution.
 		// The reason is, if the projection finishes fatal or the main/pod crashes, the lock will automatically be released
 		// (advisory lock in postgres) and do not block other instances/pods to proceed with the projection.
-		if err = e.projectionRepository.Lock(txCtx, e.id); err != nil {
+		if err = e.lockQueue(txCtx); err != nil {
 			return err
 		}
 
-		defer func() {
-			if errUnlock := e.projectionRepository.UnLock(txCtx, e.id); errUnlock != nil {
-				logger.Error(errUnlock)
-			}
-		}()
+		defer e.unLockQueue(txCtx)
 
 		// execute the projection
-		executed, err = e.execute(txCtx, stream, stream.Options().ExecutionTimeOut, projection.Running)
+		streamWithNewEvents, err := e.getWithNewEventsSinceLastRun(txCtx)
+		if err != nil {
+			return err
+		}
+		executed, err = e.executeNewEvents(txCtx, streamWithNewEvents, stream.Options().ExecutionTimeOut, projection.Running)
 		if err != nil {
 			return e.handleErrorsDuringProjection(stream, err)
 		}
-		return
+		// a running blue/green rebuild projects the executed events as well
+		if err = e.projectionRepository.SaveEventsToShadow(txCtx, streamWithNewEvents); err != nil {
+			return err
+		}
+
+		if executed == 0 {
+			return nil
+		}
+		return e.projectionRepository.SaveExecutionResult(txCtx, e.id, projPort.ExecutionResult{ExecutedAt: time.Now()})
 	})
 
-	return executed == stream.ChunkSize(), errTx
+	return executed, errTx
+}
+
+// saveFailedExecution records the failed execution of the projection (see event.ProjectionHealth). Its own transaction
+// is necessary, because the transaction of the execution is rolled back.
+func (e EventualConsistentProjectionExecutor) saveFailedExecution(ctx context.Context, errExecute error) {
+	errTx := e.transactor.WithinTX(ctx, func(txCtx context.Context) error {
+		return e.projectionRepository.SaveExecutionResult(txCtx, e.id, projPort.ExecutionResult{
+			ExecutedAt: time.Now(),
+			Error:      errExecute.Error(),
+		})
+	})
+	if errTx != nil {
+		logger.Error(fmt.Errorf("saving of failed execution of projection %q failed: %w", e.id, errTx))
+	}
+}
+
+// quarantineChunk executes the events of the chunk one by one and moves the failing events into the quarantine (see
+// eventstore.WithProjectionQuarantine), so that they no longer block the queue of the projection.
+func (e EventualConsistentProjectionExecutor) quarantineChunk(ctx context.Context, stream projection.Stream) (int, error) {
+	var executed int
+	errTx := e.transactor.WithinTX(ctx, func(txCtx context.Context) (err error) {
+		if err = e.lockQueue(txCtx); err != nil {
+			return err
+		}
+
+		defer e.unLockQueue(txCtx)
+
+		streamWithNewEvents, err := e.getWithNewEventsSinceLastRun(txCtx)
+		if err != nil {
+			return err
+		}
+
+		var quarantined []projPort.QuarantinedEvent
+		for _, single := range streamWithNewEvents.SplitByEvent() {
+			_, errExecute := single.ExecuteWithTimeOut(txCtx, stream.Options().ExecutionTimeOut, projection.Running)
+			switch {
+			case isRetryableExecutionError(errExecute):
+				logger.Error(fmt.Errorf("event %q of projection %q quarantined: %w", single.Events()[0].ID, e.id, errExecute))
+				quarantined = append(quarantined, projPort.QuarantinedEvent{
+					Event:         single.Events()[0],
+					LastError:     errExecute.Error(),
+					QuarantinedAt: time.Now(),
+				})
+			case errExecute != nil:
+				return e.handleErrorsDuringProjection(stream, errExecute)
+			default:
+				if err = e.projectionRepository.SaveEventsToShadow(txCtx, single); err != nil {
+					return err
+				}
+			}
+			executed++
+		}
+
+		return e.projectionRepository.AddQuarantinedEvents(txCtx, e.id, quarantined...)
+	})
+
+	return executed, errTx
+}
+
+// lockQueue locks the lane of the executor or, without lane, the whole projection (including all its lanes).
+func (e EventualConsistentProjectionExecutor) lockQueue(txCtx context.Context) error {
+	if e.lane == noLane {
+		return e.projectionRepository.Lock(txCtx, e.id)
+	}
+	return e.projectionRepository.LockLane(txCtx, e.id, e.lane)
+}
+
+func (e EventualConsistentProjectionExecutor) unLockQueue(txCtx context.Context) {
+	var err error
+	if e.lane == noLane {
+		err = e.projectionRepository.UnLock(txCtx, e.id)
+	} else {
+		err = e.projectionRepository.UnLockLane(txCtx, e.id, e.lane)
+	}
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+func (e EventualConsistentProjectionExecutor) getWithNewEventsSinceLastRun(txCtx context.Context) (stream projection.Stream, err error) {
+	if e.lane == noLane {
+		stream, err = e.projectionRepository.GetWithNewEventsSinceLastRun(txCtx, e.id)
+	} else {
+		stream, err = e.projectionRepository.GetWithNewEventsOfLaneSinceLastRun(txCtx, e.id, e.lane)
+	}
+	if err != nil {
+		return stream, fmt.Errorf("get new events since last failed for projection %q failed: %w", e.id, err)
+	}
+	return stream, nil
+}
+
+func isRetryableExecutionError(err error) bool {
+	var timeOut *event.ErrorProjectionTimeOut
+	var executeFail *event.ErrorProjectionExecutionFailed
+	return errors.As(err, &timeOut) || errors.As(err, &executeFail)
 }
 
 func (e EventualConsistentProjectionExecutor) Projected(ctx context.Context) error {
@@ -98,7 +98,7 @@ func (s *Stream) EarliestPatchInCurrentStream() time.Time {
 	var earliestPatch time.Time
 	for _, evt := range s.events {
 		switch evt.Class {
-		case event.HistoricalPatch:
+		case event.HistoricalPatch, event.DeleteRevision:
 
 			if earliestPatch.IsZero() {
 				earliestPatch = evt.ValidTime
@@ -120,9 +120,12 @@ func (s *Stream) applyStreamConsistencyChecks(evt event.PersistenceEvent) (err e
 	switch {
 	case !s.id.Equal(shared.NewAggregateID(evt.TenantID, evt.AggregateType, evt.AggregateID)):
 		err = fmt.Errorf("event for stream %q does not belong to stream %q", shared.NewAggregateID(evt.TenantID, evt.AggregateType, evt.AggregateID), s.id)
+	case evt.Class == event.ReopenStreamEvent:
+		err = s.applyReopenConsistencyChecks(evt)
 	case !s.closeTime.IsZero():
-		if s.closeTime.Before(evt.ValidTime) {
-			err = fmt.Errorf("cannot write to a closed stream %q", s.ID())
+		// events before the close time (e.g. historical patches) are still allowed, but no further close events
+		if s.closeTime.Before(evt.ValidTime) || evt.Class == event.CloseStreamEvent {
+			err = s.errorStreamClosed()
 		}
 	case evt.ValidTime.Before(s.createTime):
 		err = fmt.Errorf("valid time %v is before creation time %v", evt.ValidTime, s.createTime)
@@ -134,8 +137,6 @@ func (s *Stream) applyStreamConsistencyChecks(evt event.PersistenceEvent) (err e
 			AggregateID:   evt.AggregateID,
 			AggregateType: evt.AggregateType,
 		}
-	case evt.Class == event.CloseStreamEvent && !s.closeTime.IsZero():
-		err = fmt.Errorf("stream %q has already a closed event at %s", s.ID(), s.CloseTime())
 	case evt.Class == event.CloseStreamEvent && !(evt.ValidTime.Equal(evt.TransactionTime) || evt.ValidTime.After(evt.TransactionTime)):
 		err = fmt.Errorf("close event for aggregate %q has a valid time %v before its transaction time %v", evt.AggregateID, evt.ValidTime, evt.TransactionTime)
 	case evt.Class == event.CloseStreamEvent && s.latestValidTime.After(evt.ValidTime):
@@ -148,13 +149,34 @@ func (s *Stream) applyStreamConsistencyChecks(evt event.PersistenceEvent) (err e
 	return nil
 }
 
+func (s *Stream) applyReopenConsistencyChecks(evt event.PersistenceEvent) error {
+	switch {
+	case s.closeTime.IsZero():
+		return fmt.Errorf("stream %q is not closed", s.ID())
+	case evt.ValidTime.Before(s.closeTime):
+		return fmt.Errorf("reopen time %v of stream %q is before its close time %v", evt.ValidTime, s.ID(), s.closeTime)
+	case evt.TransactionTime.Before(s.lastTransactionTime):
+		return fmt.Errorf("transaction time %v is before last stored transaction time %v", evt.TransactionTime, s.lastTransactionTime)
+	}
+	return nil
+}
+
+func (s *Stream) errorStreamClosed() error {
+	return &event.ErrorStreamClosed{
+		TenantID:      s.id.TenantID,
+		AggregateType: s.id.AggregateType,
+		AggregateID:   s.id.AggregateID,
+		CloseTime:     s.closeTime,
+	}
+}
+
 func (s *Stream) addTimeStamps(evt event.PersistenceEvent, time time.Time) event.PersistenceEvent {
 	//NewMigrationEvent has already defined timestamps, which should not be changed
 	if evt.FromMigration {
 		return evt
 	}
 	switch evt.Class {
-	case event.CreateStreamEvent, event.CloseStreamEvent:
+	case event.CreateStreamEvent, event.CloseStreamEvent, event.ReopenStreamEvent:
 		if evt.ValidTime.IsZero() {
 			evt.ValidTime = time
 		}
@@ -163,7 +185,7 @@ func (s *Stream) addTimeStamps(evt event.PersistenceEvent, time time.Time) event
 	case event.InstantEvent, event.SnapShot:
 		evt.ValidTime = time
 		evt.TransactionTime = time
-	case event.HistoricalPatch, event.FuturePatch, event.HistoricalSnapShot:
+	case event.HistoricalPatch, event.FuturePatch, event.HistoricalSnapShot, event.DeleteRevision:
 		evt.TransactionTime = time
 	}
 
@@ -172,7 +194,7 @@ func (s *Stream) addTimeStamps(evt event.PersistenceEvent, time time.Time) event
 
 func (s *Stream) addVersion(evt event.PersistenceEvent, currentVersion int64) (event.PersistenceEvent, int64) {
 	switch evt.Class {
-	case event.CreateStreamEvent, event.CloseStreamEvent, event.InstantEvent, event.HistoricalPatch, event.FuturePatch:
+	case event.CreateStreamEvent, event.CloseStreamEvent, event.ReopenStreamEvent, event.InstantEvent, event.HistoricalPatch, event.FuturePatch, event.DeleteRevision:
 		currentVersion++
 		evt.Version = int(currentVersion)
 	case event.SnapShot, event.HistoricalSnapShot:
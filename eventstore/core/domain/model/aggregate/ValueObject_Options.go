@@ -6,4 +6,7 @@ type Options struct {
 	ConcurrentModificationStrategy event.ConcurrentModificationStrategy
 	EphemeralEvents                map[string]bool
 	DeleteStrategy                 event.DeleteStrategy
+	// SnapshotPolicy is only applied with a Snapshotter
+	SnapshotPolicy event.SnapshotPolicy
+	Snapshotter    event.Snapshotter
 }
@@ -31,10 +31,15 @@ func (s *Stream) AddEvent(evt event.PersistenceEvent, time time.Time) (event.Per
 		}
 	default:
 		s.events = append(s.events, versionedEvt)
-		if versionedEvt.Class == event.CloseStreamEvent {
+		switch versionedEvt.Class {
+		case event.CloseStreamEvent:
 			if err = s.CloseStream(versionedEvt, time); err != nil {
 				return event.PersistenceEvent{}, err
 			}
+		case event.ReopenStreamEvent:
+			if err = s.ReopenStream(versionedEvt); err != nil {
+				return event.PersistenceEvent{}, err
+			}
 		}
 	}
 
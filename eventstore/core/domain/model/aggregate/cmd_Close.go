@@ -12,7 +12,7 @@ func (s *Stream) CloseStream(evt event.PersistenceEvent, closed time.Time) error
 	}
 
 	if !s.closeTime.IsZero() {
-		return fmt.Errorf("stream already closed")
+		return s.errorStreamClosed()
 	}
 	if evt.FromMigration || !evt.ValidTime.IsZero() {
 		closed = evt.ValidTime
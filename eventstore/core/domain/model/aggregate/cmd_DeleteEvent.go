@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/global-soft-ba/go-eventstore"
+	"github.com/google/uuid"
 	"time"
 )
 
@@ -13,6 +14,8 @@ func (s *Stream) DeleteEvent(evt event.PersistenceEvent, userID string) (event.P
 		return evt, fmt.Errorf("cannot delete create stream event")
 	case event.SnapShot, event.HistoricalSnapShot:
 		return evt, fmt.Errorf("cannot delete snapshot event")
+	case event.ReopenStreamEvent:
+		return evt, fmt.Errorf("cannot delete reopen stream event, the stream must be closed again instead")
 	default:
 	}
 
@@ -22,22 +25,82 @@ func (s *Stream) DeleteEvent(evt event.PersistenceEvent, userID string) (event.P
 		return evt, nil
 	case event.SoftDelete:
 		evt.Class = event.DeletePatch
-		return s.markAsDeleted(evt, userID)
+		return s.markAsDeleted(evt, event.Deleted{DeletedAt: time.Now(), DeletedBy: userID})
+	case event.RevisionDelete:
+		return s.tombstone(evt, userID)
 	default:
 		return evt, fmt.Errorf("unknown delete strategy %v", s.Options().DeleteStrategy)
 	}
 }
 
-func (s *Stream) markAsDeleted(evt event.PersistenceEvent, userID string) (event.PersistenceEvent, error) {
+// tombstone creates the delete event of the RevisionDelete strategy, which is appended to the stream. It has the type
+// and the data of the deleted event (marked as deleted), and its valid time, so that it hides the deleted event in all
+// loads as of its transaction time.
+func (s *Stream) tombstone(evt event.PersistenceEvent, userID string) (event.PersistenceEvent, error) {
+	if evt.Class == event.DeletePatch || evt.Class == event.DeleteRevision {
+		return evt, fmt.Errorf("event %q is already deleted", evt.ID)
+	}
+
+	tombstone, err := s.markAsDeleted(evt, event.Deleted{DeletedAt: time.Now(), DeletedBy: userID, EventID: evt.ID})
+	if err != nil {
+		return evt, err
+	}
+
+	tombstone.ID = uuid.NewString()
+	tombstone.Class = event.DeleteRevision
+	tombstone.Version = 0
+	tombstone.Position = 0
+	tombstone.TransactionTime = time.Time{}
+	tombstone.FromMigration = false
+	return tombstone, nil
+}
+
+// RemoveRevisionDeletes removes the tombstones of the RevisionDelete strategy and the events deleted by them.
+func RemoveRevisionDeletes(events []event.PersistenceEvent) []event.PersistenceEvent {
+	deleted := RevisionDeletedEventIDs(events)
+	if deleted == nil {
+		return events
+	}
+
+	var result []event.PersistenceEvent
+	for _, evt := range events {
+		if evt.Class != event.DeleteRevision && !deleted[evt.ID] {
+			result = append(result, evt)
+		}
+	}
+	return result
+}
+
+// RevisionDeletedEventIDs returns the ids of the events deleted by the tombstones of the RevisionDelete strategy (nil,
+// if there are no tombstones).
+func RevisionDeletedEventIDs(events []event.PersistenceEvent) map[string]bool {
+	var deleted map[string]bool
+	for _, evt := range events {
+		if evt.Class != event.DeleteRevision {
+			continue
+		}
+		if deleted == nil {
+			deleted = make(map[string]bool)
+		}
+		var data struct{ Deleted event.Deleted }
+		if err := json.Unmarshal(evt.Data, &data); err == nil {
+			deleted[data.Deleted.EventID] = true
+		}
+	}
+	return deleted
+}
+
+func (s *Stream) markAsDeleted(evt event.PersistenceEvent, deleted event.Deleted) (event.PersistenceEvent, error) {
+	if evt.Codec != "" {
+		return evt, fmt.Errorf("soft delete of event %q failed: data of codec %q cannot be marked as deleted", evt.ID, evt.Codec)
+	}
+
 	var existingDataFields map[string]interface{}
 	if err := json.Unmarshal(evt.Data, &existingDataFields); err != nil {
 		return evt, fmt.Errorf("failed to unmarshal data: %w", err)
 	}
 
-	existingDataFields["Deleted"] = event.Deleted{
-		DeletedAt: time.Now(),
-		DeletedBy: userID,
-	}
+	existingDataFields["Deleted"] = deleted
 
 	updatedRaw, err := json.Marshal(existingDataFields)
 	if err != nil {
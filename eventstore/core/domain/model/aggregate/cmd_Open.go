@@ -2,7 +2,13 @@ package aggregate
 
 import "github.com/global-soft-ba/go-eventstore"
 
-func (s *Stream) Open(version int) error {
+// Open checks the expectation of the events to be saved. Without an expectation, the version is checked with respect
+// to the concurrent modification strategy.
+func (s *Stream) Open(version int, expect event.Expectation) error {
+	if !expect.IsEmpty() {
+		return s.openWithExpectation(version, expect)
+	}
+
 	if int64(version) != s.currentVersion {
 		switch s.options.ConcurrentModificationStrategy {
 		case event.Fail:
@@ -21,3 +27,33 @@ func (s *Stream) Open(version int) error {
 	s.latestVersion = true
 	return nil
 }
+
+func (s *Stream) openWithExpectation(version int, expect event.Expectation) error {
+	var violated bool
+	switch expect.State {
+	case event.ExpectedNoStream:
+		violated = s.currentVersion != initVersion
+	case event.ExpectedAny:
+	case event.ExpectedVersion:
+		violated = int64(expect.Version) != s.currentVersion
+		version = expect.Version
+	case event.ExpectedClosed:
+		violated = s.currentVersion == initVersion || s.closeTime.IsZero()
+	default:
+		violated = true
+	}
+	if violated {
+		return &event.ErrorExpectationViolated{
+			TenantID:      s.ID().TenantID,
+			AggregateType: s.ID().AggregateType,
+			AggregateID:   s.ID().AggregateID,
+			Expected:      expect,
+			Version:       int(s.currentVersion),
+			Closed:        !s.closeTime.IsZero(),
+		}
+	}
+
+	// with any version or only the closed state as expectation, the events could be based on an outdated version
+	s.latestVersion = int64(version) == s.currentVersion
+	return nil
+}
@@ -0,0 +1,20 @@
+package aggregate
+
+import (
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"time"
+)
+
+func (s *Stream) ReopenStream(evt event.PersistenceEvent) error {
+	if evt.Class != event.ReopenStreamEvent {
+		return fmt.Errorf("wrong event class for reopen")
+	}
+
+	if s.closeTime.IsZero() {
+		return fmt.Errorf("stream not closed")
+	}
+
+	s.closeTime = time.Time{}
+	return nil
+}
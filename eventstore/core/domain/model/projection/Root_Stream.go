@@ -18,8 +18,12 @@ func LoadFromDTO(dto projection.DTO, proj event.Projection, opt Options) Stream
 		projection: proj,
 		options:    opt,
 		state:      State(dto.State),
+		generation: dto.Generation,
 		updatedAt:  dto.UpdatedAt,
 		events:     dto.Events,
+		lastRunAt:  dto.LastRunAt,
+		lastError:  dto.LastError,
+		failures:   dto.Failures,
 	}
 }
 
@@ -38,8 +42,13 @@ type Stream struct {
 	projection event.Projection
 	options    Options
 	state      State
+	generation int
 	updatedAt  time.Time
 
+	lastRunAt time.Time
+	lastError string
+	failures  int
+
 	events []event.PersistenceEvent
 }
 
@@ -89,6 +98,43 @@ func (s *Stream) UpdatedAt() time.Time {
 	return s.updatedAt
 }
 
+// LastRunAt returns the time of the last execution, which projected events successfully.
+func (s *Stream) LastRunAt() time.Time {
+	return s.lastRunAt
+}
+
+// LastError returns the error of the last failed execution, which is reset by a successful execution.
+func (s *Stream) LastError() string {
+	return s.lastError
+}
+
+// Failures returns the number of consecutive failed executions.
+func (s *Stream) Failures() int {
+	return s.failures
+}
+
+// Generation returns the generation of the read model, which the stream writes (see event.ProjectionGenerations).
+func (s *Stream) Generation() event.ProjectionGeneration {
+	return event.ProjectionGeneration{Number: s.generation, Shadow: IsShadowID(s.id.ProjectionID)}
+}
+
+func (s *Stream) SupportsGenerations() bool {
+	_, ok := s.projection.(event.ProjectionGenerations)
+	return ok
+}
+
+// NewShadow returns the shadow stream of a blue/green rebuild, which writes the next generation of the read model.
+func (s *Stream) NewShadow() Stream {
+	return Stream{
+		id:         shared.NewProjectionID(s.id.TenantID, ShadowID(s.id.ProjectionID)),
+		projection: s.projection,
+		options:    s.options,
+		state:      Rebuilding,
+		generation: s.generation + 1,
+		updatedAt:  s.updatedAt,
+	}
+}
+
 func (s *Stream) Options() Options {
 	return s.options
 }
@@ -149,6 +195,63 @@ func (s *Stream) Prepare(_ context.Context, sinceTime time.Time, timeOut time.Du
 	return err
 }
 
+// PrepareGeneration prepares the empty read model of the shadow stream (see NewShadow).
+func (s *Stream) PrepareGeneration(_ context.Context, timeOut time.Duration) error {
+	projGen, ok := s.projection.(event.ProjectionGenerations)
+	if !ok {
+		return fmt.Errorf("projection %q does not support generations", s.id.ProjectionID)
+	}
+
+	return s.withTimeOut(timeOut, "preparation of generation", func(ctx context.Context) error {
+		return projGen.PrepareGeneration(ctx, s.id.TenantID, s.Generation())
+	})
+}
+
+// SwitchGeneration replaces the read model of the stream by the read model of the shadow stream. Afterward, the stream
+// writes the generation of the shadow.
+func (s *Stream) SwitchGeneration(_ context.Context, shadow Stream, timeOut time.Duration) error {
+	projGen, ok := s.projection.(event.ProjectionGenerations)
+	if !ok {
+		return fmt.Errorf("projection %q does not support generations", s.id.ProjectionID)
+	}
+
+	if err := s.withTimeOut(timeOut, "switch of generation", func(ctx context.Context) error {
+		return projGen.SwitchGeneration(ctx, s.id.TenantID, shadow.Generation())
+	}); err != nil {
+		return err
+	}
+
+	s.generation = shadow.generation
+	return nil
+}
+
+// withTimeOut calls the projection non-blocking, to make sure that a blocking projection in domain does not block the
+// events store.
+func (s *Stream) withTimeOut(timeOut time.Duration, step string, call func(ctx context.Context) error) error {
+	//Create new context in order to avoid leaking of transaction
+	ctxNew, cancel := context.WithTimeout(context.Background(), timeOut)
+	defer cancel()
+
+	resCh := make(chan error, 1)
+	go func() {
+		if errIntern := call(ctxNew); errIntern != nil {
+			resCh <- event.NewErrorProjectionExecutionFailed(fmt.Errorf("%s failed: %w", step, errIntern), s.id)
+			return
+		}
+		resCh <- nil
+	}()
+
+	var err error
+	select {
+	case err = <-resCh: // executed in time
+	case <-ctxNew.Done(): // timeout
+		err = event.NewErrorProjectionTimeOut(fmt.Errorf("deadline exceeded for %s", step), s.id)
+		logger.Error(err)
+	}
+
+	return err
+}
+
 func (s *Stream) validateState(state State) (bool, error) {
 	if s.State() != state {
 		switch s.Options().ProjectionType {
@@ -197,7 +300,7 @@ func (s *Stream) ExecuteWithTimeOut(ctx context.Context, timeOut time.Duration,
 		// we used a chunked execution because we cannot guarantee that the stream contains the exact chunk size
 		// especially in the case of consistent projections execution
 		for _, chunk := range chunkSlice(iEvents, s.ChunkSize()) {
-			errIntern = s.projection.Execute(ctxNew, chunk)
+			errIntern = s.execute(ctxNew, chunk)
 			if errIntern != nil {
 				errIntern = event.NewErrorProjectionExecutionFailed(fmt.Errorf("execution failed: %w", errIntern), s.id)
 				break
@@ -216,6 +319,14 @@ func (s *Stream) ExecuteWithTimeOut(ctx context.Context, timeOut time.Duration,
 	return len(s.events), err
 }
 
+// execute passes the generation to projections, which support generations.
+func (s *Stream) execute(ctx context.Context, events []event.IEvent) error {
+	if projGen, ok := s.projection.(event.ProjectionGenerations); ok {
+		return projGen.ExecuteGeneration(ctx, s.Generation(), events)
+	}
+	return s.projection.Execute(ctx, events)
+}
+
 func (s *Stream) Finish(_ context.Context, timeOut time.Duration) error {
 	//Create new context in order to avoid leaking of transaction
 	ctxNew, cancel := context.WithTimeout(context.Background(), timeOut)
@@ -248,6 +359,16 @@ func (s *Stream) Finish(_ context.Context, timeOut time.Duration) error {
 	return err
 }
 
+// SplitByEvent returns a stream for each event of the stream, e.g. to isolate the events whose execution fails.
+func (s *Stream) SplitByEvent() []Stream {
+	streams := make([]Stream, len(s.events))
+	for i, evt := range s.events {
+		streams[i] = *s
+		streams[i].events = []event.PersistenceEvent{evt}
+	}
+	return streams
+}
+
 // SortByValidTimeByAggregateIdByVersion sorts the events by valid time, aggregate id and version.
 // This is the general sort of criteria for all events in projections.
 func (s *Stream) SortByValidTimeByAggregateIdByVersion() {
@@ -0,0 +1,19 @@
+package projection
+
+import "strings"
+
+// shadowSuffix marks the ID of the shadow projection of a blue/green rebuild. The shadow has its own state and queue,
+// but no own registration, i.e. it uses the projection and the options of the live projection.
+const shadowSuffix = "#shadow"
+
+func ShadowID(projectionID string) string {
+	return LiveID(projectionID) + shadowSuffix
+}
+
+func IsShadowID(projectionID string) bool {
+	return strings.HasSuffix(projectionID, shadowSuffix)
+}
+
+func LiveID(projectionID string) string {
+	return strings.TrimSuffix(projectionID, shadowSuffix)
+}
@@ -15,4 +15,16 @@ type Options struct {
 	InputQueueLength        int
 	ProjectionType          event.ProjectionType
 	RetryDurations          []time.Duration
+	Quarantine              bool
+	Lanes                   int
+}
+
+// QueueLanes returns the number of lanes in which the queue of the projection is executed in parallel. Only eventual
+// consistent single stream projections are partitioned into lanes, because the order of events is only preserved per
+// aggregate.
+func (o Options) QueueLanes() int {
+	if o.ProjectionType != event.ESS || o.Lanes < 1 {
+		return 1
+	}
+	return o.Lanes
 }
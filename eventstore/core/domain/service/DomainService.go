@@ -35,6 +35,10 @@ func (d DomainService) GetUniqueAggregateIDsAndEventTypes(_ context.Context, ten
 		}
 
 		for _, evt := range persistenceEvents.Events {
+			// all events of a save belong to the tenant, whose aggregates are locked
+			if evt.TenantID != tenantID {
+				return nil, nil, fmt.Errorf("GetUniqueAggregateIDsAndEventTypes() event %q of tenant %q cannot be saved for tenant %q", evt.ID, evt.TenantID, tenantID)
+			}
 			if _, exists := uniqueEventTypes[evt.Type]; !exists {
 				eventTypes = append(eventTypes, evt.Type)
 				uniqueEventTypes[evt.Type] = true
@@ -59,7 +63,7 @@ func (d DomainService) AddEventsInStreams(ctx context.Context, events []event.Pe
 			return fmt.Errorf("get from collection failed: %w", err)
 		}
 		// open checks if version / modification strategy etc. is correct
-		if err = aggStream.Open(pEvents.Version); err != nil {
+		if err = aggStream.Open(pEvents.Version, pEvents.Expect); err != nil {
 			return fmt.Errorf("open stream failed: %w", err)
 		}
 		if err = d.addEventsToAggregate(ctx, pEvents.Events, aggStream, cache); err != nil {
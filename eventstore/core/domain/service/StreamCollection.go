@@ -8,8 +8,11 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/local/eventBus/domainEvents"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/aggregate"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"time"
 )
 
 func NewStreamCollection(aggregates []aggregate.Stream, projections []projection.Stream) *StreamCollection {
@@ -96,6 +99,60 @@ func (s *StreamCollection) EventsDuringSaving() []eventBus.Event {
 	return result
 }
 
+// ScheduledTasks returns a task for each future patch of the projection streams. The tasks must be executed (projected)
+// as soon as the valid time of the future patch is reached.
+func (s *StreamCollection) ScheduledTasks() []scheduler.ScheduledProjectionTask {
+	var result []scheduler.ScheduledProjectionTask
+	for _, stream := range append(s.consistentProjections, s.evtlConsistentProjections...) {
+		for _, evt := range stream.FuturePatches() {
+			result = append(result, scheduler.ScheduledProjectionTask{
+				Id:      stream.ID(),
+				Time:    evt.ValidTime,
+				Version: evt.Version,
+			})
+		}
+	}
+	return result
+}
+
+// Notifications returns a notification for each eventual consistent projection stream. The notifications wake up the
+// projections in other instances of the event store (the own instance uses EventsDuringSaving).
+func (s *StreamCollection) Notifications(origin string) []notifier.Notification {
+	var result []notifier.Notification
+	for _, stream := range append(s.consistentProjections, s.evtlConsistentProjections...) {
+		if stream.Options().ProjectionType == event.ESS || stream.Options().ProjectionType == event.ECS {
+			result = append(result, notifier.Notification{
+				Origin:         origin,
+				Id:             stream.ID(),
+				EarliestHPatch: stream.EarliestHPatch(),
+			})
+		}
+	}
+	return result
+}
+
+// OutboxMessages returns a pending message for each saved event of the aggregate streams with one of the given event
+// types. The messages are due immediately.
+func (s *StreamCollection) OutboxMessages(eventTypes map[string]bool, now time.Time) []outbox.Message {
+	if len(eventTypes) == 0 {
+		return nil
+	}
+
+	var result []outbox.Message
+	for _, stream := range s.aggregates {
+		for _, evt := range stream.Events() {
+			if eventTypes[evt.Type] {
+				result = append(result, outbox.Message{
+					Event:       evt,
+					Status:      outbox.Pending,
+					NextAttempt: now,
+				})
+			}
+		}
+	}
+	return result
+}
+
 func (s *StreamCollection) GetProjectionsForEventType(_ context.Context, eventType string) []*projection.Stream {
 	return s.evenTypeToProjections[eventType]
 }
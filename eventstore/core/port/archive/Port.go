@@ -0,0 +1,28 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"github.com/global-soft-ba/go-eventstore"
+)
+
+// The archive stores the events and snapshots of closed aggregate streams outside the event store (see
+// event.StreamArchiveManagement). Storing a stream again overwrites it, because a restored stream stays in the archive
+// until it is archived again.
+
+// ErrStreamNotFound is returned for streams, which are not stored in the archive.
+var ErrStreamNotFound = errors.New("archived stream not found")
+
+type Stream struct {
+	// Events contain the deleted events and delete patches as well. Each event keeps its position.
+	Events    []event.PersistenceEvent `json:"events"`
+	SnapShots []event.PersistenceEvent `json:"snapShots"`
+}
+
+type Port interface {
+	Store(ctx context.Context, tenantID, aggregateType, aggregateID string, stream Stream) error
+	// Load returns the stream or ErrStreamNotFound.
+	Load(ctx context.Context, tenantID, aggregateType, aggregateID string) (Stream, error)
+	// DeleteTenant deletes all streams of the tenant, e.g. if the tenant is purged.
+	DeleteTenant(ctx context.Context, tenantID string) error
+}
@@ -0,0 +1,30 @@
+package consistentClock
+
+import (
+	"fmt"
+	"time"
+)
+
+func NewErrorClockSkew(wallClock, persisted time.Time, maxSkew time.Duration) *ErrorClockSkew {
+	return &ErrorClockSkew{
+		WallClock: wallClock,
+		Persisted: persisted,
+		MaxSkew:   maxSkew,
+	}
+}
+
+// ErrorClockSkew is returned if the wall clock is further behind the latest persisted transaction time than allowed
+type ErrorClockSkew struct {
+	WallClock time.Time
+	Persisted time.Time
+	MaxSkew   time.Duration
+}
+
+func (c *ErrorClockSkew) Error() string {
+	return fmt.Sprintf("wall clock %v is %v behind the persisted transaction time %v (max skew %v)", c.WallClock, c.Persisted.Sub(c.WallClock), c.Persisted, c.MaxSkew)
+}
+
+func (c *ErrorClockSkew) Is(target error) bool {
+	_, ok := target.(*ErrorClockSkew)
+	return ok
+}
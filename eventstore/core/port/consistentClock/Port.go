@@ -9,3 +9,10 @@ import "time"
 type Port interface {
 	Now() time.Time
 }
+
+// SeedablePort is a clock, which can be seeded with the latest persisted transaction time (e.g. after a restart of
+// the pod), so that it never issues a time before the already stored one.
+type SeedablePort interface {
+	Port
+	Seed(latest time.Time) error
+}
@@ -0,0 +1,20 @@
+package keyStore
+
+import (
+	"context"
+	"errors"
+)
+
+// Personal data of events is encrypted with a key per data subject (e.g. a person). Deleting the key of a data subject
+// makes its personal data unreadable in all events (crypto-shredding), without rewriting the history.
+
+// ErrKeyNotFound is returned for data subjects without key, e.g. if the key was deleted.
+var ErrKeyNotFound = errors.New("key not found")
+
+type Port interface {
+	// GetOrCreateKey returns the key of the data subject, and creates it, if it does not exist (yet or anymore).
+	GetOrCreateKey(ctx context.Context, tenantID, subjectID string) ([]byte, error)
+	// GetKey returns the key of the data subject or ErrKeyNotFound.
+	GetKey(ctx context.Context, tenantID, subjectID string) ([]byte, error)
+	DeleteKey(ctx context.Context, tenantID, subjectID string) error
+}
@@ -0,0 +1,23 @@
+package notifier
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"time"
+)
+
+// Notification announces new events in the queue of an eventual consistent projection.
+type Notification struct {
+	// Origin is the instance id of the event store, which saved the events
+	Origin         string
+	Id             shared.ProjectionID
+	EarliestHPatch time.Time
+}
+
+type Port interface {
+	// Notify sends the notifications to all listeners (of all instances), as soon as the surrounding transaction is
+	// committed. Notifications of rolled back transactions are never sent.
+	Notify(ctx context.Context, notifications ...Notification) error
+	// Listen passes all received notifications to the handler until the context is canceled.
+	Listen(ctx context.Context, handler func(ctx context.Context, notification Notification)) error
+}
@@ -1,13 +1,25 @@
 package persistence
 
 import (
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/partition"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
 )
 
 type Port interface {
 	AggregatePort() aggregate.Port
 	ProjectionPort() projection.Port
+	SchedulerPort() scheduler.Port
+	NotifierPort() notifier.Port
+	SubscriptionPort() subscription.Port
+	OutboxPort() outbox.Port
+	TenantPort() tenant.Port
+	PartitionPort() partition.Port
 	Transactor() transactor.Port
 }
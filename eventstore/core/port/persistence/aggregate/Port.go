@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"iter"
 	"time"
 )
 
 const MaxPageSizeAggregatesEvents = 1000
+const MaxLimitLoadFromPosition = 1000
 
 type NotFoundError struct {
 	ID shared.AggregateID
@@ -46,6 +48,32 @@ type Port interface {
 	LoadAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error)
 	LoadAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error)
 	LoadAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) (eventStreams []event.PersistenceEvents, err error)
+	LoadFromPosition(ctx context.Context, tenantID string, position int64, limit int) (events []event.PersistenceEvent, err error)
+	// LoadAllOfAggregateAsOfRange returns the event streams with as-of semantic at the end of the range, but with the
+	// most recent snapshot at the start of the range. So each stream can be sliced for any projection time of the
+	// range. An empty result is no error.
+	LoadAllOfAggregateAsOfRange(ctx context.Context, tenantID, aggregateType string, from, till time.Time) (eventStreams []event.PersistenceEvents, err error)
+	// LoadEventsAsOfTill and LoadAllEventsOfAggregateAsOfTill return the events with as-of-till semantic, but without the
+	// snapshot short-cut (and without delete patches), ordered by aggregate, valid time and version.
+	LoadEventsAsOfTill(ctx context.Context, projectionTime, reportTime time.Time, key shared.AggregateID) (events []event.PersistenceEvent, err error)
+	LoadAllEventsOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) (events []event.PersistenceEvent, err error)
+	// StreamAll... have the same semantics as LoadAll..., but read the event streams lazily while iterating. An empty
+	// result yields nothing instead of an ErrorEmptyEventStream.
+	StreamAllOfAggregateAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllOfAggregateAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+	StreamAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error]
+
+	// ExportAggregates, ExportEvents and ExportSnapShots read all rows of the tenant lazily (e.g. for a tenant export).
+	// The events include deleted events and delete patches and are ordered by their position.
+	ExportAggregates(ctx context.Context, tenantID string) iter.Seq2[DTO, error]
+	ExportEvents(ctx context.Context, tenantID string) iter.Seq2[event.PersistenceEvent, error]
+	ExportSnapShots(ctx context.Context, tenantID string) iter.Seq2[event.PersistenceEvent, error]
+	// Import writes the rows of a tenant import as they are, i.e. without the consistency checks of Save. The events
+	// get the next positions of their tenant in the given order.
+	Import(ctx context.Context, states []DTO, events []event.PersistenceEvent, snapShots []event.PersistenceEvent) error
 
 	Lock(ctx context.Context, ids ...shared.AggregateID) error
 	UnLock(ctx context.Context, ids ...shared.AggregateID) error
@@ -63,6 +91,21 @@ type Port interface {
 	DeleteAllInvalidSnapsShots(ctx context.Context, patchEvents []PatchDTO) error
 	UndoCloseStream(ctx context.Context, id shared.AggregateID) error
 
+	// GetArchivableStreams returns at most limit streams of all tenants, which were closed before closedBefore and are
+	// not archived yet, ordered by their close time.
+	GetArchivableStreams(ctx context.Context, closedBefore time.Time, limit int) ([]shared.AggregateID, error)
+	// GetArchivedStreams returns all archived streams of the tenant.
+	GetArchivedStreams(ctx context.Context, tenantID string) ([]shared.AggregateID, error)
+	// GetArchiveTime returns the time, when the stream was archived, or the zero time for streams, which are not archived.
+	GetArchiveTime(ctx context.Context, id shared.AggregateID) (time.Time, error)
+	// ArchiveStream deletes the events (including deleted events and delete patches) and snapshots of the stream, marks
+	// the stream as archived, and returns the deleted rows. The aggregate state is kept.
+	ArchiveStream(ctx context.Context, id shared.AggregateID, archived time.Time) (events []event.PersistenceEvent, snapShots []event.PersistenceEvent, err error)
+	// RestoreStream writes the archived rows back as they are, i.e. the events keep their positions, and removes the
+	// archive mark of the stream.
+	RestoreStream(ctx context.Context, id shared.AggregateID, events []event.PersistenceEvent, snapShots []event.PersistenceEvent) error
+
 	GetPatchFreePeriodsForInterval(ctx context.Context, tenantID, aggregateType, aggregateID string, start time.Time, end time.Time) ([]event.TimeInterval, error)
 	GetAggregatesEvents(ctx context.Context, tenantID string, page event.PageDTO) (events []event.PersistenceEvent, pages event.PagesDTO, err error)
+	GetLatestTransactionTime(ctx context.Context) (time.Time, error)
 }
@@ -0,0 +1,33 @@
+package outbox
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"time"
+)
+
+type Status string
+
+const (
+	Pending    Status = "pending"
+	DeadLetter Status = "dead_letter"
+)
+
+// Message is a saved event, which must be delivered to the publisher.
+type Message struct {
+	Event       event.PersistenceEvent
+	Status      Status
+	Attempts    int
+	NextAttempt time.Time
+	LastError   string
+}
+
+type Port interface {
+	AddMessages(ctx context.Context, messages ...Message) error
+	// GetDueMessages returns the pending messages (of all tenants), whose next attempt is not after the given time,
+	// ordered by the transaction time of the events. Messages which are locked by another transaction are skipped.
+	GetDueMessages(ctx context.Context, now time.Time, limit int) ([]Message, error)
+	GetDeadLetters(ctx context.Context, tenantID string) ([]Message, error)
+	UpdateMessages(ctx context.Context, messages ...Message) error
+	DelMessages(ctx context.Context, messages ...Message) error
+}
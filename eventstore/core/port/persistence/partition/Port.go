@@ -0,0 +1,33 @@
+package partition
+
+import (
+	"context"
+)
+
+type DTO struct {
+	Table     string
+	Partition string
+	Key       string
+	TenantID  string
+	Default   bool
+	Rows      int64
+	Bytes     int64
+}
+
+// UnpartitionedDTO is an aggregate type or projection of a tenant with rows in a default partition.
+type UnpartitionedDTO struct {
+	TenantID      string
+	AggregateType string
+	ProjectionID  string
+}
+
+type Port interface {
+	// Enabled is false, if the adapter does not partition the tables, so that nothing must be prepared.
+	Enabled() bool
+	// Prepare creates the missing partitions of the aggregate types and projections (and of the tenant, if they are
+	// sub-partitioned by tenant) and moves their rows out of the default partitions. It needs stronger locks than a
+	// save and must not run within the transaction of a save.
+	Prepare(ctx context.Context, tenantID string, aggregateTypes []string, projectionIDs []string) error
+	GetUnpartitioned(ctx context.Context) ([]UnpartitionedDTO, error)
+	GetSizes(ctx context.Context) ([]DTO, error)
+}
@@ -18,19 +18,53 @@ func (c *NotFoundError) Error() string {
 
 type LoadOptions struct {
 	ChunkSize int
+	// Lanes > 1 restricts the loaded events to the aggregates of the given Lane. The adapter assigns each aggregate by
+	// the hash of its ID to exactly one of the lanes, and this assignment must not change over time.
+	Lane  int
+	Lanes int
 }
 
 type DTO struct {
 	TenantID     string
 	ProjectionID string
 	State        string
+	Generation   int
 	UpdatedAt    time.Time
 	Events       []event.PersistenceEvent
+
+	// LastRunAt, LastError and Failures are the latest execution results (see SaveExecutionResult). They are not changed
+	// by SaveStates.
+	LastRunAt time.Time
+	LastError string
+	Failures  int
+}
+
+// ExecutionResult is the result of an execution of the projection. An empty Error marks a successful execution, which
+// resets the consecutive failures of the projection.
+type ExecutionResult struct {
+	ExecutedAt time.Time
+	Error      string
+}
+
+// QueueStatistics describes the events in the queue of the projection, which are due to be projected.
+type QueueStatistics struct {
+	ID                    shared.ProjectionID
+	QueuedEvents          int
+	OldestTransactionTime time.Time
+}
+
+// QuarantinedEvent is an event, which is removed from the queue of the projection, because its execution failed.
+type QuarantinedEvent struct {
+	Event         event.PersistenceEvent
+	LastError     string
+	QuarantinedAt time.Time
 }
 
 type Port interface {
 	Lock(ctx context.Context, ids ...shared.ProjectionID) error
 	UnLock(ctx context.Context, ids ...shared.ProjectionID) error
+	LockLane(ctx context.Context, id shared.ProjectionID, lane int) error
+	UnLockLane(ctx context.Context, id shared.ProjectionID, lane int) error
 
 	Get(ctx context.Context, ids ...shared.ProjectionID) ([]DTO, []NotFoundError, error)
 	GetAllForTenant(ctx context.Context, tenantID string) ([]DTO, error)
@@ -40,11 +74,26 @@ type Port interface {
 
 	SaveStates(ctx context.Context, projections ...DTO) error
 	SaveEvents(ctx context.Context, projections ...DTO) error
+	SaveExecutionResult(txCtx context.Context, id shared.ProjectionID, result ExecutionResult) error
+
+	// GetQueueStatistics returns the statistics of the queues of the projections. Projections without queued events
+	// are missing in the result.
+	GetQueueStatistics(txCtx context.Context, ids ...shared.ProjectionID) ([]QueueStatistics, error)
 
 	RemoveProjection(ctx context.Context, projectionID string) error
+	// DeleteProjection removes the state and the queue of the projection of a single tenant.
+	DeleteProjection(txCtx context.Context, id shared.ProjectionID) error
 
 	ResetSince(txCtx context.Context, id shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error
+	// ForkQueue resets the queue of the projection fork like ResetSince, but without the events, which are still in the
+	// queue of the projection id. These events reach the fork, once the projection id executed them.
+	ForkQueue(txCtx context.Context, id, fork shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error
 
 	DeleteEventFromQueue(txCtx context.Context, eventID string, id ...shared.ProjectionID) error
 	GetProjectionsWithEventInQueue(txCtx context.Context, id shared.AggregateID, eventID string) ([]shared.ProjectionID, error)
+
+	AddQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, events ...QuarantinedEvent) error
+	// GetQuarantinedEvents returns the quarantined events of the projection, ordered by valid time.
+	GetQuarantinedEvents(txCtx context.Context, id shared.ProjectionID) ([]QuarantinedEvent, error)
+	DelQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, eventIDs ...string) error
 }
@@ -0,0 +1,18 @@
+package subscription
+
+import (
+	"context"
+)
+
+type Checkpoint struct {
+	TenantID       string
+	SubscriptionID string
+	Position       int64
+}
+
+type Port interface {
+	// GetCheckpoint returns the persisted checkpoint of the subscription. If the subscription has no checkpoint yet,
+	// found is false.
+	GetCheckpoint(ctx context.Context, tenantID, subscriptionID string) (checkpoint Checkpoint, found bool, err error)
+	SaveCheckpoint(ctx context.Context, checkpoint Checkpoint) error
+}
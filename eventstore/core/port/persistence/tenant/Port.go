@@ -0,0 +1,27 @@
+package tenant
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"time"
+)
+
+type DTO struct {
+	TenantID  string
+	Status    event.TenantStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Port interface {
+	// Register adds the tenant as active, if it does not exist yet, and returns the (existing) tenant.
+	Register(ctx context.Context, tenantID string, now time.Time) (DTO, error)
+	// Get returns the tenant. If the tenant does not exist, found is false. Within a transaction, the tenant is locked
+	// shared, so that a change of its status waits for the end of the transaction (e.g. a save).
+	Get(ctx context.Context, tenantID string) (tenant DTO, found bool, err error)
+	GetAll(ctx context.Context) ([]DTO, error)
+	SetStatus(ctx context.Context, tenantID string, status event.TenantStatus, now time.Time) error
+	// Purge deletes the tenant with all its data, i.e. its aggregates, events, snapshots, projections, queued projection
+	// events, quarantined events, scheduled projection tasks, positions, subscription checkpoints and outbox messages.
+	Purge(ctx context.Context, tenantID string) error
+}
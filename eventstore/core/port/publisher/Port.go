@@ -0,0 +1,12 @@
+package publisher
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+)
+
+// Port delivers the events of the outbox to an external system (e.g. a message broker). The delivery is at least
+// once, so the receiver must be able to handle duplicates (e.g. by the id of the event).
+type Port interface {
+	Publish(ctx context.Context, evt event.PersistenceEvent) error
+}
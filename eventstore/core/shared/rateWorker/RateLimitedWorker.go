@@ -64,7 +64,7 @@ func (p *RateLimitedWorker[T]) Start() {
 	for {
 		select {
 		case <-p.stopCh:
-			break
+			return
 		case param := <-p.msgCh:
 			p.subscribe(param.ResultCh)
 			runID := p.getCurrentRunAndSetNextRun()
@@ -11,17 +11,28 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/repository"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/services"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/application/services/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/archive"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/consistentClock"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/keyStore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/publisher"
 	noopLogger "github.com/global-soft-ba/go-eventstore/instrumentation/adapter/logger/noop"
 	noopMetrics "github.com/global-soft-ba/go-eventstore/instrumentation/adapter/metrics/noop"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"iter"
 	"time"
 )
 
 func NewForTestWithTxCTX(txCtx context.Context, adapter persistence.Port, options ...func(store *eventStore) error) (event.EventStore, error, chan error) {
 	adpAgg := adapter.AggregatePort()
 	projAgg := adapter.ProjectionPort()
+	sched := adapter.SchedulerPort()
+	notify := adapter.NotifierPort()
+	subs := adapter.SubscriptionPort()
+	outboxPort := adapter.OutboxPort()
+	tenantPort := adapter.TenantPort()
+	partitionPort := adapter.PartitionPort()
 	trans := adapter.Transactor()
 
 	registries := registry.NewRegistries()
@@ -31,11 +42,18 @@ func NewForTestWithTxCTX(txCtx context.Context, adapter persistence.Port, option
 	evtBus := eventBus.NewEventPublisher()
 	cmdBus := commandBus.NewCommandPublisher()
 
-	saver := services.NewSaverService(aggRepro, projRepro, trans, evtBus, cmdBus, registries)
-	loader := services.NewLoaderService(aggRepro, trans)
-	projecter := projection.NewProjectionService(projRepro, trans, evtBus, cmdBus, registries)
-
-	evtStore := &eventStore{saver, loader, projecter, registries}
+	tenants := services.NewTenantService(tenantPort, trans, cmdBus, registries)
+	partitions := services.NewPartitionService(partitionPort, trans)
+	streamArchiver := services.NewStreamArchiveService(adpAgg, trans, cmdBus)
+	saver := services.NewSaverService(aggRepro, projRepro, sched, notify, outboxPort, trans, evtBus, cmdBus, registries, tenants, partitions, streamArchiver)
+	loader := services.NewLoaderService(aggRepro, trans, streamArchiver)
+	projecter := projection.NewProjectionService(projRepro, sched, notify, tenantPort, trans, evtBus, cmdBus, registries)
+	subscriber := services.NewSubscriptionService(aggRepro, subs, trans)
+	outboxer := services.NewOutboxService(outboxPort, trans)
+	archiver := services.NewTenantArchiveService(adpAgg, projAgg, trans, tenants, streamArchiver, registries)
+
+	evtStore := &eventStore{saver: saver, loader: loader, projecter: projecter, subscriber: subscriber, outboxer: outboxer, archiver: archiver, tenants: tenants, partitions: partitions, streamArchiver: streamArchiver, registries: registries}
+	evtStore.snapshotter = services.NewSnapshotService(aggRepro, &evtStore.saver, trans, registries)
 	for _, opt := range options {
 		err := opt(evtStore)
 		if err != nil {
@@ -43,6 +61,7 @@ func NewForTestWithTxCTX(txCtx context.Context, adapter persistence.Port, option
 		}
 	}
 	evtStore.ensureLoggerAndMetrics()
+	evtStore.outboxer.StartDispatcher()
 
 	return evtStore, nil, evtBus.Publish(txCtx, domainEvents.EventStoreStarted())
 }
@@ -50,6 +69,12 @@ func NewForTestWithTxCTX(txCtx context.Context, adapter persistence.Port, option
 func New(adapter persistence.Port, options ...func(store *eventStore) error) (event.EventStore, error, chan error) {
 	adpAgg := adapter.AggregatePort()
 	projAgg := adapter.ProjectionPort()
+	sched := adapter.SchedulerPort()
+	notify := adapter.NotifierPort()
+	subs := adapter.SubscriptionPort()
+	outboxPort := adapter.OutboxPort()
+	tenantPort := adapter.TenantPort()
+	partitionPort := adapter.PartitionPort()
 	trans := adapter.Transactor()
 
 	registries := registry.NewRegistries()
@@ -59,17 +84,25 @@ func New(adapter persistence.Port, options ...func(store *eventStore) error) (ev
 	evtBus := eventBus.NewEventPublisher()
 	cmdBus := commandBus.NewCommandPublisher()
 
-	saver := services.NewSaverService(aggRepro, projRepro, trans, evtBus, cmdBus, registries)
-	loader := services.NewLoaderService(aggRepro, trans)
-	projecter := projection.NewProjectionService(projRepro, trans, evtBus, cmdBus, registries)
-
-	evtStore := &eventStore{saver, loader, projecter, registries}
+	tenants := services.NewTenantService(tenantPort, trans, cmdBus, registries)
+	partitions := services.NewPartitionService(partitionPort, trans)
+	streamArchiver := services.NewStreamArchiveService(adpAgg, trans, cmdBus)
+	saver := services.NewSaverService(aggRepro, projRepro, sched, notify, outboxPort, trans, evtBus, cmdBus, registries, tenants, partitions, streamArchiver)
+	loader := services.NewLoaderService(aggRepro, trans, streamArchiver)
+	projecter := projection.NewProjectionService(projRepro, sched, notify, tenantPort, trans, evtBus, cmdBus, registries)
+	subscriber := services.NewSubscriptionService(aggRepro, subs, trans)
+	outboxer := services.NewOutboxService(outboxPort, trans)
+	archiver := services.NewTenantArchiveService(adpAgg, projAgg, trans, tenants, streamArchiver, registries)
+
+	evtStore := &eventStore{saver: saver, loader: loader, projecter: projecter, subscriber: subscriber, outboxer: outboxer, archiver: archiver, tenants: tenants, partitions: partitions, streamArchiver: streamArchiver, registries: registries}
+	evtStore.snapshotter = services.NewSnapshotService(aggRepro, &evtStore.saver, trans, registries)
 	for _, opt := range options {
 		err := opt(evtStore)
 		if err != nil {
 			return eventStore{}, fmt.Errorf("could not configure eventstore: %w", err), nil
 		}
 	}
+	evtStore.outboxer.StartDispatcher()
 
 	return evtStore, nil, evtBus.Publish(context.Background(), domainEvents.EventStoreStarted())
 }
@@ -112,6 +145,32 @@ func WithSaveRetryDurations(retryAfterMilliseconds []time.Duration) func(store *
 	}
 }
 
+// WithConsistentClock replaces the default wall clock, which defines the global order of all events (default:
+// time.Now()). A seedable clock (e.g. a hybrid logical clock) is seeded with the latest persisted transaction time.
+func WithConsistentClock(clock consistentClock.Port) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		return s.saver.SetClock(context.Background(), clock)
+	}
+}
+
+// WithSnapshotPolicy creates the snapshots of the aggregates of the aggregate type automatically according to the
+// policy. The snapshotter provides the state of the aggregates for the snapshots.
+func WithSnapshotPolicy(aggregateType string, snapshotter event.Snapshotter, policy event.SnapshotPolicy) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		return s.registries.AggregateRegistry.SetSnapshotPolicy(aggregateType, snapshotter, policy)
+	}
+}
+
+// WithSnapshotContext creates the snapshots of the snapshot policies in the background until the given context is
+// canceled (default: context.Background()). Afterward, no new snapshots are started and the running ones are canceled
+// (see event.SnapshotManagement.WaitForSnapshots).
+func WithSnapshotContext(ctx context.Context) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		s.snapshotter.SetContext(ctx)
+		return nil
+	}
+}
+
 func WithProjection(proj event.Projection) func(store *eventStore) error {
 	return func(s *eventStore) error {
 		err := s.registries.ProjectionRegistry.Register(proj)
@@ -122,6 +181,16 @@ func WithProjection(proj event.Projection) func(store *eventStore) error {
 	}
 }
 
+// WithProjectionNotifications enables the listening for notifications of other instances (pods) of the event store.
+// Eventual consistent projections are then executed immediately after another instance saved events for them (instead
+// of waiting for the next ExecuteAllProjections). The listener runs until the given context is canceled.
+func WithProjectionNotifications(ctx context.Context) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		s.projecter.SetNotificationContext(ctx)
+		return nil
+	}
+}
+
 func WithProjectionWorkerQueueLength(projectionID string, workerQueueLength int) func(store *eventStore) error {
 	return func(s *eventStore) error {
 		err := s.registries.ProjectionRegistry.SetWorkerQueueLength(projectionID, workerQueueLength)
@@ -176,6 +245,45 @@ func WithFinishTimeOut(projectionID string, timeOut time.Duration) func(store *e
 	}
 }
 
+// WithProjectionRetry retries the failed or timed out execution of an eventual consistent projection up to maxRetries
+// times. The backoff before the first retry doubles with each further retry (default: no retries).
+func WithProjectionRetry(projectionID string, maxRetries int, backoff time.Duration) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if maxRetries < 0 || backoff < 0 {
+			return fmt.Errorf("invalid projection retry with %d retries and backoff %v", maxRetries, backoff)
+		}
+		durations := make([]time.Duration, maxRetries)
+		for i := range durations {
+			durations[i] = backoff << i
+		}
+		return s.registries.ProjectionRegistry.SetRetryDurations(projectionID, durations)
+	}
+}
+
+// WithProjectionQuarantine moves the events of an eventual consistent projection, which still fail after all retries
+// (see WithProjectionRetry), into the quarantine. The other events of the queue are projected further on. Without
+// quarantine, the failed events block the projection until they are deleted or the projection is fixed.
+func WithProjectionQuarantine(projectionID string) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		return s.registries.ProjectionRegistry.SetQuarantine(projectionID, true)
+	}
+}
+
+// WithProjectionLanes partitions the queue of an eventual consistent single stream projection (event.ESS) by the hash
+// of the aggregate ID into the given number of lanes (default: 1). Each lane is executed by its own worker and lock,
+// so that the lanes are projected in parallel (also across instances). The order of the events is only preserved per
+// aggregate. With more than one lane, the handlers of the projection (event.Projection.Execute) run concurrently, i.e.
+// projections with state shared across aggregates must synchronize it and must not assume a serial execution. The
+// lanes are ignored for all other projection types.
+func WithProjectionLanes(projectionID string, lanes int) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if lanes < 1 {
+			return fmt.Errorf("invalid number of %d lanes for projection %q", lanes, projectionID)
+		}
+		return s.registries.ProjectionRegistry.SetLanes(projectionID, lanes)
+	}
+}
+
 func WithHistoricalPatchStrategy(projectionID string, strategy event.ProjectionPatchStrategy) func(store *eventStore) error {
 	return func(s *eventStore) error {
 		err := s.registries.ProjectionRegistry.SetHPatchStrategy(projectionID, strategy)
@@ -206,6 +314,109 @@ func WithProjectionType(projectionID string, projectionType event.ProjectionType
 	}
 }
 
+// WithSubscriptionPollInterval sets the interval in which subscriptions look for new events, after they have handled
+// all events of the global event log (default: 500 * time.Millisecond)
+func WithSubscriptionPollInterval(interval time.Duration) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if interval <= 0 {
+			return fmt.Errorf("invalid subscription poll interval %v", interval)
+		}
+		s.subscriber.SetPollInterval(interval)
+		return nil
+	}
+}
+
+// WithOutbox writes the events of the given event types into the outbox, within the transaction which saves them.
+func WithOutbox(eventTypes ...string) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if len(eventTypes) == 0 {
+			return fmt.Errorf("no event types for the outbox given")
+		}
+		s.saver.SetOutboxEventTypes(eventTypes...)
+		return nil
+	}
+}
+
+// WithOutboxPublisher starts a dispatcher, which delivers the events of the outbox to the publisher. The dispatcher
+// runs until the given context is canceled.
+func WithOutboxPublisher(ctx context.Context, publisher publisher.Port) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if publisher == nil {
+			return fmt.Errorf("no outbox publisher given")
+		}
+		s.outboxer.SetPublisher(ctx, publisher)
+		return nil
+	}
+}
+
+// WithOutboxPollInterval sets the interval in which the dispatcher looks for due events of the outbox
+// (default: 500 * time.Millisecond)
+func WithOutboxPollInterval(interval time.Duration) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if interval <= 0 {
+			return fmt.Errorf("invalid outbox poll interval %v", interval)
+		}
+		s.outboxer.SetPollInterval(interval)
+		return nil
+	}
+}
+
+// WithOutboxRetry sets the maximal number of attempts to deliver an event of the outbox, before it is moved into the
+// dead letters (default: 10), and the backoff after the first failed attempt, which doubles with each further attempt
+// (default: 100 * time.Millisecond)
+func WithOutboxRetry(maxAttempts int, backoff time.Duration) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if maxAttempts <= 0 || backoff < 0 {
+			return fmt.Errorf("invalid outbox retry with %d attempts and backoff %v", maxAttempts, backoff)
+		}
+		s.outboxer.SetRetry(maxAttempts, backoff)
+		return nil
+	}
+}
+
+// WithSerializer encodes the events of the given aggregate types or event types with the serializer (default: JSON).
+// The serializer is also registered for the decoding of events with its codec.
+func WithSerializer(serializer event.Serializer, aggregateOrEventTypes ...string) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if len(aggregateOrEventTypes) == 0 {
+			return fmt.Errorf("no aggregate types or event types for the serializer given")
+		}
+		return event.RegisterSerializer(serializer, aggregateOrEventTypes...)
+	}
+}
+
+// WithKeyStore sets the key store for the encryption of the personal data of events (see event.ShredPersonalData).
+func WithKeyStore(keyStore keyStore.Port) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if keyStore == nil {
+			return fmt.Errorf("no key store given")
+		}
+		event.SetKeyStore(keyStore)
+		return nil
+	}
+}
+
+// WithStreamArchive moves the events and snapshots of streams, which are closed for longer than closedFor, into the
+// archive, whenever event.StreamArchiveManagement.ArchiveClosedStreams is called. The strategy defines, how loads,
+// saves and deletes of archived streams are handled (e.g. event.RehydrateArchivedStreams).
+func WithStreamArchive(archive archive.Port, closedFor time.Duration, strategy event.ArchivedStreamStrategy) func(store *eventStore) error {
+	return func(s *eventStore) error {
+		if archive == nil {
+			return fmt.Errorf("no stream archive given")
+		}
+		if closedFor < 0 {
+			return fmt.Errorf("invalid closing period %v of archivable streams", closedFor)
+		}
+		switch strategy {
+		case event.RehydrateArchivedStreams, event.RejectArchivedStreams:
+		default:
+			return fmt.Errorf("invalid archived stream strategy %q", strategy)
+		}
+		s.streamArchiver.SetArchive(archive, closedFor, strategy)
+		return nil
+	}
+}
+
 func WithMetrics(metricsPort metrics.Port) func(store *eventStore) error {
 	return func(s *eventStore) error {
 		metrics.SetMetrics(metricsPort)
@@ -221,10 +432,17 @@ func WithLogger(loggerPort logger.Port) func(store *eventStore) error {
 }
 
 type eventStore struct {
-	saver      services.SaverService
-	loader     services.LoaderService
-	projecter  projection.ProjectionService
-	registries *registry.Registries
+	saver          services.SaverService
+	loader         services.LoaderService
+	projecter      projection.ProjectionService
+	subscriber     services.SubscriptionService
+	outboxer       services.OutboxService
+	snapshotter    services.SnapshotService
+	archiver       services.TenantArchiveService
+	tenants        services.TenantService
+	partitions     services.PartitionService
+	streamArchiver services.StreamArchiveService
+	registries     *registry.Registries
 }
 
 func (e eventStore) Save(ctx context.Context, tenantID string, events []event.PersistenceEvent, version int) (chan error, error) {
@@ -242,7 +460,11 @@ func (e eventStore) SaveAll(ctx context.Context, tenantID string, events []event
 	ctx, endSpan := metrics.StartSpan(ctx, "SaveAll (store)", map[string]interface{}{"tenantID": tenantID, "amount of events": len(events)})
 	defer endSpan()
 
-	return e.saver.SaveWithRetry(ctx, tenantID, events)
+	errCh, err := e.saver.SaveWithRetry(ctx, tenantID, events)
+	if err == nil {
+		e.snapshotter.CreateSnapshotsInBackground(tenantID, events)
+	}
+	return errCh, err
 }
 
 func (e eventStore) LoadAsAt(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime time.Time) (eventStream []event.PersistenceEvent, version int, err error) {
@@ -266,6 +488,20 @@ func (e eventStore) LoadAsOfTill(ctx context.Context, tenantID, aggregateType, a
 	return e.loader.LoadAsOfTill(ctx, tenantID, aggregateType, aggregateID, projectionTime, reportTime)
 }
 
+func (e eventStore) DiffAsOfTill(ctx context.Context, tenantID, aggregateType, aggregateID string, projectionTime, reportTimeA, reportTimeB time.Time) (event.Diff, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "DiffAsOfTill (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "aggregateID": aggregateID})
+	defer endSpan()
+
+	return e.loader.DiffAsOfTill(ctx, tenantID, aggregateType, aggregateID, projectionTime, reportTimeA, reportTimeB)
+}
+
+func (e eventStore) DiffAllOfAggregateTypeAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTimeA, reportTimeB time.Time) ([]event.Diff, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "DiffAllOfAggregateTypeAsOfTill (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType})
+	defer endSpan()
+
+	return e.loader.DiffAllOfAggregateAsOfTill(ctx, tenantID, aggregateType, projectionTime, reportTimeA, reportTimeB)
+}
+
 func (e eventStore) LoadAllOfAggregateTypeAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "LoadAllOfAggregateTypeAsAt (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType})
 	defer endSpan()
@@ -287,6 +523,13 @@ func (e eventStore) LoadAllOfAggregateTypeAsOfTill(ctx context.Context, tenantID
 	return e.loader.LoadAllOfAggregateAsOfTill(ctx, tenantID, aggregateType, projectionTime, reportTime)
 }
 
+func (e eventStore) LoadAllOfAggregateTypeAsOfSeries(ctx context.Context, tenantID, aggregateType string, projectionTimes []time.Time) ([]event.PersistenceEventsSeries, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "LoadAllOfAggregateTypeAsOfSeries (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "projectionTimes": len(projectionTimes)})
+	defer endSpan()
+
+	return e.loader.LoadAllOfAggregateAsOfSeries(ctx, tenantID, aggregateType, projectionTimes)
+}
+
 func (e eventStore) LoadAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) (eventStreams []event.PersistenceEvents, err error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "LoadAllAsAt (store)", map[string]interface{}{"tenantID": tenantID, "projectionTime": projectionTime})
 	defer endSpan()
@@ -308,6 +551,63 @@ func (e eventStore) LoadAllAsOfTill(ctx context.Context, tenantID string, projec
 	return e.loader.LoadAllAsOfTill(ctx, tenantID, projectionTime, reportTime)
 }
 
+func (e eventStore) StreamAllOfAggregateTypeAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return streamWithSpan(ctx, "StreamAllOfAggregateTypeAsAt (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType}, func(ctx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return e.loader.StreamAllOfAggregateAsAt(ctx, tenantID, aggregateType, projectionTime)
+	})
+}
+
+func (e eventStore) StreamAllOfAggregateTypeAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return streamWithSpan(ctx, "StreamAllOfAggregateTypeAsOf (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType}, func(ctx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return e.loader.StreamAllOfAggregateAsOf(ctx, tenantID, aggregateType, projectionTime)
+	})
+}
+
+func (e eventStore) StreamAllOfAggregateTypeAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return streamWithSpan(ctx, "StreamAllOfAggregateTypeAsOfTill (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType}, func(ctx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return e.loader.StreamAllOfAggregateAsOfTill(ctx, tenantID, aggregateType, projectionTime, reportTime)
+	})
+}
+
+func (e eventStore) StreamAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return streamWithSpan(ctx, "StreamAllAsAt (store)", map[string]interface{}{"tenantID": tenantID, "projectionTime": projectionTime}, func(ctx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return e.loader.StreamAllAsAt(ctx, tenantID, projectionTime)
+	})
+}
+
+func (e eventStore) StreamAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return streamWithSpan(ctx, "StreamAllAsOf (store)", map[string]interface{}{"tenantID": tenantID, "projectionTime": projectionTime}, func(ctx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return e.loader.StreamAllAsOf(ctx, tenantID, projectionTime)
+	})
+}
+
+func (e eventStore) StreamAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return streamWithSpan(ctx, "StreamAllAsOfTill (store)", map[string]interface{}{"tenantID": tenantID, "projectionTime": projectionTime}, func(ctx context.Context) iter.Seq2[event.PersistenceEvents, error] {
+		return e.loader.StreamAllAsOfTill(ctx, tenantID, projectionTime, reportTime)
+	})
+}
+
+// streamWithSpan starts the span with the iteration, so that it covers the loading of the event streams.
+func streamWithSpan(ctx context.Context, name string, attributes map[string]interface{}, load func(ctx context.Context) iter.Seq2[event.PersistenceEvents, error]) iter.Seq2[event.PersistenceEvents, error] {
+	return func(yield func(event.PersistenceEvents, error) bool) {
+		ctx, endSpan := metrics.StartSpan(ctx, name, attributes)
+		defer endSpan()
+
+		for eventStream, err := range load(ctx) {
+			if !yield(eventStream, err) {
+				return
+			}
+		}
+	}
+}
+
+func (e eventStore) LoadFromPosition(ctx context.Context, tenantID string, position int64, limit int) (events []event.PersistenceEvent, err error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "LoadFromPosition (store)", map[string]interface{}{"tenantID": tenantID, "position": position, "limit": limit})
+	defer endSpan()
+
+	return e.loader.LoadFromPosition(ctx, tenantID, position, limit)
+}
+
 func (e eventStore) DeleteEvent(ctx context.Context, tenantID, aggregateType, aggregateID, eventID string) error {
 	ctx, endspan := metrics.StartSpan(ctx, "DeletedEvent (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "aggregateID": aggregateID, "eventID": eventID})
 	defer endspan()
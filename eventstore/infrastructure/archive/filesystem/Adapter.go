@@ -0,0 +1,103 @@
+package filesystem
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/archive"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const fileExtension = ".json.gz"
+
+// New creates an archive in the local directory. Each stream is stored as gzipped JSON file in the path
+// <dir>/<tenantID>/<aggregateType>/<aggregateID>.json.gz, whereby the path elements are escaped.
+func New(dir string) *Adapter {
+	return &Adapter{dir: dir}
+}
+
+var _ archive.Port = (*Adapter)(nil)
+
+type Adapter struct {
+	dir string
+}
+
+// Store writes the stream into a temporary file first, which replaces the file of the stream afterward, so that a
+// failed write leaves no broken file behind.
+func (a *Adapter) Store(_ context.Context, tenantID, aggregateType, aggregateID string, stream archive.Stream) (err error) {
+	path := a.path(tenantID, aggregateType, aggregateID)
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("storing of stream %q failed: %w", aggregateID, err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+escape(aggregateID)+"-*")
+	if err != nil {
+		return fmt.Errorf("storing of stream %q failed: %w", aggregateID, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	zipped := gzip.NewWriter(file)
+	if err = json.NewEncoder(zipped).Encode(stream); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("storing of stream %q failed: %w", aggregateID, err)
+	}
+	if err = zipped.Close(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("storing of stream %q failed: %w", aggregateID, err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("storing of stream %q failed: %w", aggregateID, err)
+	}
+	if err = os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("storing of stream %q failed: %w", aggregateID, err)
+	}
+	return nil
+}
+
+func (a *Adapter) Load(_ context.Context, tenantID, aggregateType, aggregateID string) (archive.Stream, error) {
+	file, err := os.Open(a.path(tenantID, aggregateType, aggregateID))
+	if errors.Is(err, os.ErrNotExist) {
+		return archive.Stream{}, archive.ErrStreamNotFound
+	}
+	if err != nil {
+		return archive.Stream{}, fmt.Errorf("loading of stream %q failed: %w", aggregateID, err)
+	}
+	defer file.Close()
+
+	zipped, err := gzip.NewReader(file)
+	if err != nil {
+		return archive.Stream{}, fmt.Errorf("loading of stream %q failed: %w", aggregateID, err)
+	}
+	defer zipped.Close()
+
+	var stream archive.Stream
+	if err = json.NewDecoder(zipped).Decode(&stream); err != nil {
+		return archive.Stream{}, fmt.Errorf("loading of stream %q failed: %w", aggregateID, err)
+	}
+	return stream, nil
+}
+
+func (a *Adapter) DeleteTenant(_ context.Context, tenantID string) error {
+	if err := os.RemoveAll(filepath.Join(a.dir, escape(tenantID))); err != nil {
+		return fmt.Errorf("deletion of streams of tenant %q failed: %w", tenantID, err)
+	}
+	return nil
+}
+
+func (a *Adapter) path(tenantID, aggregateType, aggregateID string) string {
+	return filepath.Join(a.dir, escape(tenantID), escape(aggregateType), escape(aggregateID)+fileExtension)
+}
+
+// escape makes the IDs safe as path elements, i.e. they cannot contain separators or be "." and "..".
+func escape(id string) string {
+	return strings.ReplaceAll(url.PathEscape(id), ".", "%2E")
+}
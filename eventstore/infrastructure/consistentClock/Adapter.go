@@ -13,8 +13,7 @@ type Adapter struct {
 }
 
 func (a Adapter) Now() time.Time {
-	//TODO Time synchronizing
-	// - we should keep in mind that the time between different pods are "equal enough"
-	// - within and pod we should have a protection, that a wrong started pod /time destroy temporal order
+	// plain wall clock without any protection against clock skew. Use the HybridLogicalClock
+	// (eventstore.WithConsistentClock) to ensure the temporal order within and across pod restarts.
 	return time.Now()
 }
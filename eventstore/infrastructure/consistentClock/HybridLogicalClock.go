@@ -0,0 +1,88 @@
+package consistentClock
+
+import (
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/consistentClock"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"sync"
+	"time"
+)
+
+// SkewPolicy defines the behaviour of the hybrid logical clock, if the wall clock is further behind the latest
+// persisted transaction time than the allowed max skew.
+type SkewPolicy int
+
+const (
+	// WarnOnSkew logs a warning and continues with logical time steps after the persisted transaction time
+	WarnOnSkew SkewPolicy = iota
+	// RefuseOnSkew refuses the seeding of the clock (and thereby the start of the event store)
+	RefuseOnSkew
+)
+
+// NewHybridLogicalClock returns a clock, which never goes backwards within a process. As long as the wall clock is
+// ahead, it returns the wall clock. Otherwise, it returns the last issued time plus one nanosecond (logical step).
+// A maxSkew <= 0 disables the skew check.
+func NewHybridLogicalClock(maxSkew time.Duration, policy SkewPolicy) *HybridLogicalClock {
+	return &HybridLogicalClock{
+		wallClock: time.Now,
+		maxSkew:   maxSkew,
+		policy:    policy,
+	}
+}
+
+type HybridLogicalClock struct {
+	mu        sync.Mutex
+	wallClock func() time.Time
+	last      time.Time
+	skewed    bool
+
+	maxSkew time.Duration
+	policy  SkewPolicy
+}
+
+var _ consistentClock.SeedablePort = (*HybridLogicalClock)(nil)
+
+func (h *HybridLogicalClock) Now() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	wall := h.wallClock()
+	if wall.After(h.last) {
+		h.last = wall
+		h.skewed = false
+		return h.last
+	}
+
+	h.last = h.last.Add(time.Nanosecond)
+	// warn only once per skew period, otherwise every event would be logged
+	if !h.skewed && h.exceedsMaxSkew(wall, h.last) {
+		h.skewed = true
+		logger.Warn("hybrid logical clock: %v", consistentClock.NewErrorClockSkew(wall, h.last, h.maxSkew))
+	}
+	return h.last
+}
+
+// Seed moves the clock forward to the given (persisted) time. If the wall clock is further behind than the max
+// skew, the seeding either fails (RefuseOnSkew) or a warning is logged (WarnOnSkew).
+func (h *HybridLogicalClock) Seed(latest time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	wall := h.wallClock()
+	if h.exceedsMaxSkew(wall, latest) {
+		err := consistentClock.NewErrorClockSkew(wall, latest, h.maxSkew)
+		if h.policy == RefuseOnSkew {
+			return err
+		}
+		h.skewed = true
+		logger.Warn("hybrid logical clock: %v", err)
+	}
+
+	if latest.After(h.last) {
+		h.last = latest
+	}
+	return nil
+}
+
+func (h *HybridLogicalClock) exceedsMaxSkew(wall, latest time.Time) bool {
+	return h.maxSkew > 0 && latest.Sub(wall) > h.maxSkew
+}
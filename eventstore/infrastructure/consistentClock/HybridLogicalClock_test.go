@@ -0,0 +1,102 @@
+package consistentClock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHybridLogicalClockNow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		wallClock []time.Time
+		want      []time.Time
+	}{
+		{
+			name:      "wall clock ahead",
+			wallClock: []time.Time{start, start.Add(time.Second)},
+			want:      []time.Time{start, start.Add(time.Second)},
+		},
+		{
+			name:      "wall clock stands still",
+			wallClock: []time.Time{start, start, start},
+			want:      []time.Time{start, start.Add(time.Nanosecond), start.Add(2 * time.Nanosecond)},
+		},
+		{
+			name:      "wall clock goes backwards",
+			wallClock: []time.Time{start, start.Add(-time.Second), start.Add(time.Second)},
+			want:      []time.Time{start, start.Add(time.Nanosecond), start.Add(time.Second)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := 0
+			hlc := NewHybridLogicalClock(0, WarnOnSkew)
+			hlc.wallClock = func() time.Time {
+				defer func() { i++ }()
+				return tt.wallClock[i]
+			}
+
+			for j, want := range tt.want {
+				if got := hlc.Now(); !got.Equal(want) {
+					t.Errorf("Now() call %d got %v, want %v", j, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHybridLogicalClockSeed(t *testing.T) {
+	wall := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		maxSkew time.Duration
+		policy  SkewPolicy
+		seed    time.Time
+		wantErr bool
+		wantNow time.Time
+	}{
+		{
+			name:    "seed in the past",
+			maxSkew: time.Second,
+			policy:  RefuseOnSkew,
+			seed:    wall.Add(-time.Hour),
+			wantNow: wall,
+		},
+		{
+			name:    "seed within max skew",
+			maxSkew: time.Second,
+			policy:  RefuseOnSkew,
+			seed:    wall.Add(time.Millisecond),
+			wantNow: wall.Add(time.Millisecond + time.Nanosecond),
+		},
+		{
+			name:    "seed exceeds max skew (refuse)",
+			maxSkew: time.Second,
+			policy:  RefuseOnSkew,
+			seed:    wall.Add(time.Hour),
+			wantErr: true,
+			wantNow: wall,
+		},
+		{
+			name:    "seed exceeds max skew (warn)",
+			maxSkew: time.Second,
+			policy:  WarnOnSkew,
+			seed:    wall.Add(time.Hour),
+			wantNow: wall.Add(time.Hour + time.Nanosecond),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hlc := NewHybridLogicalClock(tt.maxSkew, tt.policy)
+			hlc.wallClock = func() time.Time { return wall }
+
+			if err := hlc.Seed(tt.seed); (err != nil) != tt.wantErr {
+				t.Errorf("Seed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := hlc.Now(); !got.Equal(tt.wantNow) {
+				t.Errorf("Now() got %v, want %v", got, tt.wantNow)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/keyStore"
+	"sync"
+)
+
+const keySize = 32 // AES-256
+
+// New creates an in-process key store (e.g. for tests). The keys are lost with the process, so a key management
+// system should be used in production.
+func New() *Adapter {
+	return &Adapter{keys: make(map[string][]byte)}
+}
+
+var _ keyStore.Port = (*Adapter)(nil)
+
+type Adapter struct {
+	mu   sync.RWMutex
+	keys map[string][]byte // [tenantID/subjectID]key
+}
+
+func (a *Adapter) GetOrCreateKey(_ context.Context, tenantID, subjectID string) ([]byte, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if key, ok := a.keys[a.id(tenantID, subjectID)]; ok {
+		return key, nil
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("creation of key for data subject %q failed: %w", subjectID, err)
+	}
+	a.keys[a.id(tenantID, subjectID)] = key
+	return key, nil
+}
+
+func (a *Adapter) GetKey(_ context.Context, tenantID, subjectID string) ([]byte, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if key, ok := a.keys[a.id(tenantID, subjectID)]; ok {
+		return key, nil
+	}
+	return nil, keyStore.ErrKeyNotFound
+}
+
+func (a *Adapter) DeleteKey(_ context.Context, tenantID, subjectID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.keys, a.id(tenantID, subjectID))
+	return nil
+}
+
+func (a *Adapter) id(tenantID, subjectID string) string {
+	return tenantID + "/" + subjectID
+}
@@ -1,10 +1,16 @@
 package memory
 
 import (
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/partition"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/tests"
 )
@@ -17,8 +23,14 @@ func NewTXPassThrough() persistence.Port {
 	trans := tests.NewTxPassThroughTransactor(internal.CtxStorageKey)
 	aggRepro := internal.NewAggregates(trans)
 	projRepro := internal.NewProjecter(trans)
+	schedRepro := internal.NewScheduler(trans)
+	notifyRepro := internal.NewNotifier(trans)
+	subRepro := internal.NewSubscriptions(trans)
+	outboxRepro := internal.NewOutbox(trans)
+	tenantRepro := internal.NewTenants(trans)
+	partitionRepro := internal.NewPartitions()
 
-	return Adapter{aggregates: aggRepro, projections: projRepro, transactor: trans}
+	return Adapter{aggregates: aggRepro, projections: projRepro, scheduler: schedRepro, notifier: notifyRepro, subscriptions: subRepro, outbox: outboxRepro, tenants: tenantRepro, partitions: partitionRepro, transactor: trans}
 }
 
 // NewTXStored creates a new PostgreSQL event store supporting single transaction operations.
@@ -29,22 +41,40 @@ func NewTXStored() persistence.Port {
 	trans := tests.NewTxPassThroughTransactor(internal.CtxStorageKey)
 	aggRepro := internal.NewAggregates(trans)
 	projRepro := internal.NewProjecter(trans)
+	schedRepro := internal.NewScheduler(trans)
+	notifyRepro := internal.NewNotifier(trans)
+	subRepro := internal.NewSubscriptions(trans)
+	outboxRepro := internal.NewOutbox(trans)
+	tenantRepro := internal.NewTenants(trans)
+	partitionRepro := internal.NewPartitions()
 
-	return Adapter{aggregates: aggRepro, projections: projRepro, transactor: trans}
+	return Adapter{aggregates: aggRepro, projections: projRepro, scheduler: schedRepro, notifier: notifyRepro, subscriptions: subRepro, outbox: outboxRepro, tenants: tenantRepro, partitions: partitionRepro, transactor: trans}
 }
 
 func New() persistence.Port {
 	trans := internal.NewTransactor()
 	aggRepro := internal.NewAggregates(trans)
 	projRepro := internal.NewProjecter(trans)
+	schedRepro := internal.NewScheduler(trans)
+	notifyRepro := internal.NewNotifier(trans)
+	subRepro := internal.NewSubscriptions(trans)
+	outboxRepro := internal.NewOutbox(trans)
+	tenantRepro := internal.NewTenants(trans)
+	partitionRepro := internal.NewPartitions()
 
-	return Adapter{aggregates: aggRepro, projections: projRepro, transactor: trans}
+	return Adapter{aggregates: aggRepro, projections: projRepro, scheduler: schedRepro, notifier: notifyRepro, subscriptions: subRepro, outbox: outboxRepro, tenants: tenantRepro, partitions: partitionRepro, transactor: trans}
 }
 
 type Adapter struct {
-	aggregates  aggregate.Port
-	projections projection.Port
-	transactor  transactor.Port
+	aggregates    aggregate.Port
+	projections   projection.Port
+	scheduler     scheduler.Port
+	notifier      notifier.Port
+	subscriptions subscription.Port
+	outbox        outbox.Port
+	tenants       tenant.Port
+	partitions    partition.Port
+	transactor    transactor.Port
 }
 
 func (a Adapter) ProjectionPort() projection.Port {
@@ -55,6 +85,30 @@ func (a Adapter) AggregatePort() aggregate.Port {
 	return a.aggregates
 }
 
+func (a Adapter) SchedulerPort() scheduler.Port {
+	return a.scheduler
+}
+
+func (a Adapter) NotifierPort() notifier.Port {
+	return a.notifier
+}
+
+func (a Adapter) SubscriptionPort() subscription.Port {
+	return a.subscriptions
+}
+
+func (a Adapter) OutboxPort() outbox.Port {
+	return a.outbox
+}
+
+func (a Adapter) TenantPort() tenant.Port {
+	return a.tenants
+}
+
+func (a Adapter) PartitionPort() partition.Port {
+	return a.partitions
+}
+
 func (a Adapter) Transactor() transactor.Port {
 	return a.transactor
 }
@@ -14,8 +14,14 @@ func NewSlowAdapterForTest() persistence.Port {
 	trans := internal.NewTransactor()
 	aggRepro := internal.NewSlowAggregatePort(trans)
 	projRepro := internal.NewProjecter(trans)
+	schedRepro := internal.NewScheduler(trans)
+	notifyRepro := internal.NewNotifier(trans)
+	subRepro := internal.NewSubscriptions(trans)
+	outboxRepro := internal.NewOutbox(trans)
+	tenantRepro := internal.NewTenants(trans)
+	partitionRepro := internal.NewPartitions()
 
-	return Adapter{aggRepro, projRepro, trans}
+	return Adapter{aggRepro, projRepro, schedRepro, notifyRepro, subRepro, outboxRepro, tenantRepro, partitionRepro, trans}
 }
 
 func NewTransactor() transactor.Port {
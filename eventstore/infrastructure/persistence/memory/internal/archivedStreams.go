@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
+	"sort"
+	"time"
+)
+
+type archivedStreamRow struct {
+	TenantID      string
+	AggregateType string
+	AggregateID   string
+	ArchiveTime   int64
+}
+
+func (s saver) GetArchivableStreams(ctx context.Context, closedBefore time.Time, limit int) ([]shared.AggregateID, error) {
+	it, err := s.GetTx(ctx).Get(db.TableAggregates, db.IdxUnique)
+	if err != nil {
+		return nil, fmt.Errorf("GetArchivableStreams failed: %w", err)
+	}
+
+	var closed []aggregate.DTO
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		agg, ok := obj.(aggregate.DTO)
+		if !ok {
+			return nil, fmt.Errorf("GetArchivableStreams type cast failed %q", obj)
+		}
+		if !agg.CloseTime.IsZero() && agg.CloseTime.Before(closedBefore) {
+			closed = append(closed, agg)
+		}
+	}
+	sort.SliceStable(closed, func(i, j int) bool {
+		return closed[i].CloseTime.Before(closed[j].CloseTime)
+	})
+
+	var result []shared.AggregateID
+	for _, agg := range closed {
+		id := shared.NewAggregateID(agg.TenantID, agg.AggregateType, agg.AggregateID)
+		archived, err := s.GetArchiveTime(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("GetArchivableStreams failed: %w", err)
+		}
+		if !archived.IsZero() {
+			continue
+		}
+		if result = append(result, id); len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s saver) GetArchivedStreams(ctx context.Context, tenantID string) ([]shared.AggregateID, error) {
+	it, err := s.GetTx(ctx).Get(db.TableArchivedStreams, db.IdxUnique+"_prefix", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("GetArchivedStreams failed: %w", err)
+	}
+
+	var result []shared.AggregateID
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		row, ok := obj.(archivedStreamRow)
+		if !ok {
+			return nil, fmt.Errorf("GetArchivedStreams type cast failed %q", obj)
+		}
+		result = append(result, shared.NewAggregateID(row.TenantID, row.AggregateType, row.AggregateID))
+	}
+	return result, nil
+}
+
+func (s saver) GetArchiveTime(ctx context.Context, id shared.AggregateID) (time.Time, error) {
+	obj, err := s.GetTx(ctx).First(db.TableArchivedStreams, db.IdxUnique, id.TenantID, id.AggregateType, id.AggregateID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("GetArchiveTime failed: %w", err)
+	}
+	if obj == nil {
+		return time.Time{}, nil
+	}
+
+	row, ok := obj.(archivedStreamRow)
+	if !ok {
+		return time.Time{}, fmt.Errorf("GetArchiveTime type cast failed %q", obj)
+	}
+	return time.Unix(0, row.ArchiveTime), nil
+}
+
+func (s saver) ArchiveStream(ctx context.Context, id shared.AggregateID, archived time.Time) (events []event.PersistenceEvent, snapShots []event.PersistenceEvent, err error) {
+	// memDB iterators must not be used after a modification of their table, so that the rows are collected first
+	it, err := s.GetTx(ctx).Get(db.TableEvent, db.IdxSetOfId, id.TenantID, id.AggregateType, id.AggregateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ArchiveStream failed: %w", err)
+	}
+	var incEvents []db.AutoIncrementEvent
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		incEvents = append(incEvents, obj.(db.AutoIncrementEvent))
+	}
+	sort.SliceStable(incEvents, func(i, j int) bool {
+		return incEvents[i].Event.Position < incEvents[j].Event.Position
+	})
+	for _, incEvt := range incEvents {
+		if err = s.GetTx(ctx).DeleteEventWithAutoIncrement(db.TableEvent, incEvt.ID, incEvt.Event); err != nil {
+			return nil, nil, fmt.Errorf("ArchiveStream failed: %w", err)
+		}
+		events = append(events, incEvt.Event)
+	}
+
+	it, err = s.GetTx(ctx).Get(db.TableSnapShot, db.IdxSetOfId, id.TenantID, id.AggregateType, id.AggregateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ArchiveStream failed: %w", err)
+	}
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		snapShots = append(snapShots, obj.(event.PersistenceEvent))
+	}
+	for _, snapShot := range snapShots {
+		if err = s.GetTx(ctx).Delete(db.TableSnapShot, snapShot); err != nil {
+			return nil, nil, fmt.Errorf("ArchiveStream failed: %w", err)
+		}
+	}
+
+	row := archivedStreamRow{TenantID: id.TenantID, AggregateType: id.AggregateType, AggregateID: id.AggregateID, ArchiveTime: archived.UnixNano()}
+	if err = s.GetTx(ctx).Insert(db.TableArchivedStreams, row); err != nil {
+		return nil, nil, fmt.Errorf("ArchiveStream failed: %w", err)
+	}
+	return events, snapShots, nil
+}
+
+func (s saver) RestoreStream(ctx context.Context, id shared.AggregateID, events []event.PersistenceEvent, snapShots []event.PersistenceEvent) error {
+	for _, evt := range events {
+		if err := s.GetTx(ctx).InsertEventWithAutoIncrement(db.TableEvent, evt); err != nil {
+			return fmt.Errorf("RestoreStream failed: %w", err)
+		}
+	}
+	for _, snapShot := range snapShots {
+		if err := s.GetTx(ctx).Insert(db.TableSnapShot, snapShot); err != nil {
+			return fmt.Errorf("RestoreStream failed: %w", err)
+		}
+	}
+
+	if _, err := s.GetTx(ctx).DeleteAll(db.TableArchivedStreams, db.IdxUnique, id.TenantID, id.AggregateType, id.AggregateID); err != nil {
+		return fmt.Errorf("RestoreStream failed: %w", err)
+	}
+	return nil
+}
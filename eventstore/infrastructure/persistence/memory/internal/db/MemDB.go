@@ -85,12 +85,20 @@ const (
 	IdxUniqueTenantsId       = "idxUniqueTenantId"
 	IdxSetOfIdClass          = "IdxSetOfIdClass"
 	IdxWithEventID           = "IdxWithEventID"
+	IdxStatus                = "IdxStatus"
 
 	TableAggregates       = "aggregates"
 	TableEvent            = "event"
 	TableSnapShot         = "snapshot"
 	TableProjections      = "projections"
 	TableProjectionsQueue = "projectionsQueue"
+	TableQuarantine       = "projectionsQuarantine"
+	TableScheduledTasks   = "scheduledTasks"
+	TableTenantPositions  = "tenantPositions"
+	TableSubscriptions    = "subscriptions"
+	TableOutbox           = "outbox"
+	TableTenants          = "tenants"
+	TableArchivedStreams  = "archivedStreams"
 )
 
 var dbSchema = &memdb.DBSchema{
@@ -342,5 +350,141 @@ var dbSchema = &memdb.DBSchema{
 				},
 			},
 		},
+		TableQuarantine: {
+			Name: TableQuarantine,
+			Indexes: map[string]*memdb.IndexSchema{
+				IdxUnique: {
+					Name:   IdxUnique,
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "ProjectionID"},
+							&memdb.StringFieldIndex{Field: "EventID"},
+						},
+						AllowMissing: false,
+					},
+				},
+				IdxSetOfId: {
+					Name:   IdxSetOfId,
+					Unique: false,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "ProjectionID"},
+						},
+						AllowMissing: false,
+					},
+				},
+			},
+		},
+		TableScheduledTasks: {
+			Name: TableScheduledTasks,
+			Indexes: map[string]*memdb.IndexSchema{
+				IdxUnique: {
+					Name:   IdxUnique,
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "ProjectionID"},
+							&memdb.IntFieldIndex{Field: "ValidTime"},
+						},
+						AllowMissing: false,
+					},
+				},
+				IdxSetOfId: {
+					Name:   IdxSetOfId,
+					Unique: false,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "ProjectionID"},
+						},
+						AllowMissing: false,
+					},
+				},
+			},
+		},
+		TableTenantPositions: {
+			Name: TableTenantPositions,
+			Indexes: map[string]*memdb.IndexSchema{
+				IdxUnique: {
+					Name:    IdxUnique,
+					Unique:  true,
+					Indexer: &memdb.StringFieldIndex{Field: "TenantID"},
+				},
+			},
+		},
+		TableSubscriptions: {
+			Name: TableSubscriptions,
+			Indexes: map[string]*memdb.IndexSchema{
+				IdxUnique: {
+					Name:   IdxUnique,
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "SubscriptionID"},
+						},
+						AllowMissing: false,
+					},
+				},
+			},
+		},
+		TableOutbox: {
+			Name: TableOutbox,
+			Indexes: map[string]*memdb.IndexSchema{
+				IdxUnique: {
+					Name:   IdxUnique,
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "EventID"},
+						},
+						AllowMissing: false,
+					},
+				},
+				IdxStatus: {
+					Name:   IdxStatus,
+					Unique: false,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "Status"},
+							&memdb.StringFieldIndex{Field: "TenantID"},
+						},
+						AllowMissing: false,
+					},
+				},
+			},
+		},
+		TableTenants: {
+			Name: TableTenants,
+			Indexes: map[string]*memdb.IndexSchema{
+				IdxUnique: {
+					Name:    IdxUnique,
+					Unique:  true,
+					Indexer: &memdb.StringFieldIndex{Field: "TenantID"},
+				},
+			},
+		},
+		TableArchivedStreams: {
+			Name: TableArchivedStreams,
+			Indexes: map[string]*memdb.IndexSchema{
+				IdxUnique: {
+					Name:   IdxUnique,
+					Unique: true,
+					Indexer: &memdb.CompoundIndex{
+						Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "TenantID"},
+							&memdb.StringFieldIndex{Field: "AggregateType"},
+							&memdb.StringFieldIndex{Field: "AggregateID"},
+						},
+						AllowMissing: false,
+					},
+				},
+			},
+		},
 	},
 }
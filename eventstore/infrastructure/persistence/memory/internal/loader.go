@@ -9,6 +9,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
 	"github.com/hashicorp/go-memdb"
+	"iter"
 	"reflect"
 
 	"sort"
@@ -194,35 +195,198 @@ func (l loader) LoadAllAsOfTill(ctx context.Context, tenantID string, projection
 	return pEvent, err
 }
 
-func (l loader) loadAsAt(ctx context.Context, projectionTime time.Time, index string, keys ...interface{}) (eventStream []event.PersistenceEvents, err error) {
-	loadAsAtFilter := func(e event.PersistenceEvent, p, r time.Time) bool {
-		if (e.TransactionTime.Before(p) || e.TransactionTime.Equal(p)) && (e.ValidTime.Before(p) || e.ValidTime.Equal(p)) {
-			return true
+func (l loader) LoadAllOfAggregateAsOfRange(ctx context.Context, tenantID, aggregateType string, from, till time.Time) (eventStreams []event.PersistenceEvents, err error) {
+	aggregates, err := l.retrieveAggregates(ctx, db.IdxTenantIdAggregateType, tenantID, aggregateType)
+	if err != nil {
+		return nil, fmt.Errorf("retrieval of aggregates failed: %w", err)
+	}
+
+	for _, agg := range aggregates {
+		stream, err := l.loadEventStreamOfAggregateWithSnapShotTime(ctx, agg, from, till, till, loadAsOfFilter)
+		if err != nil {
+			return nil, fmt.Errorf("LoadAllOfAggregateAsOfRange failed: %w", err)
+		}
+
+		if len(stream) > 0 {
+			eventStreams = append(eventStreams, event.PersistenceEvents{
+				Events:  stream,
+				Version: int(agg.CurrentVersion)})
 		}
-		return false
 	}
 
-	return l.loadAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsAtFilter, index, keys...)
+	return eventStreams, nil
 }
 
-func (l loader) loadAsOf(ctx context.Context, projectionTime time.Time, index string, keys ...interface{}) (eventStream []event.PersistenceEvents, err error) {
-	loadAsOfFilter := func(e event.PersistenceEvent, p, r time.Time) bool {
-		if e.ValidTime.Before(p) || e.ValidTime.Equal(p) {
-			return true
+func (l loader) LoadEventsAsOfTill(ctx context.Context, projectionTime, reportTime time.Time, id shared.AggregateID) (events []event.PersistenceEvent, err error) {
+	return l.loadEventsOfAggregates(ctx, projectionTime, reportTime, loadAsOfTillFilter, db.IdxSetOfId, id.TenantID, id.AggregateType, id.AggregateID)
+}
+
+func (l loader) LoadAllEventsOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) (events []event.PersistenceEvent, err error) {
+	return l.loadEventsOfAggregates(ctx, projectionTime, reportTime, loadAsOfTillFilter, db.IdxTenantIdAggregateType, tenantID, aggregateType)
+}
+
+func (l loader) StreamAllOfAggregateAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.streamAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsAtFilter, db.IdxTenantIdAggregateType, tenantID, aggregateType)
+}
+
+func (l loader) StreamAllOfAggregateAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.streamAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsOfFilter, db.IdxTenantIdAggregateType, tenantID, aggregateType)
+}
+
+func (l loader) StreamAllOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.streamAllEventsOfAggregates(ctx, projectionTime, reportTime, loadAsOfTillFilter, db.IdxTenantIdAggregateType, tenantID, aggregateType)
+}
+
+func (l loader) StreamAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.streamAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsAtFilter, db.IdxTenantId, tenantID)
+}
+
+func (l loader) StreamAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.streamAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsOfFilter, db.IdxTenantId, tenantID)
+}
+
+func (l loader) StreamAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	return l.streamAllEventsOfAggregates(ctx, projectionTime, reportTime, loadAsOfTillFilter, db.IdxTenantId, tenantID)
+}
+
+func (l loader) ExportAggregates(ctx context.Context, tenantID string) iter.Seq2[aggregate.DTO, error] {
+	return func(yield func(aggregate.DTO, error) bool) {
+		aggregates, err := l.retrieveAggregates(ctx, db.IdxTenantId, tenantID)
+		if err != nil {
+			yield(aggregate.DTO{}, fmt.Errorf("ExportAggregates failed: %w", err))
+			return
+		}
+		for _, agg := range aggregates {
+			if !yield(agg, nil) {
+				return
+			}
 		}
-		return false
 	}
-	return l.loadAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsOfFilter, index, keys...)
 }
 
-func (l loader) loadAsOfTill(ctx context.Context, projectionTime time.Time, reportTime time.Time, index string, keys ...interface{}) (eventStream []event.PersistenceEvents, err error) {
-	loadAsOfTillFilter := func(e event.PersistenceEvent, p, r time.Time) bool {
-		if (e.ValidTime.Before(p) || e.ValidTime.Equal(p)) && (e.TransactionTime.Before(reportTime) || e.TransactionTime.Equal(reportTime)) {
-			return true
+func (l loader) ExportEvents(ctx context.Context, tenantID string) iter.Seq2[event.PersistenceEvent, error] {
+	return func(yield func(event.PersistenceEvent, error) bool) {
+		it, err := l.GetTx(ctx).Get(db.TableEvent, db.IdxTenantId, tenantID)
+		if err != nil {
+			yield(event.PersistenceEvent{}, fmt.Errorf("ExportEvents failed: %w", err))
+			return
+		}
+
+		var events []event.PersistenceEvent
+		for obj := it.Next(); obj != nil; obj = it.Next() {
+			incEvt, ok := obj.(db.AutoIncrementEvent)
+			if !ok {
+				yield(event.PersistenceEvent{}, fmt.Errorf("ExportEvents type cast failed for value %q", obj))
+				return
+			}
+			events = append(events, incEvt.Event)
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Position < events[j].Position
+		})
+
+		for _, evt := range events {
+			if !yield(evt, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (l loader) ExportSnapShots(ctx context.Context, tenantID string) iter.Seq2[event.PersistenceEvent, error] {
+	return func(yield func(event.PersistenceEvent, error) bool) {
+		aggregates, err := l.retrieveAggregates(ctx, db.IdxTenantId, tenantID)
+		if err != nil {
+			yield(event.PersistenceEvent{}, fmt.Errorf("ExportSnapShots failed: %w", err))
+			return
+		}
+
+		for _, agg := range aggregates {
+			it, err := l.GetTx(ctx).Get(db.TableSnapShot, db.IdxSetOfId, agg.TenantID, agg.AggregateType, agg.AggregateID)
+			if err != nil {
+				yield(event.PersistenceEvent{}, fmt.Errorf("ExportSnapShots failed: %w", err))
+				return
+			}
+
+			var snapShots []event.PersistenceEvent
+			for obj := it.Next(); obj != nil; obj = it.Next() {
+				snapShot, ok := obj.(event.PersistenceEvent)
+				if !ok {
+					yield(event.PersistenceEvent{}, fmt.Errorf("ExportSnapShots type cast failed for value %q", obj))
+					return
+				}
+				snapShots = append(snapShots, snapShot)
+			}
+			sort.SliceStable(snapShots, func(i, j int) bool {
+				return snapShots[i].ValidTime.Before(snapShots[j].ValidTime) || (snapShots[i].ValidTime.Equal(snapShots[j].ValidTime) && snapShots[i].Version < snapShots[j].Version)
+			})
+
+			for _, snapShot := range snapShots {
+				if !yield(snapShot, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l loader) LoadFromPosition(ctx context.Context, tenantID string, position int64, limit int) (events []event.PersistenceEvent, err error) {
+	it, err := l.GetTx(ctx).Get(db.TableEvent, db.IdxTenantId, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("LoadFromPosition failed: %w", err)
+	}
+
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		incEvt, ok := obj.(db.AutoIncrementEvent)
+		if !ok {
+			return nil, fmt.Errorf("LoadFromPosition type cast failed for value %q", obj)
+		}
+		if incEvt.Event.Position > position {
+			events = append(events, incEvt.Event)
 		}
-		return false
 	}
-	return l.loadAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsOfTillFilter, index, keys...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Position < events[j].Position
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+func loadAsAtFilter(e event.PersistenceEvent, p, r time.Time) bool {
+	if (e.TransactionTime.Before(p) || e.TransactionTime.Equal(p)) && (e.ValidTime.Before(p) || e.ValidTime.Equal(p)) {
+		return true
+	}
+	return false
+}
+
+func loadAsOfFilter(e event.PersistenceEvent, p, r time.Time) bool {
+	if e.ValidTime.Before(p) || e.ValidTime.Equal(p) {
+		return true
+	}
+	return false
+}
+
+func loadAsOfTillFilter(e event.PersistenceEvent, p, r time.Time) bool {
+	if (e.ValidTime.Before(p) || e.ValidTime.Equal(p)) && (e.TransactionTime.Before(r) || e.TransactionTime.Equal(r)) {
+		return true
+	}
+	return false
+}
+
+func (l loader) loadAsAt(ctx context.Context, projectionTime time.Time, index string, keys ...interface{}) (eventStream []event.PersistenceEvents, err error) {
+	return l.loadAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsAtFilter, index, keys...)
+}
+
+func (l loader) loadAsOf(ctx context.Context, projectionTime time.Time, index string, keys ...interface{}) (eventStream []event.PersistenceEvents, err error) {
+	return l.loadAllEventsOfAggregates(ctx, projectionTime, projectionTime, loadAsOfFilter, index, keys...)
+}
+
+func (l loader) loadAsOfTill(ctx context.Context, projectionTime time.Time, reportTime time.Time, index string, keys ...interface{}) (eventStream []event.PersistenceEvents, err error) {
+	return l.loadAllEventsOfAggregates(ctx, projectionTime, reportTime, loadAsOfTillFilter, index, keys...)
 }
 
 func (l loader) loadAllEventsOfAggregates(ctx context.Context, projectionTime, reportTime time.Time,
@@ -250,6 +414,78 @@ func (l loader) loadAllEventsOfAggregates(ctx context.Context, projectionTime, r
 	return eventStream, err
 }
 
+// streamAllEventsOfAggregates loads the event stream of one aggregate after the other while iterating.
+func (l loader) streamAllEventsOfAggregates(ctx context.Context, projectionTime, reportTime time.Time,
+	filter func(evt event.PersistenceEvent, projectionTime, reportingTime time.Time) bool, idx string, keys ...interface{}) iter.Seq2[event.PersistenceEvents, error] {
+
+	return func(yield func(event.PersistenceEvents, error) bool) {
+		it, err := l.GetTx(ctx).Get(db.TableAggregates, idx, keys...)
+		if err != nil {
+			yield(event.PersistenceEvents{}, fmt.Errorf("access on aggregate table failed %w for key %v", err, keys))
+			return
+		}
+
+		for obj := it.Next(); obj != nil; obj = it.Next() {
+			agg, ok := obj.(aggregate.DTO)
+			if !ok {
+				yield(event.PersistenceEvents{}, fmt.Errorf("type cast failed for value %q", obj))
+				return
+			}
+
+			stream, err := l.loadEventStreamOfAggregate(ctx, agg, projectionTime, reportTime, filter)
+			if err != nil {
+				yield(event.PersistenceEvents{}, fmt.Errorf("stream failed: %w", err))
+				return
+			}
+			if len(stream) == 0 {
+				continue
+			}
+
+			if !yield(event.PersistenceEvents{Events: stream, Version: int(agg.CurrentVersion)}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// loadEventsOfAggregates loads the events of the aggregates without the snapshot short-cut of loadEventStreamOfAggregate.
+func (l loader) loadEventsOfAggregates(ctx context.Context, projectionTime, reportTime time.Time,
+	filter func(evt event.PersistenceEvent, projectionTime, reportingTime time.Time) bool, idx string, keys ...interface{}) (events []event.PersistenceEvent, err error) {
+
+	aggregates, err := l.retrieveAggregates(ctx, idx, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("retrieval of aggregates failed: %w", err)
+	}
+	sort.SliceStable(aggregates, func(i, j int) bool {
+		return aggregates[i].AggregateID < aggregates[j].AggregateID
+	})
+
+	for _, agg := range aggregates {
+		it, err := l.GetTx(ctx).Get(db.TableEvent, db.IdxSetOfId, agg.TenantID, agg.AggregateType, agg.AggregateID)
+		if err != nil {
+			return nil, fmt.Errorf("access on event table failed %w for aggregate %q", err, agg.AggregateID)
+		}
+
+		var stream []event.PersistenceEvent
+		for obj := it.Next(); obj != nil; obj = it.Next() {
+			incEvt, ok := obj.(db.AutoIncrementEvent)
+			if !ok {
+				return nil, fmt.Errorf("type cast failed for value %q", obj)
+			}
+			if incEvt.Event.Class != event.DeletePatch && filter(incEvt.Event, projectionTime, reportTime) {
+				stream = append(stream, incEvt.Event)
+			}
+		}
+
+		sort.SliceStable(stream, func(i, j int) bool {
+			return stream[i].ValidTime.Before(stream[j].ValidTime) || (stream[i].ValidTime.Equal(stream[j].ValidTime) && stream[i].Version < stream[j].Version)
+		})
+		events = append(events, stream...)
+	}
+
+	return events, nil
+}
+
 func (l loader) retrieveAggregates(ctx context.Context, index string, keys ...interface{}) (aggregates []aggregate.DTO, err error) {
 	var it memdb.ResultIterator
 
@@ -278,17 +514,28 @@ func (l loader) loadEventStreamOfAggregate(
 	projectionTime, reportTime time.Time,
 	filter func(evt event.PersistenceEvent, projectionTime, reportingTime time.Time) bool) (stream []event.PersistenceEvent, err error) {
 
+	return l.loadEventStreamOfAggregateWithSnapShotTime(ctx, aggregate, projectionTime, projectionTime, reportTime, filter)
+}
+
+// loadEventStreamOfAggregateWithSnapShotTime uses the most recent snapshot with respect to snapShotTime instead of
+// projectionTime, which is used to load several projection times (snapShotTime <= projectionTime) with the same snapshot.
+func (l loader) loadEventStreamOfAggregateWithSnapShotTime(
+	ctx context.Context,
+	aggregate aggregate.DTO,
+	snapShotTime, projectionTime, reportTime time.Time,
+	filter func(evt event.PersistenceEvent, projectionTime, reportingTime time.Time) bool) (stream []event.PersistenceEvent, err error) {
+
 	//retrieve most recent snapshot with respect to loadAsOf, loadAsAt and loadAsOfTill semantic
-	snapshot, err := l.retrieveMostRecentSnapshot(ctx, aggregate, projectionTime, reportTime, filter)
+	snapshot, err := l.retrieveMostRecentSnapshot(ctx, aggregate, snapShotTime, reportTime, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	snapShotTime := time.Time{}
+	snapShotValidTime := time.Time{}
 	snapShotVersion := 0
 	if !reflect.DeepEqual(snapshot, event.PersistenceEvent{}) {
 		stream = append(stream, snapshot)
-		snapShotTime = snapshot.ValidTime
+		snapShotValidTime = snapshot.ValidTime
 		snapShotVersion = snapshot.Version
 	}
 
@@ -312,8 +559,8 @@ func (l loader) loadEventStreamOfAggregate(
 
 		evt := incEvt.Event
 		if filter(evt, projectionTime, reportTime) &&
-			(evt.ValidTime.After(snapShotTime) ||
-				(evt.ValidTime.Equal(snapShotTime) && evt.Version > snapShotVersion)) /* case create and first events at the same time*/ {
+			(evt.ValidTime.After(snapShotValidTime) ||
+				(evt.ValidTime.Equal(snapShotValidTime) && evt.Version > snapShotVersion)) /* case create and first events at the same time*/ {
 			stream = append(stream, evt)
 		}
 	}
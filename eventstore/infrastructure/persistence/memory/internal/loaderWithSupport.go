@@ -176,7 +176,10 @@ func (l loader) GetAggregatesEvents(ctx context.Context, tenantID string, page e
 	}
 
 	// filtering
-	filteredEvents := filterEvents(allEvents, page.SearchFields)
+	filteredEvents, err := filterEvents(allEvents, page.SearchFields)
+	if err != nil {
+		return nil, event.PagesDTO{}, err
+	}
 
 	// sorting
 	sortEvents(filteredEvents, page.SortFields)
@@ -186,3 +189,23 @@ func (l loader) GetAggregatesEvents(ctx context.Context, tenantID string, page e
 
 	return paginatedEvents, event.PagesDTO{Previous: first, Next: last}, nil
 }
+
+func (l loader) GetLatestTransactionTime(ctx context.Context) (time.Time, error) {
+	it, err := l.GetTx(ctx).Get(db.TableEvent, db.IdxUnique+"_prefix")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not load latest transaction time: %w", err)
+	}
+
+	var latest time.Time
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		incEvt, ok := obj.(db.AutoIncrementEvent)
+		if !ok {
+			return time.Time{}, fmt.Errorf("type cast failed for value %q", obj)
+		}
+		if incEvt.Event.TransactionTime.After(latest) {
+			latest = incEvt.Event.TransactionTime
+		}
+	}
+
+	return latest, nil
+}
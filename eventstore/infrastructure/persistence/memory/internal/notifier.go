@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
+	"sync"
+)
+
+// NewNotifier creates an in-process broadcast. All event stores using the same adapter receive the notifications of
+// each other, which is the in-memory equivalent of LISTEN/NOTIFY in postgres.
+func NewNotifier(trans trans.Port) notifier.Port {
+	return &broadcast{trans: trans, listeners: make(map[int]*listener)}
+}
+
+type listener struct {
+	ctx     context.Context
+	handler func(ctx context.Context, notification notifier.Notification)
+}
+
+type broadcast struct {
+	trans trans.Port
+
+	mu        sync.RWMutex
+	listeners map[int]*listener
+	next      int
+}
+
+func (b *broadcast) Notify(ctx context.Context, notifications ...notifier.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	tx, err := b.trans.GetTX(ctx)
+	if err != nil {
+		return fmt.Errorf("Notify failed: %w", err)
+	}
+	// deferred functions are called after the commit of the transaction (and never after an abort). The notifications
+	// are sent asynchronously, because the (single writer) transaction is still active at this point.
+	tx.(*db.MemDBTX).Defer(func() {
+		go b.send(notifications...)
+	})
+	return nil
+}
+
+func (b *broadcast) Listen(ctx context.Context, handler func(ctx context.Context, notification notifier.Notification)) error {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.listeners[id] = &listener{ctx: ctx, handler: handler}
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.listeners, id)
+	b.mu.Unlock()
+
+	return ctx.Err()
+}
+
+func (b *broadcast) send(notifications ...notifier.Notification) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, l := range b.listeners {
+		for _, notification := range notifications {
+			l.handler(l.ctx, notification)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
+	"github.com/hashicorp/go-memdb"
+	"sort"
+	"time"
+)
+
+type outboxMessage struct {
+	TenantID    string
+	EventID     string
+	Status      string
+	Event       event.PersistenceEvent
+	Attempts    int
+	NextAttempt int64
+	LastError   string
+}
+
+func NewOutbox(trans trans.Port) outbox.Port {
+	return &outboxMessages{trans: trans}
+}
+
+type outboxMessages struct {
+	trans trans.Port
+}
+
+func (o outboxMessages) GetTx(ctx context.Context) *db.MemDBTX {
+	t, err := o.trans.GetTX(ctx)
+	if err != nil {
+		return nil
+	}
+	return t.(*db.MemDBTX)
+}
+
+func (o outboxMessages) AddMessages(ctx context.Context, messages ...outbox.Message) error {
+	for _, msg := range messages {
+		if err := o.GetTx(ctx).Insert(db.TableOutbox, toOutboxMessage(msg)); err != nil {
+			return fmt.Errorf("AddMessages failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetDueMessages returns the due messages. Because memDB allows only a single writer, there are no messages locked by
+// another transaction.
+func (o outboxMessages) GetDueMessages(ctx context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	messages, err := o.getMessages(ctx, db.IdxStatus+"_prefix", string(outbox.Pending))
+	if err != nil {
+		return nil, fmt.Errorf("GetDueMessages failed: %w", err)
+	}
+
+	var result []outbox.Message
+	for _, msg := range messages {
+		if !msg.NextAttempt.After(now) {
+			result = append(result, msg)
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (o outboxMessages) GetDeadLetters(ctx context.Context, tenantID string) ([]outbox.Message, error) {
+	messages, err := o.getMessages(ctx, db.IdxStatus, string(outbox.DeadLetter), tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("GetDeadLetters failed: %w", err)
+	}
+	return messages, nil
+}
+
+func (o outboxMessages) UpdateMessages(ctx context.Context, messages ...outbox.Message) error {
+	for _, msg := range messages {
+		if err := o.GetTx(ctx).Insert(db.TableOutbox, toOutboxMessage(msg)); err != nil {
+			return fmt.Errorf("UpdateMessages failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (o outboxMessages) DelMessages(ctx context.Context, messages ...outbox.Message) error {
+	for _, msg := range messages {
+		if err := o.GetTx(ctx).Delete(db.TableOutbox, toOutboxMessage(msg)); err != nil && !errors.Is(err, memdb.ErrNotFound) {
+			return fmt.Errorf("DelMessages failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (o outboxMessages) getMessages(ctx context.Context, index string, args ...interface{}) ([]outbox.Message, error) {
+	it, err := o.GetTx(ctx).Get(db.TableOutbox, index, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []outbox.Message
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		msg, ok := obj.(outboxMessage)
+		if !ok {
+			return nil, fmt.Errorf("type cast failed %q", obj)
+		}
+		result = append(result, outbox.Message{
+			Event:       msg.Event,
+			Status:      outbox.Status(msg.Status),
+			Attempts:    msg.Attempts,
+			NextAttempt: time.Unix(0, msg.NextAttempt),
+			LastError:   msg.LastError,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Event.TransactionTime.Before(result[j].Event.TransactionTime)
+	})
+	return result, nil
+}
+
+func toOutboxMessage(msg outbox.Message) outboxMessage {
+	return outboxMessage{
+		TenantID:    msg.Event.TenantID,
+		EventID:     msg.Event.ID,
+		Status:      string(msg.Status),
+		Event:       msg.Event,
+		Attempts:    msg.Attempts,
+		NextAttempt: msg.NextAttempt.UnixNano(),
+		LastError:   msg.LastError,
+	}
+}
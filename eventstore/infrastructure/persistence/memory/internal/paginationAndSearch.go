@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"fmt"
 	"github.com/global-soft-ba/go-eventstore"
 	"regexp"
 	"sort"
@@ -9,23 +10,36 @@ import (
 	"time"
 )
 
-func filterEvents(events []event.PersistenceEvent, searchFields []event.SearchField) []event.PersistenceEvent {
+func filterEvents(events []event.PersistenceEvent, searchFields []event.SearchField) ([]event.PersistenceEvent, error) {
 	var filteredEvents []event.PersistenceEvent
 
 	for _, evt := range events {
 		match := true
 		for _, searchField := range searchFields {
+			if searchField.Name == event.SearchData {
+				continue
+			}
 			if !matchesSearchField(evt, searchField) {
 				match = false
 				break
 			}
 		}
+		for _, searchField := range searchFields {
+			if !match || searchField.Name != event.SearchData {
+				continue
+			}
+			// only the data of JSON encoded events is searchable
+			if evt.Codec != "" {
+				return nil, fmt.Errorf("search in event data failed: the data of codec %q is not searchable", evt.Codec)
+			}
+			match = matchesSearchField(evt, searchField)
+		}
 		if match {
 			filteredEvents = append(filteredEvents, evt)
 		}
 	}
 
-	return filteredEvents
+	return filteredEvents, nil
 }
 
 func matchesSearchField(evt event.PersistenceEvent, searchField event.SearchField) bool {
@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/partition"
+)
+
+// NewPartitions returns the partition port of the in memory adapter, which does not partition its tables.
+func NewPartitions() partition.Port {
+	return partitions{}
+}
+
+type partitions struct{}
+
+func (p partitions) Enabled() bool {
+	return false
+}
+
+func (p partitions) Prepare(_ context.Context, _ string, _ []string, _ []string) error {
+	return nil
+}
+
+func (p partitions) GetUnpartitioned(_ context.Context) ([]partition.UnpartitionedDTO, error) {
+	return nil, nil
+}
+
+func (p partitions) GetSizes(_ context.Context) ([]partition.DTO, error) {
+	return nil, nil
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared/kvTable"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
+	"hash/fnv"
 	"sort"
+	"strconv"
 	"time"
 )
 
@@ -20,6 +22,15 @@ type projectedEvent struct {
 	ProjectionID string
 }
 
+type quarantinedEvent struct {
+	TenantID      string
+	ProjectionID  string
+	EventID       string
+	Event         event.PersistenceEvent
+	LastError     string
+	QuarantinedAt time.Time
+}
+
 func NewProjecter(trans trans.Port) projection.Port {
 	return &projecter{
 		trans:                trans,
@@ -75,6 +86,33 @@ func (p projecter) UnLock(_ context.Context, ids ...shared.ProjectionID) (err er
 	return nil
 }
 
+func (p projecter) LockLane(_ context.Context, id shared.ProjectionID, lane int) error {
+	key := kvTable.NewKey(id.TenantID, id.ProjectionID, strconv.Itoa(lane))
+	if _, err := kvTable.GetFirst(p.lockTableProjections, key); err != nil {
+		if !kvTable.IsKeyNotFound(err) {
+			return fmt.Errorf("LockLane failed: could not query locks: %w", err)
+		}
+		if err = kvTable.Add(p.lockTableProjections, key); err != nil {
+			return fmt.Errorf("LockLane failed: could not save lock for lane %d of projection %q: %w", lane, id, err)
+		}
+		return nil
+	}
+
+	return &event.ErrorConcurrentProjectionAccess{
+		TenantID:     id.TenantID,
+		ProjectionID: id.ProjectionID,
+	}
+}
+
+func (p projecter) UnLockLane(_ context.Context, id shared.ProjectionID, lane int) error {
+	if err := kvTable.Del(p.lockTableProjections, kvTable.NewKey(id.TenantID, id.ProjectionID, strconv.Itoa(lane))); err != nil {
+		if !kvTable.IsKeyNotFound(err) {
+			return fmt.Errorf("UnLockLane failed: could not unlock lane %d of projection %q: %w", lane, id, err)
+		}
+	}
+	return nil
+}
+
 func (p projecter) Get(ctx context.Context, ids ...shared.ProjectionID) ([]projection.DTO, []projection.NotFoundError, error) {
 	var result []projection.DTO
 	var notFound []projection.NotFoundError
@@ -138,9 +176,16 @@ func (p projecter) SaveStates(ctx context.Context, projections ...projection.DTO
 			TenantID:     dto.TenantID,
 			ProjectionID: dto.ProjectionID,
 			State:        dto.State,
+			Generation:   dto.Generation,
 			UpdatedAt:    time.Now(),
 			Events:       nil,
 		}
+		// keep the execution results (see SaveExecutionResult)
+		if existing, errGet := p.getProjection(ctx, shared.NewProjectionID(dto.TenantID, dto.ProjectionID)); errGet != nil {
+			return fmt.Errorf("save projection failed: %w", errGet)
+		} else if existing != nil {
+			newDto.LastRunAt, newDto.LastError, newDto.Failures = existing.LastRunAt, existing.LastError, existing.Failures
+		}
 		err = p.GetTx(ctx).Insert(db.TableProjections, newDto)
 		if err != nil {
 			return fmt.Errorf("save projection failed: %w", err)
@@ -149,6 +194,65 @@ func (p projecter) SaveStates(ctx context.Context, projections ...projection.DTO
 	return nil
 }
 
+func (p projecter) SaveExecutionResult(txCtx context.Context, id shared.ProjectionID, result projection.ExecutionResult) error {
+	dto, err := p.getProjection(txCtx, id)
+	if err != nil {
+		return fmt.Errorf("SaveExecutionResult failed: %w", err)
+	}
+	if dto == nil {
+		return &projection.NotFoundError{ID: id}
+	}
+
+	if result.Error == "" {
+		dto.LastRunAt, dto.LastError, dto.Failures = result.ExecutedAt, "", 0
+	} else {
+		dto.LastError, dto.Failures = result.Error, dto.Failures+1
+	}
+	if err = p.GetTx(txCtx).Insert(db.TableProjections, *dto); err != nil {
+		return fmt.Errorf("SaveExecutionResult failed: %w", err)
+	}
+	return nil
+}
+
+func (p projecter) getProjection(ctx context.Context, id shared.ProjectionID) (*projection.DTO, error) {
+	obj, err := p.GetTx(ctx).First(db.TableProjections, db.IdxUnique, id.TenantID, id.ProjectionID)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+	dto, ok := obj.(projection.DTO)
+	if !ok {
+		return nil, fmt.Errorf("type cast failed for value %q", obj)
+	}
+	return &dto, nil
+}
+
+func (p projecter) GetQueueStatistics(txCtx context.Context, ids ...shared.ProjectionID) ([]projection.QueueStatistics, error) {
+	var result []projection.QueueStatistics
+	for _, id := range ids {
+		it, err := p.GetTx(txCtx).Get(db.TableProjectionsQueue, db.IdxSetOfId, id.TenantID, id.ProjectionID)
+		if err != nil {
+			return nil, fmt.Errorf("GetQueueStatistics failed: %w", err)
+		}
+
+		statistics := projection.QueueStatistics{ID: id}
+		for obj := it.Next(); obj != nil; obj = it.Next() {
+			evt := obj.(projectedEvent)
+			// future patches are not due yet (see GetSinceLastRun)
+			if !evt.ValidTime.Before(time.Now()) {
+				continue
+			}
+			statistics.QueuedEvents++
+			if statistics.OldestTransactionTime.IsZero() || evt.TransactionTime.Before(statistics.OldestTransactionTime) {
+				statistics.OldestTransactionTime = evt.TransactionTime
+			}
+		}
+		if statistics.QueuedEvents > 0 {
+			result = append(result, statistics)
+		}
+	}
+	return result, nil
+}
+
 func (p projecter) SaveEvents(ctx context.Context, projections ...projection.DTO) (err error) {
 	for _, dto := range projections {
 		for _, evt := range dto.Events {
@@ -181,8 +285,8 @@ func (p projecter) GetSinceLastRun(ctx context.Context, id shared.ProjectionID,
 
 	for obj := it.Next(); obj != nil; obj = it.Next() {
 		a := obj.(projectedEvent)
-		//no future patches
-		if a.ValidTime.Before(time.Now()) {
+		//no future patches and only the aggregates of the lane
+		if a.ValidTime.Before(time.Now()) && p.isInLane(a.AggregateID, args) {
 			pEvent := event.PersistenceEvent{
 				ID:              a.ID,
 				AggregateID:     a.AggregateID,
@@ -195,6 +299,8 @@ func (p projecter) GetSinceLastRun(ctx context.Context, id shared.ProjectionID,
 				ValidTime:       a.ValidTime,
 				FromMigration:   a.FromMigration,
 				Data:            a.Data,
+				SchemaVersion:   a.SchemaVersion,
+				Codec:           a.Codec,
 			}
 			dto.Events = append(dto.Events, pEvent)
 		}
@@ -233,6 +339,15 @@ func (p projecter) sortEventsWithValidTimeAggIdVersion(stream []event.Persistenc
 	return stream
 }
 
+func (p projecter) isInLane(aggregateID string, args projection.LoadOptions) bool {
+	if args.Lanes <= 1 {
+		return true
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(aggregateID))
+	return int(hash.Sum32()%uint32(args.Lanes)) == args.Lane
+}
+
 func (p projecter) restrictWithChunkSize(events []event.PersistenceEvent, chunkSize int) []event.PersistenceEvent {
 	if len(events) > chunkSize {
 		events = events[0:chunkSize]
@@ -262,6 +377,36 @@ func (p projecter) ResetSince(txCtx context.Context, id shared.ProjectionID, sin
 	return nil
 }
 
+func (p projecter) ForkQueue(txCtx context.Context, id, fork shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error {
+	if err := p.ResetSince(txCtx, fork, sinceTime, eventTypes...); err != nil {
+		return err
+	}
+
+	it, err := p.GetTx(txCtx).Get(db.TableProjectionsQueue, db.IdxSetOfId, id.TenantID, id.ProjectionID)
+	if err != nil {
+		return fmt.Errorf("retrieving projection events failed: %w", err)
+	}
+
+	var queued []string
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		queued = append(queued, obj.(projectedEvent).ID)
+	}
+
+	for _, eventID := range queued {
+		if err = p.deleteEventFormPorjectionQueue(txCtx, eventID, fork.TenantID, fork.ProjectionID); err != nil {
+			return fmt.Errorf("delete of projection queue failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p projecter) DeleteProjection(txCtx context.Context, id shared.ProjectionID) error {
+	if _, err := p.GetTx(txCtx).DeleteAll(db.TableProjections, db.IdxUnique, id.TenantID, id.ProjectionID); err != nil {
+		return fmt.Errorf("delete of projection %s failed:%w", id.ProjectionID, err)
+	}
+	return p.emptyQueue(txCtx, id)
+}
+
 func (p projecter) RemoveProjection(ctx context.Context, projectionID string) error {
 	iter, err := p.GetTx(ctx).Get(db.TableProjections, db.IdxTenantId)
 	if err != nil {
@@ -287,6 +432,11 @@ func (p projecter) RemoveProjection(ctx context.Context, projectionID string) er
 		if _, err = p.GetTx(ctx).DeleteAll(db.TableProjectionsQueue, db.IdxSetOfId, dto.TenantID, projectionID); err != nil {
 			return fmt.Errorf("delete of projection queue failed:%w", err)
 		}
+
+		// delete quarantined events
+		if _, err = p.GetTx(ctx).DeleteAll(db.TableQuarantine, db.IdxSetOfId, dto.TenantID, projectionID); err != nil {
+			return fmt.Errorf("delete of projection quarantine failed:%w", err)
+		}
 	}
 	return nil
 }
@@ -387,3 +537,56 @@ func (p projecter) isEventStillInQueue(txCtx context.Context, eventID string, te
 
 	return false, nil
 }
+
+func (p projecter) AddQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, events ...projection.QuarantinedEvent) error {
+	for _, evt := range events {
+		if err := p.GetTx(txCtx).Insert(db.TableQuarantine, quarantinedEvent{
+			TenantID:      id.TenantID,
+			ProjectionID:  id.ProjectionID,
+			EventID:       evt.Event.ID,
+			Event:         evt.Event,
+			LastError:     evt.LastError,
+			QuarantinedAt: evt.QuarantinedAt,
+		}); err != nil {
+			return fmt.Errorf("AddQuarantinedEvents failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p projecter) GetQuarantinedEvents(txCtx context.Context, id shared.ProjectionID) ([]projection.QuarantinedEvent, error) {
+	it, err := p.GetTx(txCtx).Get(db.TableQuarantine, db.IdxSetOfId, id.TenantID, id.ProjectionID)
+	if err != nil {
+		return nil, fmt.Errorf("GetQuarantinedEvents failed: %w", err)
+	}
+
+	var events []event.PersistenceEvent
+	quarantined := make(map[string]quarantinedEvent)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		evt, ok := obj.(quarantinedEvent)
+		if !ok {
+			return nil, fmt.Errorf("type cast failed %q", obj)
+		}
+		events = append(events, evt.Event)
+		quarantined[evt.EventID] = evt
+	}
+
+	var result []projection.QuarantinedEvent
+	for _, evt := range p.sortEventsWithValidTimeAggIdVersion(events) {
+		result = append(result, projection.QuarantinedEvent{
+			Event:         evt,
+			LastError:     quarantined[evt.ID].LastError,
+			QuarantinedAt: quarantined[evt.ID].QuarantinedAt,
+		})
+	}
+	return result, nil
+}
+
+func (p projecter) DelQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, eventIDs ...string) error {
+	for _, eventID := range eventIDs {
+		if _, err := p.GetTx(txCtx).DeleteAll(db.TableQuarantine, db.IdxUnique, id.TenantID, id.ProjectionID, eventID); err != nil {
+			return fmt.Errorf("DelQuarantinedEvents failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -53,6 +53,23 @@ func (s saver) Save(ctx context.Context, states []aggregate.DTO, events []event.
 	return nil
 }
 
+// Import does not check the snapshots against the patch intervals, because they were already checked in the
+// exporting store.
+func (s saver) Import(ctx context.Context, states []aggregate.DTO, events []event.PersistenceEvent, snapShots []event.PersistenceEvent) error {
+	if err := s.saveStreamState(ctx, states); err != nil {
+		return err
+	}
+	if err := s.saveEvents(ctx, events); err != nil {
+		return err
+	}
+	for _, snapShot := range snapShots {
+		if err := s.GetTx(ctx).Insert(db.TableSnapShot, snapShot); err != nil {
+			return fmt.Errorf("Import failed: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s saver) Get(ctx context.Context, ids ...shared.AggregateID) (aggregates []aggregate.DTO, notFound []aggregate.NotFoundError, err error) {
 	var raw interface{}
 	for _, id := range ids {
@@ -115,7 +132,17 @@ func (s saver) saveStreamState(ctx context.Context, events []aggregate.DTO) (err
 	return nil
 }
 
+// tenantPosition is the last assigned position in the global event log of a tenant
+type tenantPosition struct {
+	TenantID string
+	Position int64
+}
+
 func (s saver) saveEvents(ctx context.Context, events []event.PersistenceEvent) (err error) {
+	if events, err = s.assignPositions(ctx, events); err != nil {
+		return fmt.Errorf("SaveEvents failed: %w", err)
+	}
+
 	for _, evt := range events {
 		err = s.GetTx(ctx).InsertEventWithAutoIncrement(db.TableEvent, evt)
 		if err != nil {
@@ -125,6 +152,34 @@ func (s saver) saveEvents(ctx context.Context, events []event.PersistenceEvent)
 	return nil
 }
 
+// assignPositions assigns the next positions of the global event log to the events. Because memDB allows only a
+// single writer, the positions are assigned in commit order.
+func (s saver) assignPositions(ctx context.Context, events []event.PersistenceEvent) ([]event.PersistenceEvent, error) {
+	positions := make(map[string]int64)
+	result := make([]event.PersistenceEvent, len(events))
+	for i, evt := range events {
+		if _, exists := positions[evt.TenantID]; !exists {
+			raw, err := s.GetTx(ctx).First(db.TableTenantPositions, db.IdxUnique, evt.TenantID)
+			if err != nil {
+				return nil, fmt.Errorf("could not retrieve position of tenant %q: %w", evt.TenantID, err)
+			}
+			if raw != nil {
+				positions[evt.TenantID] = raw.(tenantPosition).Position
+			}
+		}
+		positions[evt.TenantID]++
+		evt.Position = positions[evt.TenantID]
+		result[i] = evt
+	}
+
+	for tenantID, position := range positions {
+		if err := s.GetTx(ctx).Insert(db.TableTenantPositions, tenantPosition{TenantID: tenantID, Position: position}); err != nil {
+			return nil, fmt.Errorf("could not save position of tenant %q: %w", tenantID, err)
+		}
+	}
+	return result, nil
+}
+
 func (s saver) saveSnapShots(ctx context.Context, events []event.PersistenceEvent) (err error) {
 	for _, evt := range events {
 		//snapshots become invalid if their valid time is within a patch interval (interval between valid time
@@ -249,6 +304,8 @@ func (s saver) SoftDeleteEvent(ctx context.Context, id shared.AggregateID, evt e
 
 	for obj := updateEvent.Next(); obj != nil; obj = updateEvent.Next() {
 		evtToUpdate := obj.(db.AutoIncrementEvent)
+		// the position in the event log stays the same
+		evt.Position = evtToUpdate.Event.Position
 		evtToUpdate.Event = evt
 
 		err = s.GetTx(ctx).Insert(db.TableEvent, evtToUpdate)
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
+	"github.com/hashicorp/go-memdb"
+	"sort"
+	"time"
+)
+
+type scheduledTask struct {
+	TenantID     string
+	ProjectionID string
+	ValidTime    int64
+	Version      int
+}
+
+func NewScheduler(trans trans.Port) scheduler.Port {
+	return &schedulerTasks{trans: trans}
+}
+
+type schedulerTasks struct {
+	trans trans.Port
+}
+
+func (s schedulerTasks) GetTx(ctx context.Context) *db.MemDBTX {
+	t, err := s.trans.GetTX(ctx)
+	if err != nil {
+		return nil
+	}
+	return t.(*db.MemDBTX)
+}
+
+func (s schedulerTasks) GetOpenTasks(ctx context.Context, id shared.ProjectionID) ([]scheduler.ScheduledProjectionTask, error) {
+	it, err := s.GetTx(ctx).Get(db.TableScheduledTasks, db.IdxSetOfId, id.TenantID, id.ProjectionID)
+	if err != nil {
+		return nil, fmt.Errorf("GetOpenTasks failed: %w", err)
+	}
+
+	var result []scheduler.ScheduledProjectionTask
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		task, ok := obj.(scheduledTask)
+		if !ok {
+			return nil, fmt.Errorf("GetOpenTasks type cast failed %q", obj)
+		}
+		result = append(result, scheduler.ScheduledProjectionTask{
+			Id:      shared.NewProjectionID(task.TenantID, task.ProjectionID),
+			Time:    time.Unix(0, task.ValidTime),
+			Version: task.Version,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
+
+// AddTasks stores the tasks. Several future patches with the same valid time need only one wake-up of the projection,
+// so an already existing task is simply overwritten.
+func (s schedulerTasks) AddTasks(ctx context.Context, tasks ...scheduler.ScheduledProjectionTask) error {
+	for _, task := range tasks {
+		if err := s.GetTx(ctx).Insert(db.TableScheduledTasks, toScheduledTask(task)); err != nil {
+			return fmt.Errorf("AddTasks failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s schedulerTasks) DelTasks(ctx context.Context, tasks ...scheduler.ScheduledProjectionTask) error {
+	for _, task := range tasks {
+		if err := s.GetTx(ctx).Delete(db.TableScheduledTasks, toScheduledTask(task)); err != nil && !errors.Is(err, memdb.ErrNotFound) {
+			return fmt.Errorf("DelTasks failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func toScheduledTask(task scheduler.ScheduledProjectionTask) scheduledTask {
+	return scheduledTask{
+		TenantID:     task.Id.TenantID,
+		ProjectionID: task.Id.ProjectionID,
+		ValidTime:    task.Time.UnixNano(),
+		Version:      task.Version,
+	}
+}
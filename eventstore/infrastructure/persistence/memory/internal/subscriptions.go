@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
+)
+
+type subscriptionCheckpoint struct {
+	TenantID       string
+	SubscriptionID string
+	Position       int64
+}
+
+func NewSubscriptions(trans trans.Port) subscription.Port {
+	return &subscriptions{trans: trans}
+}
+
+type subscriptions struct {
+	trans trans.Port
+}
+
+func (s subscriptions) GetTx(ctx context.Context) *db.MemDBTX {
+	t, err := s.trans.GetTX(ctx)
+	if err != nil {
+		return nil
+	}
+	return t.(*db.MemDBTX)
+}
+
+func (s subscriptions) GetCheckpoint(ctx context.Context, tenantID, subscriptionID string) (subscription.Checkpoint, bool, error) {
+	obj, err := s.GetTx(ctx).First(db.TableSubscriptions, db.IdxUnique, tenantID, subscriptionID)
+	if err != nil {
+		return subscription.Checkpoint{}, false, fmt.Errorf("GetCheckpoint failed: %w", err)
+	}
+	if obj == nil {
+		return subscription.Checkpoint{}, false, nil
+	}
+
+	checkpoint, ok := obj.(subscriptionCheckpoint)
+	if !ok {
+		return subscription.Checkpoint{}, false, fmt.Errorf("GetCheckpoint type cast failed %q", obj)
+	}
+
+	return subscription.Checkpoint{
+		TenantID:       checkpoint.TenantID,
+		SubscriptionID: checkpoint.SubscriptionID,
+		Position:       checkpoint.Position,
+	}, true, nil
+}
+
+func (s subscriptions) SaveCheckpoint(ctx context.Context, checkpoint subscription.Checkpoint) error {
+	err := s.GetTx(ctx).Insert(db.TableSubscriptions, subscriptionCheckpoint{
+		TenantID:       checkpoint.TenantID,
+		SubscriptionID: checkpoint.SubscriptionID,
+		Position:       checkpoint.Position,
+	})
+	if err != nil {
+		return fmt.Errorf("SaveCheckpoint failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/memory/internal/db"
+	"time"
+)
+
+type tenantRow struct {
+	TenantID  string
+	Status    string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// tenantData lists the tables and indexes, by which the data of a tenant is deleted during a purge.
+var tenantData = []struct {
+	table string
+	index string
+}{
+	{db.TableEvent, db.IdxTenantId},
+	{db.TableSnapShot, db.IdxSetOfId + "_prefix"},
+	{db.TableAggregates, db.IdxTenantId},
+	{db.TableArchivedStreams, db.IdxUnique + "_prefix"},
+	{db.TableProjections, db.IdxTenantId},
+	{db.TableProjectionsQueue, db.IdxSetOfId + "_prefix"},
+	{db.TableQuarantine, db.IdxSetOfId + "_prefix"},
+	{db.TableScheduledTasks, db.IdxSetOfId + "_prefix"},
+	{db.TableTenantPositions, db.IdxUnique},
+	{db.TableSubscriptions, db.IdxUnique + "_prefix"},
+	{db.TableOutbox, db.IdxUnique + "_prefix"},
+	{db.TableTenants, db.IdxUnique},
+}
+
+func NewTenants(trans trans.Port) tenant.Port {
+	return &tenants{trans: trans}
+}
+
+type tenants struct {
+	trans trans.Port
+}
+
+func (t tenants) GetTx(ctx context.Context) *db.MemDBTX {
+	tx, err := t.trans.GetTX(ctx)
+	if err != nil {
+		return nil
+	}
+	return tx.(*db.MemDBTX)
+}
+
+func (t tenants) Register(ctx context.Context, tenantID string, now time.Time) (tenant.DTO, error) {
+	existing, found, err := t.Get(ctx, tenantID)
+	if err != nil || found {
+		return existing, err
+	}
+
+	row := tenantRow{TenantID: tenantID, Status: string(event.TenantActive), CreatedAt: now.UnixNano(), UpdatedAt: now.UnixNano()}
+	if err = t.GetTx(ctx).Insert(db.TableTenants, row); err != nil {
+		return tenant.DTO{}, fmt.Errorf("Register failed: %w", err)
+	}
+	return toTenantDTO(row), nil
+}
+
+// Get needs no lock, because memDB allows only a single writer.
+func (t tenants) Get(ctx context.Context, tenantID string) (tenant.DTO, bool, error) {
+	obj, err := t.GetTx(ctx).First(db.TableTenants, db.IdxUnique, tenantID)
+	if err != nil {
+		return tenant.DTO{}, false, fmt.Errorf("Get failed: %w", err)
+	}
+	if obj == nil {
+		return tenant.DTO{}, false, nil
+	}
+
+	row, ok := obj.(tenantRow)
+	if !ok {
+		return tenant.DTO{}, false, fmt.Errorf("Get type cast failed %q", obj)
+	}
+	return toTenantDTO(row), true, nil
+}
+
+func (t tenants) GetAll(ctx context.Context) ([]tenant.DTO, error) {
+	it, err := t.GetTx(ctx).Get(db.TableTenants, db.IdxUnique)
+	if err != nil {
+		return nil, fmt.Errorf("GetAll failed: %w", err)
+	}
+
+	var result []tenant.DTO
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		row, ok := obj.(tenantRow)
+		if !ok {
+			return nil, fmt.Errorf("GetAll type cast failed %q", obj)
+		}
+		result = append(result, toTenantDTO(row))
+	}
+	return result, nil
+}
+
+func (t tenants) SetStatus(ctx context.Context, tenantID string, status event.TenantStatus, now time.Time) error {
+	existing, found, err := t.Get(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("SetStatus failed: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("SetStatus failed: tenant %q not found", tenantID)
+	}
+
+	row := tenantRow{TenantID: tenantID, Status: string(status), CreatedAt: existing.CreatedAt.UnixNano(), UpdatedAt: now.UnixNano()}
+	if err = t.GetTx(ctx).Insert(db.TableTenants, row); err != nil {
+		return fmt.Errorf("SetStatus failed: %w", err)
+	}
+	return nil
+}
+
+func (t tenants) Purge(ctx context.Context, tenantID string) error {
+	for _, data := range tenantData {
+		if _, err := t.GetTx(ctx).DeleteAll(data.table, data.index, tenantID); err != nil {
+			return fmt.Errorf("Purge failed for table %q: %w", data.table, err)
+		}
+	}
+	return nil
+}
+
+func toTenantDTO(row tenantRow) tenant.DTO {
+	return tenant.DTO{
+		TenantID:  row.TenantID,
+		Status:    event.TenantStatus(row.Status),
+		CreatedAt: time.Unix(0, row.CreatedAt),
+		UpdatedAt: time.Unix(0, row.UpdatedAt),
+	}
+}
@@ -6,10 +6,16 @@ import (
 	"errors"
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/partition"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tests"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
@@ -26,6 +32,18 @@ const dataBaseSchema = "eventstore"
 
 type Options struct {
 	AutoMigrate bool
+	Partitions  PartitionOptions
+}
+
+// PartitionOptions enable the automatic partition management of the event store tables (see
+// event.PartitionManagement). The partitions are created on first use, i.e. before the first save of an aggregate type
+// or projection (and tenant), and the existing rows are moved out of the default partitions.
+type PartitionOptions struct {
+	// PerType partitions the aggregates, events and snapshots by aggregate type and the queued projection events by
+	// projection.
+	PerType bool
+	// PerTenant sub-partitions the partitions per type by tenant. It requires PerType.
+	PerTenant bool
 }
 
 //go:embed internal/migration/*
@@ -39,6 +57,15 @@ func NewTxPassThrough(db *pgxpool.Pool, opt Options) (persistence.Port, error) {
 	trans := tests.NewTxPassThroughTransactor(internal.CtxStorageKey)
 	aggRepro := internal.NewAggregates(dataBaseSchema, sq.Dollar, trans)
 	projRepro := internal.NewProjecter(dataBaseSchema, sq.Dollar, trans)
+	schedRepro := internal.NewScheduler(dataBaseSchema, sq.Dollar, trans)
+	notifyRepro := internal.NewNotifier(dataBaseSchema, sq.Dollar, trans, db)
+	subRepro := internal.NewSubscriptions(dataBaseSchema, sq.Dollar, trans)
+	outboxRepro := internal.NewOutbox(dataBaseSchema, sq.Dollar, trans)
+	tenantRepro := internal.NewTenants(dataBaseSchema, sq.Dollar, trans)
+	partitionRepro, err := internal.NewPartitions(dataBaseSchema, sq.Dollar, trans, opt.Partitions.PerType, opt.Partitions.PerTenant)
+	if err != nil {
+		return nil, err
+	}
 
 	if opt.AutoMigrate {
 		if err := applyMigration(context.Background(), dataBaseSchema, db); err != nil {
@@ -46,7 +73,7 @@ func NewTxPassThrough(db *pgxpool.Pool, opt Options) (persistence.Port, error) {
 		}
 	}
 
-	return Adapter{aggregates: aggRepro, projections: projRepro, transactor: trans}, nil
+	return Adapter{aggregates: aggRepro, projections: projRepro, scheduler: schedRepro, notifier: notifyRepro, subscriptions: subRepro, outbox: outboxRepro, tenants: tenantRepro, partitions: partitionRepro, transactor: trans}, nil
 }
 
 // NewTXStored creates a new PostgreSQL event store supporting single transaction operations.
@@ -57,6 +84,15 @@ func NewTXStored(txCtx context.Context, db *pgxpool.Pool, opt Options) (persiste
 	trans := tests.NewTxStoreTransactor(txCtx, internal.CtxStorageKey)
 	aggRepro := internal.NewAggregates(dataBaseSchema, sq.Dollar, trans)
 	projRepro := internal.NewProjecter(dataBaseSchema, sq.Dollar, trans)
+	schedRepro := internal.NewScheduler(dataBaseSchema, sq.Dollar, trans)
+	notifyRepro := internal.NewNotifier(dataBaseSchema, sq.Dollar, trans, db)
+	subRepro := internal.NewSubscriptions(dataBaseSchema, sq.Dollar, trans)
+	outboxRepro := internal.NewOutbox(dataBaseSchema, sq.Dollar, trans)
+	tenantRepro := internal.NewTenants(dataBaseSchema, sq.Dollar, trans)
+	partitionRepro, err := internal.NewPartitions(dataBaseSchema, sq.Dollar, trans, opt.Partitions.PerType, opt.Partitions.PerTenant)
+	if err != nil {
+		return nil, err
+	}
 
 	if opt.AutoMigrate {
 		if err := applyMigration(context.Background(), dataBaseSchema, db); err != nil {
@@ -64,13 +100,22 @@ func NewTXStored(txCtx context.Context, db *pgxpool.Pool, opt Options) (persiste
 		}
 	}
 
-	return Adapter{aggregates: aggRepro, projections: projRepro, transactor: trans}, nil
+	return Adapter{aggregates: aggRepro, projections: projRepro, scheduler: schedRepro, notifier: notifyRepro, subscriptions: subRepro, outbox: outboxRepro, tenants: tenantRepro, partitions: partitionRepro, transactor: trans}, nil
 }
 
 func New(db *pgxpool.Pool, opt Options) (persistence.Port, error) {
 	trans := internal.NewTransactor(db)
 	aggRepro := internal.NewAggregates(dataBaseSchema, sq.Dollar, trans)
 	projRepro := internal.NewProjecter(dataBaseSchema, sq.Dollar, trans)
+	schedRepro := internal.NewScheduler(dataBaseSchema, sq.Dollar, trans)
+	notifyRepro := internal.NewNotifier(dataBaseSchema, sq.Dollar, trans, db)
+	subRepro := internal.NewSubscriptions(dataBaseSchema, sq.Dollar, trans)
+	outboxRepro := internal.NewOutbox(dataBaseSchema, sq.Dollar, trans)
+	tenantRepro := internal.NewTenants(dataBaseSchema, sq.Dollar, trans)
+	partitionRepro, err := internal.NewPartitions(dataBaseSchema, sq.Dollar, trans, opt.Partitions.PerType, opt.Partitions.PerTenant)
+	if err != nil {
+		return nil, err
+	}
 
 	if opt.AutoMigrate {
 		if err := applyMigration(context.Background(), dataBaseSchema, db); err != nil {
@@ -78,7 +123,7 @@ func New(db *pgxpool.Pool, opt Options) (persistence.Port, error) {
 		}
 	}
 
-	return Adapter{aggregates: aggRepro, projections: projRepro, transactor: trans}, nil
+	return Adapter{aggregates: aggRepro, projections: projRepro, scheduler: schedRepro, notifier: notifyRepro, subscriptions: subRepro, outbox: outboxRepro, tenants: tenantRepro, partitions: partitionRepro, transactor: trans}, nil
 }
 
 func applyMigration(ctx context.Context, dataBaseSchema string, db *pgxpool.Pool) (err error) {
@@ -121,9 +166,15 @@ func replacePasswordInError(err error, password string) error {
 }
 
 type Adapter struct {
-	aggregates  aggregate.Port
-	projections projection.Port
-	transactor  transactor.Port
+	aggregates    aggregate.Port
+	projections   projection.Port
+	scheduler     scheduler.Port
+	notifier      notifier.Port
+	subscriptions subscription.Port
+	outbox        outbox.Port
+	tenants       tenant.Port
+	partitions    partition.Port
+	transactor    transactor.Port
 }
 
 func (a Adapter) ProjectionPort() projection.Port {
@@ -134,6 +185,30 @@ func (a Adapter) AggregatePort() aggregate.Port {
 	return a.aggregates
 }
 
+func (a Adapter) SchedulerPort() scheduler.Port {
+	return a.scheduler
+}
+
+func (a Adapter) NotifierPort() notifier.Port {
+	return a.notifier
+}
+
+func (a Adapter) SubscriptionPort() subscription.Port {
+	return a.subscriptions
+}
+
+func (a Adapter) OutboxPort() outbox.Port {
+	return a.outbox
+}
+
+func (a Adapter) TenantPort() tenant.Port {
+	return a.tenants
+}
+
+func (a Adapter) PartitionPort() partition.Port {
+	return a.partitions
+}
+
 func (a Adapter) Transactor() transactor.Port {
 	return a.transactor
 }
@@ -17,12 +17,21 @@ func NewSlowAdapter(db *pgxpool.Pool) (persistence.Port, error) {
 	trans := internal.NewTransactor(db)
 	aggRepro := internal.NewSlowAggregatePort(dataBaseSchema, sq.Dollar, trans)
 	projRepro := internal.NewProjecter(dataBaseSchema, sq.Dollar, trans)
+	schedRepro := internal.NewScheduler(dataBaseSchema, sq.Dollar, trans)
+	notifyRepro := internal.NewNotifier(dataBaseSchema, sq.Dollar, trans, db)
+	subRepro := internal.NewSubscriptions(dataBaseSchema, sq.Dollar, trans)
+	outboxRepro := internal.NewOutbox(dataBaseSchema, sq.Dollar, trans)
+	tenantRepro := internal.NewTenants(dataBaseSchema, sq.Dollar, trans)
+	partitionRepro, err := internal.NewPartitions(dataBaseSchema, sq.Dollar, trans, false, false)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := applyMigration(context.Background(), dataBaseSchema, db); err != nil {
+	if err = applyMigration(context.Background(), dataBaseSchema, db); err != nil {
 		return nil, err
 	}
 
-	return Adapter{aggregates: aggRepro, projections: projRepro, transactor: trans}, nil
+	return Adapter{aggregates: aggRepro, projections: projRepro, scheduler: schedRepro, notifier: notifyRepro, subscriptions: subRepro, outbox: outboxRepro, tenants: tenantRepro, partitions: partitionRepro, transactor: trans}, nil
 }
 
 func NewTransactor(dbPool *pgxpool.Pool) transactor.Port {
@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	copy2 "github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/copy"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"sort"
+	"time"
+)
+
+func (s saver) GetArchivableStreams(ctx context.Context, closedBefore time.Time, limit int) ([]shared.AggregateID, error) {
+	stmt, args, err := s.sql.GetArchivableStreams(ctx, closedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tables.AggregateRow
+	if err = pgxscan.Select(ctx, tx, &rows, stmt, args...); err != nil {
+		return nil, fmt.Errorf("GetArchivableStreams failed: %w", err)
+	}
+
+	result := make([]shared.AggregateID, len(rows))
+	for i, row := range rows {
+		result[i] = shared.NewAggregateID(row.TenantID, row.AggregateType, row.AggregateID)
+	}
+	return result, nil
+}
+
+func (s saver) GetArchivedStreams(ctx context.Context, tenantID string) ([]shared.AggregateID, error) {
+	stmt, args, err := s.sql.GetArchivedStreams(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tables.AggregateArchivedRow
+	if err = pgxscan.Select(ctx, tx, &rows, stmt, args...); err != nil {
+		return nil, fmt.Errorf("GetArchivedStreams failed: %w", err)
+	}
+
+	result := make([]shared.AggregateID, len(rows))
+	for i, row := range rows {
+		result[i] = shared.NewAggregateID(row.TenantID, row.AggregateType, row.AggregateID)
+	}
+	return result, nil
+}
+
+func (s saver) GetArchiveTime(ctx context.Context, id shared.AggregateID) (time.Time, error) {
+	stmt, args, err := s.sql.GetArchiveTime(ctx, id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var archiveTimes []int64
+	if err = pgxscan.Select(ctx, tx, &archiveTimes, stmt, args...); err != nil {
+		return time.Time{}, fmt.Errorf("GetArchiveTime failed: %w", err)
+	}
+	if len(archiveTimes) == 0 {
+		return time.Time{}, nil
+	}
+	return mapper.MapToTimeStampTZ(archiveTimes[0]), nil
+}
+
+func (s saver) ArchiveStream(ctx context.Context, id shared.AggregateID, archived time.Time) (events []event.PersistenceEvent, snapShots []event.PersistenceEvent, err error) {
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmt, args, err := s.sql.DeleteAggregateEvents(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var eventRows []tables.AggregateEventRow
+	if err = pgxscan.Select(ctx, tx, &eventRows, stmt, args...); err != nil {
+		return nil, nil, fmt.Errorf("ArchiveStream failed: could not delete events: %w", err)
+	}
+	sort.SliceStable(eventRows, func(i, j int) bool {
+		return eventRows[i].Position < eventRows[j].Position
+	})
+
+	stmt, args, err = s.sql.DeleteAggregateSnapShots(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var snapShotRows []tables.AggregateEventRow
+	if err = pgxscan.Select(ctx, tx, &snapShotRows, stmt, args...); err != nil {
+		return nil, nil, fmt.Errorf("ArchiveStream failed: could not delete snapshots: %w", err)
+	}
+	sort.SliceStable(snapShotRows, func(i, j int) bool {
+		return snapShotRows[i].ValidTime < snapShotRows[j].ValidTime
+	})
+
+	stmt, args, err = s.sql.InsertArchivedStream(ctx, id, archived)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err = tx.Exec(ctx, stmt, args...); err != nil {
+		return nil, nil, fmt.Errorf("ArchiveStream failed: could not mark stream: %w", err)
+	}
+
+	return mapper.ToPersistenceEventArray(eventRows), mapper.ToPersistenceEventArray(snapShotRows), nil
+}
+
+// RestoreStream writes the rows always with COPY and keeps the positions of the events, so that the global event log
+// of the tenant looks the same as before the archiving.
+func (s saver) RestoreStream(ctx context.Context, id shared.AggregateID, events []event.PersistenceEvent, snapShots []event.PersistenceEvent) error {
+	if err := s.copyFrom(ctx, tables.AggregateEventTable.Name, tables.AggregateEventTable.AllColumnsWithPosition(),
+		copy2.NewAggregateEventWithPositionIterator(mapper.ToAggregateEventRows(events...)), len(events)); err != nil {
+		return fmt.Errorf("RestoreStream failed: could not restore events: %w", err)
+	}
+	if err := s.copyFrom(ctx, tables.AggregateSnapsShotTable.Name, tables.AggregateSnapsShotTable.AllColumns(),
+		copy2.NewAggregateEventIterator(mapper.ToAggregateEventRows(snapShots...)), len(snapShots)); err != nil {
+		return fmt.Errorf("RestoreStream failed: could not restore snapshots: %w", err)
+	}
+
+	stmt, args, err := s.sql.DeleteArchivedStream(ctx, id)
+	if err != nil {
+		return err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("RestoreStream failed: could not unmark stream: %w", err)
+	}
+	return nil
+}
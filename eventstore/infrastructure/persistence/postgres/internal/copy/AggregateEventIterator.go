@@ -11,10 +11,19 @@ func NewAggregateEventIterator(rows []tables.AggregateEventRow) pgx.CopyFromSour
 	}
 }
 
+// NewAggregateEventWithPositionIterator must be used for the aggregates events table, which has an additional position column
+func NewAggregateEventWithPositionIterator(rows []tables.AggregateEventRow) pgx.CopyFromSource {
+	return &AggregateEventIterator{
+		rows:         rows,
+		withPosition: true,
+	}
+}
+
 // AggregateEventIterator implements pgx.CopyFromSource.
 type AggregateEventIterator struct {
 	rows                 []tables.AggregateEventRow
 	skippedFirstNextCall bool
+	withPosition         bool
 }
 
 func (r *AggregateEventIterator) Next() bool {
@@ -31,7 +40,7 @@ func (r *AggregateEventIterator) Next() bool {
 
 func (r *AggregateEventIterator) Values() ([]interface{}, error) {
 	//order of columns must be same as in create table
-	return []interface{}{
+	values := []interface{}{
 		r.rows[0].ID,
 		r.rows[0].TenantID,
 		r.rows[0].AggregateType,
@@ -43,7 +52,13 @@ func (r *AggregateEventIterator) Values() ([]interface{}, error) {
 		r.rows[0].ValidTime,
 		r.rows[0].FromMigration,
 		r.rows[0].Data,
-	}, nil
+		r.rows[0].SchemaVersion,
+		r.rows[0].Codec,
+	}
+	if r.withPosition {
+		values = append(values, r.rows[0].Position)
+	}
+	return values, nil
 }
 
 func (r *AggregateEventIterator) Err() error {
@@ -0,0 +1,48 @@
+package copy
+
+import (
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"github.com/jackc/pgx/v5"
+)
+
+func NewAggregateIterator(rows []tables.AggregateRow) pgx.CopyFromSource {
+	return &AggregateIterator{
+		rows: rows,
+	}
+}
+
+// AggregateIterator implements pgx.CopyFromSource.
+type AggregateIterator struct {
+	rows                 []tables.AggregateRow
+	skippedFirstNextCall bool
+}
+
+func (r *AggregateIterator) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r *AggregateIterator) Values() ([]interface{}, error) {
+	//order of columns must be same as in function AllColumns() of the aggregate table
+	return []interface{}{
+		r.rows[0].TenantID,
+		r.rows[0].AggregateType,
+		r.rows[0].AggregateID,
+		r.rows[0].CurrentVersion,
+		r.rows[0].LastTransactionTime,
+		r.rows[0].LatestValidTime,
+		r.rows[0].CreateTime,
+		r.rows[0].CloseTime,
+	}, nil
+}
+
+func (r *AggregateIterator) Err() error {
+	return nil
+}
@@ -44,6 +44,8 @@ func (r *ProjectionEventIterator) Values() ([]interface{}, error) {
 		r.rows[0].ValidTime,
 		r.rows[0].FromMigration,
 		r.rows[0].Data,
+		r.rows[0].SchemaVersion,
+		r.rows[0].Codec,
 	}, nil
 }
 
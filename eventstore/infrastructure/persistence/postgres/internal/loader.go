@@ -14,9 +14,16 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"github.com/google/uuid"
+	"iter"
+	"slices"
+	"strings"
 	"time"
 )
 
+// streamFetchSize is the number of rows fetched at once from the cursor of a streaming load
+const streamFetchSize = 1000
+
 func newLoader(dataBaseSchema string, placeholder sq.PlaceholderFormat, trans trans.Port) loader {
 	querier := queries.NewSqlLoader(dataBaseSchema, placeholder)
 	return loader{sql: querier, trans: trans}
@@ -225,6 +232,118 @@ func (s loader) LoadAllAsOfTill(ctx context.Context, tenantID string, projection
 	return result, err
 }
 
+func (s loader) LoadAllOfAggregateAsOfRange(ctx context.Context, tenantID, aggregateType string, from, till time.Time) (eventStreams []event.PersistenceEvents, err error) {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID:      tenantID,
+		tables.AggregateEventTable.AggregateType: aggregateType,
+	}
+
+	stmt, args, err := s.sql.LoadAsOfRange(ctx, selector, from, till)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.load(ctx, stmt, args)
+}
+
+func (s loader) LoadEventsAsOfTill(ctx context.Context, projectionTime, reportTime time.Time, key shared.AggregateID) ([]event.PersistenceEvent, error) {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID:      key.TenantID,
+		tables.AggregateEventTable.AggregateID:   key.AggregateID,
+		tables.AggregateEventTable.AggregateType: key.AggregateType,
+	}
+
+	return s.loadEventsAsOfTill(ctx, projectionTime, reportTime, selector)
+}
+
+func (s loader) LoadAllEventsOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) ([]event.PersistenceEvent, error) {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID:      tenantID,
+		tables.AggregateEventTable.AggregateType: aggregateType,
+	}
+
+	return s.loadEventsAsOfTill(ctx, projectionTime, reportTime, selector)
+}
+
+func (s loader) loadEventsAsOfTill(ctx context.Context, projectionTime, reportTime time.Time, selector map[string]interface{}) ([]event.PersistenceEvent, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "loadEventsAsOfTill (loader)", map[string]interface{}{"projectionTime": projectionTime, "reportTime": reportTime})
+	defer endSpan()
+
+	stmt, args, err := s.sql.LoadEventsAsOfTill(ctx, selector, projectionTime, reportTime)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tables.AggregateEventRow
+	err = pgxscan.Select(ctx, tx, &rows, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load events: %w", err)
+	}
+
+	return mapper.ToPersistenceEventArray(rows), nil
+}
+
+func (s loader) StreamAllOfAggregateAsAt(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID:      tenantID,
+		tables.AggregateEventTable.AggregateType: aggregateType,
+	}
+
+	stmt, args, err := s.sql.LoadAsAt(ctx, selector, projectionTime)
+	return s.stream(ctx, stmt, args, err)
+}
+
+func (s loader) StreamAllOfAggregateAsOf(ctx context.Context, tenantID, aggregateType string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID:      tenantID,
+		tables.AggregateEventTable.AggregateType: aggregateType,
+	}
+
+	stmt, args, err := s.sql.LoadAsOf(ctx, selector, projectionTime)
+	return s.stream(ctx, stmt, args, err)
+}
+
+func (s loader) StreamAllOfAggregateAsOfTill(ctx context.Context, tenantID, aggregateType string, projectionTime, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID:      tenantID,
+		tables.AggregateEventTable.AggregateType: aggregateType,
+	}
+
+	stmt, args, err := s.sql.LoadAsOfTill(ctx, selector, projectionTime, reportTime)
+	return s.stream(ctx, stmt, args, err)
+}
+
+func (s loader) StreamAllAsAt(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID: tenantID,
+	}
+
+	stmt, args, err := s.sql.LoadAsAt(ctx, selector, projectionTime)
+	return s.stream(ctx, stmt, args, err)
+}
+
+func (s loader) StreamAllAsOf(ctx context.Context, tenantID string, projectionTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID: tenantID,
+	}
+
+	stmt, args, err := s.sql.LoadAsOf(ctx, selector, projectionTime)
+	return s.stream(ctx, stmt, args, err)
+}
+
+func (s loader) StreamAllAsOfTill(ctx context.Context, tenantID string, projectionTime time.Time, reportTime time.Time) iter.Seq2[event.PersistenceEvents, error] {
+	selector := map[string]interface{}{
+		tables.AggregateEventTable.TenantID: tenantID,
+	}
+
+	stmt, args, err := s.sql.LoadAsOfTill(ctx, selector, projectionTime, reportTime)
+	return s.stream(ctx, stmt, args, err)
+}
+
 func (s loader) GetAggregateState(ctx context.Context, tenantID, aggregateType, aggregateID string) (event.AggregateState, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "GetAggregateState (loader)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "aggregateID": aggregateID})
 	defer endSpan()
@@ -320,6 +439,50 @@ func (s loader) GetPatchFreePeriodsForInterval(ctx context.Context, tenantID, ag
 	}(rows)), err
 }
 
+func (s loader) LoadFromPosition(ctx context.Context, tenantID string, position int64, limit int) ([]event.PersistenceEvent, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "LoadFromPosition (loader)", map[string]interface{}{"tenantID": tenantID, "position": position, "limit": limit})
+	defer endSpan()
+
+	stmt, args, err := s.sql.LoadFromPosition(ctx, tenantID, position, limit)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tables.AggregateEventRow
+	err = pgxscan.Select(ctx, tx, &rows, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load events: %w", err)
+	}
+
+	return mapper.ToPersistenceEventArray(rows), nil
+}
+
+func (s loader) GetLatestTransactionTime(ctx context.Context) (time.Time, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetLatestTransactionTime (loader)", nil)
+	defer endSpan()
+
+	stmt, args, err := s.sql.GetLatestTransactionTime(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var latest int64
+	err = pgxscan.Get(ctx, tx, &latest, stmt, args...)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not load latest transaction time: %w", err)
+	}
+
+	return mapper.MapToTimeStampTZ(latest), nil
+}
+
 func (s loader) loadAsAt(ctx context.Context, projectionTime time.Time, selector map[string]interface{}) (streams []event.PersistenceEvents, err error) {
 	stmt, args, err := s.sql.LoadAsAt(ctx, selector, projectionTime)
 	if err != nil {
@@ -365,6 +528,161 @@ func (s loader) load(ctx context.Context, statement string, args []interface{})
 	return mapper.ToPersistenceEvents(pEvents)
 }
 
+// stream reads the result of a load statement with a server-side cursor in batches of streamFetchSize rows. The rows
+// are ordered by aggregate, so an event stream is complete as soon as a row of the next aggregate (or no row) follows.
+func (s loader) stream(ctx context.Context, statement string, args []interface{}, errStmt error) iter.Seq2[event.PersistenceEvents, error] {
+	return func(yield func(event.PersistenceEvents, error) bool) {
+		ctx, endSpan := metrics.StartSpan(ctx, "stream (loader)", nil)
+		defer endSpan()
+
+		if errStmt != nil {
+			yield(event.PersistenceEvents{}, errStmt)
+			return
+		}
+
+		db, err := s.GetTx(ctx)
+		if err != nil {
+			yield(event.PersistenceEvents{}, err)
+			return
+		}
+
+		// the cursor lives in its own (sub-)transaction, which is rolled back at the end, since it is read only
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			yield(event.PersistenceEvents{}, fmt.Errorf("could not start transaction of cursor: %w", err))
+			return
+		}
+		defer tx.Rollback(ctx)
+
+		cursor := fmt.Sprintf("load_%s", strings.ReplaceAll(uuid.NewString(), "-", ""))
+		if _, err = tx.Exec(ctx, s.sql.DeclareCursor(ctx, cursor, statement), args...); err != nil {
+			yield(event.PersistenceEvents{}, fmt.Errorf("could not declare cursor: %w", err))
+			return
+		}
+
+		var pending []tables.AggregatePersistentEventLoadRow
+		for {
+			var rows []tables.AggregatePersistentEventLoadRow
+			if err = pgxscan.Select(ctx, tx, &rows, s.sql.FetchCursor(ctx, cursor, streamFetchSize)); err != nil {
+				yield(event.PersistenceEvents{}, fmt.Errorf("could not fetch from cursor: %w", err))
+				return
+			}
+			pending = append(pending, rows...)
+
+			// the last aggregate of the batch can continue in the next batch
+			complete := len(pending)
+			if len(rows) == streamFetchSize {
+				complete = startOfLastAggregate(pending)
+			}
+
+			streams, err := mapper.ToPersistenceEvents(pending[:complete])
+			if err != nil {
+				yield(event.PersistenceEvents{}, err)
+				return
+			}
+			for _, stream := range streams {
+				if !yield(stream, nil) {
+					return
+				}
+			}
+
+			if len(rows) < streamFetchSize {
+				return
+			}
+			pending = append([]tables.AggregatePersistentEventLoadRow(nil), pending[complete:]...)
+		}
+	}
+}
+
+func startOfLastAggregate(rows []tables.AggregatePersistentEventLoadRow) int {
+	last := rows[len(rows)-1]
+	i := len(rows) - 1
+	for i > 0 && rows[i-1].TenantID == last.TenantID && rows[i-1].AggregateType == last.AggregateType && rows[i-1].AggregateID == last.AggregateID {
+		i--
+	}
+	return i
+}
+
+func (s loader) ExportAggregates(ctx context.Context, tenantID string) iter.Seq2[aggregate.DTO, error] {
+	stmt, args, err := s.sql.ExportAggregates(ctx, tenantID)
+	return mapRows(fetch[tables.AggregateRow](ctx, s, stmt, args, err), func(row tables.AggregateRow) aggregate.DTO {
+		return mapper.ToAggregate(row)[0]
+	})
+}
+
+func (s loader) ExportEvents(ctx context.Context, tenantID string) iter.Seq2[event.PersistenceEvent, error] {
+	stmt, args, err := s.sql.ExportEvents(ctx, tenantID)
+	return mapRows(fetch[tables.AggregateEventRow](ctx, s, stmt, args, err), mapper.ToPersistenceEvent)
+}
+
+func (s loader) ExportSnapShots(ctx context.Context, tenantID string) iter.Seq2[event.PersistenceEvent, error] {
+	stmt, args, err := s.sql.ExportSnapShots(ctx, tenantID)
+	return mapRows(fetch[tables.AggregateEventRow](ctx, s, stmt, args, err), mapper.ToPersistenceEvent)
+}
+
+// fetch reads the rows of a statement with a server-side cursor in batches of streamFetchSize rows (see stream).
+func fetch[R any](ctx context.Context, s loader, statement string, args []interface{}, errStmt error) iter.Seq2[[]R, error] {
+	return func(yield func([]R, error) bool) {
+		ctx, endSpan := metrics.StartSpan(ctx, "fetch (loader)", nil)
+		defer endSpan()
+
+		if errStmt != nil {
+			yield(nil, errStmt)
+			return
+		}
+
+		db, err := s.GetTx(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			yield(nil, fmt.Errorf("could not start transaction of cursor: %w", err))
+			return
+		}
+		defer tx.Rollback(ctx)
+
+		cursor := fmt.Sprintf("fetch_%s", strings.ReplaceAll(uuid.NewString(), "-", ""))
+		if _, err = tx.Exec(ctx, s.sql.DeclareCursor(ctx, cursor, statement), args...); err != nil {
+			yield(nil, fmt.Errorf("could not declare cursor: %w", err))
+			return
+		}
+
+		for {
+			var rows []R
+			if err = pgxscan.Select(ctx, tx, &rows, s.sql.FetchCursor(ctx, cursor, streamFetchSize)); err != nil {
+				yield(nil, fmt.Errorf("could not fetch from cursor: %w", err))
+				return
+			}
+			if len(rows) > 0 && !yield(rows, nil) {
+				return
+			}
+			if len(rows) < streamFetchSize {
+				return
+			}
+		}
+	}
+}
+
+func mapRows[R, T any](batches iter.Seq2[[]R, error], toDTO func(R) T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for rows, err := range batches {
+			if err != nil {
+				var empty T
+				yield(empty, err)
+				return
+			}
+			for _, row := range rows {
+				if !yield(toDTO(row), nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
 func (s loader) GetAggregatesEvents(ctx context.Context, tenantID string, page event.PageDTO) ([]event.PersistenceEvent, event.PagesDTO, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "GetAggregatesEvents (loader)", map[string]interface{}{"tenantID": tenantID})
 	defer endSpan()
@@ -378,12 +696,18 @@ func (s loader) GetAggregatesEvents(ctx context.Context, tenantID string, page e
 		return nil, event.PagesDTO{}, fmt.Errorf("could not build page cursor: %w", err)
 	}
 
-	stmt, args, err := s.sql.GetAggregatesEvents(ctx, tenantID, paginator, page.SearchFields)
+	tx, err := s.GetTx(ctx)
 	if err != nil {
 		return nil, event.PagesDTO{}, err
 	}
 
-	tx, err := s.GetTx(ctx)
+	if slices.ContainsFunc(page.SearchFields, func(field event.SearchField) bool { return field.Name == event.SearchData }) {
+		if err = s.rejectUnsearchableData(ctx, tx, tenantID, page.SearchFields); err != nil {
+			return nil, event.PagesDTO{}, err
+		}
+	}
+
+	stmt, args, err := s.sql.GetAggregatesEvents(ctx, tenantID, paginator, page.SearchFields)
 	if err != nil {
 		return nil, event.PagesDTO{}, err
 	}
@@ -404,3 +728,20 @@ func (s loader) GetAggregatesEvents(ctx context.Context, tenantID string, page e
 
 	return mapper.ToPersistenceEventArray(rows), event.PagesDTO{Previous: firstCursor, Next: lastCursor}, nil
 }
+
+// rejectUnsearchableData returns an error, if the data search would skip events, because their data is not JSON
+// encoded (see event.RegisterSerializer).
+func (s loader) rejectUnsearchableData(ctx context.Context, tx dbtx.DBTX, tenantID string, searchFields []event.SearchField) error {
+	stmt, args, err := s.sql.GetUnsearchableDataCodec(ctx, tenantID, searchFields)
+	if err != nil {
+		return err
+	}
+	var codecs []string
+	if err = pgxscan.Select(ctx, tx, &codecs, stmt, args...); err != nil {
+		return fmt.Errorf("could not load codecs: %w", err)
+	}
+	if len(codecs) > 0 {
+		return fmt.Errorf("search in event data failed: the data of codec %q is not searchable", codecs[0])
+	}
+	return nil
+}
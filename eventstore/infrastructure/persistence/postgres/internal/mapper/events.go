@@ -30,6 +30,9 @@ func ToAggregateEventRow(row event.PersistenceEvent) tables.AggregateEventRow {
 		ValidTime:       MapToNanoseconds(row.ValidTime),
 		FromMigration:   row.FromMigration,
 		Data:            row.Data,
+		SchemaVersion:   int64(row.SchemaVersion),
+		Codec:           row.Codec,
+		Position:        row.Position,
 	}
 }
 
@@ -54,6 +57,9 @@ func ToPersistenceEvent(row tables.AggregateEventRow) event.PersistenceEvent {
 		ValidTime:       MapToTimeStampTZ(row.ValidTime),
 		FromMigration:   row.FromMigration,
 		Data:            row.Data,
+		SchemaVersion:   int(row.SchemaVersion),
+		Codec:           row.Codec,
+		Position:        row.Position,
 	}
 }
 
@@ -106,7 +112,18 @@ func AggregateEventRowToArrayOfValues(rows ...tables.AggregateEventRow) []interf
 			row.ValidTime,
 			row.FromMigration,
 			row.Data,
+			row.SchemaVersion,
+			row.Codec,
 		)
 	}
 	return result
 }
+
+func AggregateEventRowWithPositionToArrayOfValues(rows ...tables.AggregateEventRow) []interface{} {
+	//order of columns must be same as in function AllColumnsWithPosition()
+	var result []interface{}
+	for _, row := range rows {
+		result = append(append(result, AggregateEventRowToArrayOfValues(row)...), row.Position)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"encoding/json"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+)
+
+type notificationPayload struct {
+	Origin         string `json:"origin"`
+	TenantID       string `json:"tenantID"`
+	ProjectionID   string `json:"projectionID"`
+	EarliestHPatch int64  `json:"earliestHPatch"`
+}
+
+func ToNotificationPayloads(notifications ...notifier.Notification) ([]string, error) {
+	var result []string
+	for _, notification := range notifications {
+		payload, err := json.Marshal(notificationPayload{
+			Origin:         notification.Origin,
+			TenantID:       notification.Id.TenantID,
+			ProjectionID:   notification.Id.ProjectionID,
+			EarliestHPatch: MapToNanoseconds(notification.EarliestHPatch),
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, string(payload))
+	}
+	return result, nil
+}
+
+func ToNotification(payload string) (notifier.Notification, error) {
+	var p notificationPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return notifier.Notification{}, err
+	}
+	return notifier.Notification{
+		Origin:         p.Origin,
+		Id:             shared.NewProjectionID(p.TenantID, p.ProjectionID),
+		EarliestHPatch: MapToTimeStampTZ(p.EarliestHPatch),
+	}, nil
+}
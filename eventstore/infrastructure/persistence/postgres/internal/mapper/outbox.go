@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func ToOutboxMessageRow(msg outbox.Message) (tables.OutboxMessageRow, error) {
+	evt, err := json.Marshal(msg.Event)
+	if err != nil {
+		return tables.OutboxMessageRow{}, fmt.Errorf("could not marshal event %q of outbox: %w", msg.Event.ID, err)
+	}
+
+	return tables.OutboxMessageRow{
+		TenantID:        msg.Event.TenantID,
+		EventID:         msg.Event.ID,
+		TransactionTime: MapToNanoseconds(msg.Event.TransactionTime),
+		Status:          string(msg.Status),
+		Event:           evt,
+		Attempts:        int64(msg.Attempts),
+		NextAttempt:     MapToNanoseconds(msg.NextAttempt),
+		LastError:       msg.LastError,
+	}, nil
+}
+
+func ToOutboxMessages(rows ...tables.OutboxMessageRow) ([]outbox.Message, error) {
+	var result []outbox.Message
+	for _, row := range rows {
+		var evt event.PersistenceEvent
+		if err := json.Unmarshal(row.Event, &evt); err != nil {
+			return nil, fmt.Errorf("could not unmarshal event %q of outbox: %w", row.EventID, err)
+		}
+
+		result = append(result, outbox.Message{
+			Event:       evt,
+			Status:      outbox.Status(row.Status),
+			Attempts:    int(row.Attempts),
+			NextAttempt: MapToTimeStampTZ(row.NextAttempt),
+			LastError:   row.LastError,
+		})
+	}
+
+	return result, nil
+}
+
+func OutboxMessageRowToArrayOfValues(rows ...tables.OutboxMessageRow) []interface{} {
+	//order of columns must be same as in function AllColumns()
+	var result []interface{}
+	for _, row := range rows {
+		result = append(result,
+			row.TenantID,
+			row.EventID,
+			row.TransactionTime,
+			row.Status,
+			row.Event,
+			row.Attempts,
+			row.NextAttempt,
+			row.LastError,
+		)
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/partition"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"strings"
+)
+
+// ToPartitionDTOs maps the leaf partitions of the table. The partitions of the first level are partitions of a key
+// (or the default partition of the table), the partitions of the second level are tenant sub-partitions of a key.
+func ToPartitionDTOs(table string, rows ...tables.PartitionRow) []partition.DTO {
+	result := make([]partition.DTO, len(rows))
+	for i, row := range rows {
+		value, isDefault := fromListBound(row.Bound)
+		dto := partition.DTO{
+			Table:     table,
+			Partition: row.Partition,
+			Default:   isDefault,
+			Rows:      row.Rows,
+			Bytes:     row.Bytes,
+		}
+		if row.Level > 1 {
+			dto.Key, _ = fromListBound(row.ParentBound)
+			dto.TenantID = value
+		} else {
+			dto.Key = value
+		}
+		result[i] = dto
+	}
+	return result
+}
+
+// fromListBound returns the value of a list partition bound, i.e. FOR VALUES IN ('value'), or true for DEFAULT.
+func fromListBound(bound string) (value string, isDefault bool) {
+	if bound == "DEFAULT" {
+		return "", true
+	}
+	value = strings.TrimSuffix(strings.TrimPrefix(bound, "FOR VALUES IN ('"), "')")
+	return strings.ReplaceAll(value, "''", "'"), false
+}
+
+func ToUnpartitionedDTOs(forProjections bool, rows ...tables.UnpartitionedRow) []partition.UnpartitionedDTO {
+	result := make([]partition.UnpartitionedDTO, len(rows))
+	for i, row := range rows {
+		result[i] = partition.UnpartitionedDTO{TenantID: row.TenantID}
+		if forProjections {
+			result[i].ProjectionID = row.Key
+		} else {
+			result[i].AggregateType = row.Key
+		}
+	}
+	return result
+}
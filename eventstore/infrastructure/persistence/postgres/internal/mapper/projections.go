@@ -3,6 +3,7 @@ package mapper
 import (
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
 )
 
@@ -13,8 +14,25 @@ func ToProjections(rows ...tables.ProjectionsRow) []projection.DTO {
 			TenantID:     row.TenantID,
 			ProjectionID: row.ProjectionID,
 			State:        row.State,
+			Generation:   row.Generation,
 			UpdatedAt:    row.UpdatedAt,
 			Events:       nil,
+			LastRunAt:    MapToTimeStampTZ(row.LastRunAt),
+			LastError:    row.LastError,
+			Failures:     row.Failures,
+		})
+	}
+
+	return result
+}
+
+func ToQueueStatistics(rows ...tables.ProjectionsQueueStatisticsRow) []projection.QueueStatistics {
+	var result []projection.QueueStatistics
+	for _, row := range rows {
+		result = append(result, projection.QueueStatistics{
+			ID:                    shared.NewProjectionID(row.TenantID, row.ProjectionID),
+			QueuedEvents:          row.QueuedEvents,
+			OldestTransactionTime: MapToTimeStampTZ(row.OldestTransactionTime),
 		})
 	}
 
@@ -51,6 +69,7 @@ func ToProjection(sinceTimeStamp int64, rows ...tables.ProjectionsEventsLoadRow)
 		result.ProjectionID = row.ProjectionID
 		result.TenantID = row.TenantID
 		result.State = row.State
+		result.Generation = row.Generation
 		result.UpdatedAt = MapToTimeStampTZ(latestExecutedValidTime)
 		result.Events = append(result.Events, ToPersistenceEvent(row.AggregateEventRow))
 	}
@@ -75,6 +94,8 @@ func ProjectionEventRowsToArrayOfValues(rows ...tables.ProjectionsEventRow) []in
 			row.ValidTime,
 			row.FromMigration,
 			row.Data,
+			row.SchemaVersion,
+			row.Codec,
 		)
 	}
 	return result
@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/projection"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func ToProjectionsQuarantineRow(id shared.ProjectionID, evt projection.QuarantinedEvent) (tables.ProjectionsQuarantineRow, error) {
+	data, err := json.Marshal(evt.Event)
+	if err != nil {
+		return tables.ProjectionsQuarantineRow{}, fmt.Errorf("could not marshal quarantined event %q: %w", evt.Event.ID, err)
+	}
+
+	return tables.ProjectionsQuarantineRow{
+		TenantID:      id.TenantID,
+		ProjectionID:  id.ProjectionID,
+		EventID:       evt.Event.ID,
+		ValidTime:     MapToNanoseconds(evt.Event.ValidTime),
+		Event:         data,
+		LastError:     evt.LastError,
+		QuarantinedAt: MapToNanoseconds(evt.QuarantinedAt),
+	}, nil
+}
+
+func ToQuarantinedEvents(rows ...tables.ProjectionsQuarantineRow) ([]projection.QuarantinedEvent, error) {
+	var result []projection.QuarantinedEvent
+	for _, row := range rows {
+		var evt event.PersistenceEvent
+		if err := json.Unmarshal(row.Event, &evt); err != nil {
+			return nil, fmt.Errorf("could not unmarshal quarantined event %q: %w", row.EventID, err)
+		}
+
+		result = append(result, projection.QuarantinedEvent{
+			Event:         evt,
+			LastError:     row.LastError,
+			QuarantinedAt: MapToTimeStampTZ(row.QuarantinedAt),
+		})
+	}
+
+	return result, nil
+}
+
+func ProjectionsQuarantineRowToArrayOfValues(rows ...tables.ProjectionsQuarantineRow) []interface{} {
+	//order of columns must be same as in function AllColumns()
+	var result []interface{}
+	for _, row := range rows {
+		result = append(result,
+			row.TenantID,
+			row.ProjectionID,
+			row.EventID,
+			row.ValidTime,
+			row.Event,
+			row.LastError,
+			row.QuarantinedAt,
+		)
+	}
+	return result
+}
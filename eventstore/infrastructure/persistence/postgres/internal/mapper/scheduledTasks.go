@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func ToScheduledProjectionTaskRow(task scheduler.ScheduledProjectionTask) tables.ScheduledProjectionTaskRow {
+	return tables.ScheduledProjectionTaskRow{
+		TenantID:     task.Id.TenantID,
+		ProjectionID: task.Id.ProjectionID,
+		ValidTime:    MapToNanoseconds(task.Time),
+		Version:      int64(task.Version),
+	}
+}
+
+func ToScheduledProjectionTasks(rows ...tables.ScheduledProjectionTaskRow) []scheduler.ScheduledProjectionTask {
+	var result []scheduler.ScheduledProjectionTask
+	for _, row := range rows {
+		result = append(result, scheduler.ScheduledProjectionTask{
+			Id:      shared.NewProjectionID(row.TenantID, row.ProjectionID),
+			Time:    MapToTimeStampTZ(row.ValidTime),
+			Version: int(row.Version),
+		})
+	}
+
+	return result
+}
+
+func ScheduledProjectionTaskRowToArrayOfValues(rows ...tables.ScheduledProjectionTaskRow) []interface{} {
+	//order of columns must be same as in function AllColumns()
+	var result []interface{}
+	for _, row := range rows {
+		result = append(result,
+			row.TenantID,
+			row.ProjectionID,
+			row.ValidTime,
+			row.Version,
+		)
+	}
+	return result
+}
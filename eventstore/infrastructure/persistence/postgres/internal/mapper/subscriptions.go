@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func ToSubscriptionCheckpointRow(checkpoint subscription.Checkpoint) tables.SubscriptionCheckpointRow {
+	return tables.SubscriptionCheckpointRow{
+		TenantID:       checkpoint.TenantID,
+		SubscriptionID: checkpoint.SubscriptionID,
+		Position:       checkpoint.Position,
+	}
+}
+
+func ToSubscriptionCheckpoint(row tables.SubscriptionCheckpointRow) subscription.Checkpoint {
+	return subscription.Checkpoint{
+		TenantID:       row.TenantID,
+		SubscriptionID: row.SubscriptionID,
+		Position:       row.Position,
+	}
+}
+
+func SubscriptionCheckpointRowToArrayOfValues(rows ...tables.SubscriptionCheckpointRow) []interface{} {
+	//order of columns must be same as in function AllColumns()
+	var result []interface{}
+	for _, row := range rows {
+		result = append(result,
+			row.TenantID,
+			row.SubscriptionID,
+			row.Position,
+		)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func ToTenantRow(dto tenant.DTO) tables.TenantRow {
+	return tables.TenantRow{
+		TenantID:  dto.TenantID,
+		Status:    string(dto.Status),
+		CreatedAt: MapToNanoseconds(dto.CreatedAt),
+		UpdatedAt: MapToNanoseconds(dto.UpdatedAt),
+	}
+}
+
+func ToTenantDTOs(rows ...tables.TenantRow) []tenant.DTO {
+	var result []tenant.DTO
+	for _, row := range rows {
+		result = append(result, tenant.DTO{
+			TenantID:  row.TenantID,
+			Status:    event.TenantStatus(row.Status),
+			CreatedAt: MapToTimeStampTZ(row.CreatedAt),
+			UpdatedAt: MapToTimeStampTZ(row.UpdatedAt),
+		})
+	}
+	return result
+}
+
+func TenantRowToArrayOfValues(rows ...tables.TenantRow) []interface{} {
+	//order of columns must be same as in function AllColumns()
+	var result []interface{}
+	for _, row := range rows {
+		result = append(result,
+			row.TenantID,
+			row.Status,
+			row.CreatedAt,
+			row.UpdatedAt,
+		)
+	}
+	return result
+}
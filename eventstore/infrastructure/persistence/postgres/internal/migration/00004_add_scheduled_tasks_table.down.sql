@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS eventstore.scheduled_projection_tasks;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+/* Table for scheduled projection tasks (e.g. future patches, which must be projected when their valid time is reached) */
+CREATE TABLE IF NOT EXISTS eventstore.scheduled_projection_tasks
+(
+    tenant_id     text   not null,
+    projection_id text   not null,
+    valid_time    bigint not null,
+    version       bigint not null,
+
+    PRIMARY KEY (tenant_id, projection_id, valid_time)
+);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS eventstore.tenant_positions;
+
+DROP INDEX IF EXISTS eventstore.position_idx;
+
+ALTER TABLE eventstore.aggregates_events DROP COLUMN IF EXISTS position;
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+/* Gap-free position of an event in the global event log of a tenant (assigned in commit order) */
+ALTER TABLE eventstore.aggregates_events ADD COLUMN IF NOT EXISTS position bigint NOT NULL DEFAULT 0;
+
+/* Existing events are ordered by their transaction time (best effort, because this was the order before) */
+UPDATE eventstore.aggregates_events e
+SET position = p.position
+FROM (SELECT id,
+             aggregate_type,
+             row_number() OVER (PARTITION BY tenant_id ORDER BY transaction_time, aggregate_type, aggregate_id, version) AS position
+      FROM eventstore.aggregates_events) p
+WHERE e.id = p.id
+  AND e.aggregate_type = p.aggregate_type;
+
+CREATE INDEX IF NOT EXISTS position_idx on eventstore.aggregates_events (tenant_id, position);
+
+/* Last assigned position per tenant. The row lock during the update serializes the saves of a tenant until commit. */
+CREATE TABLE IF NOT EXISTS eventstore.tenant_positions
+(
+    tenant_id text   not null,
+    position  bigint not null,
+
+    PRIMARY KEY (tenant_id)
+);
+
+INSERT INTO eventstore.tenant_positions (tenant_id, position)
+SELECT tenant_id, max(position)
+FROM eventstore.aggregates_events
+GROUP BY tenant_id;
+
+COMMIT;
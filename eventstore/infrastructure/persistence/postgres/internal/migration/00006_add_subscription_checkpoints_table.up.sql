@@ -0,0 +1,13 @@
+BEGIN;
+
+/* Table for the checkpoints (last handled position of the global event log) of catch-up subscriptions */
+CREATE TABLE IF NOT EXISTS eventstore.subscription_checkpoints
+(
+    tenant_id       text   not null,
+    subscription_id text   not null,
+    position        bigint not null,
+
+    PRIMARY KEY (tenant_id, subscription_id)
+);
+
+COMMIT;
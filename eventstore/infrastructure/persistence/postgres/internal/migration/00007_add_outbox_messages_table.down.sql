@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS eventstore.outbox_messages;
+
+COMMIT;
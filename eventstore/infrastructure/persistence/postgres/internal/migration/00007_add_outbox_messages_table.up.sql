@@ -0,0 +1,20 @@
+BEGIN;
+
+/* Table for the outbox, i.e. saved events which must be delivered to a publisher (e.g. a message broker) */
+CREATE TABLE IF NOT EXISTS eventstore.outbox_messages
+(
+    tenant_id        text   not null,
+    event_id         text   not null,
+    transaction_time bigint not null,
+    status           text   not null,
+    event            json   not null,
+    attempts         bigint not null,
+    next_attempt     bigint not null,
+    last_error       text   not null,
+
+    PRIMARY KEY (tenant_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS outbox_messages_due_idx on eventstore.outbox_messages (status, next_attempt);
+
+COMMIT;
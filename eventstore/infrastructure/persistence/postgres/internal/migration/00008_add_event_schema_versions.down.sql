@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE eventstore.aggregates_events DROP COLUMN IF EXISTS schema_version;
+ALTER TABLE eventstore.aggregates_snapshots DROP COLUMN IF EXISTS schema_version;
+ALTER TABLE eventstore.projections_events DROP COLUMN IF EXISTS schema_version;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+/* Schema version of the event data (see event.RegisterUpcaster). Existing events keep the schema version 0, which stands for the initial schema version. */
+ALTER TABLE eventstore.aggregates_events ADD COLUMN IF NOT EXISTS schema_version bigint NOT NULL DEFAULT 0;
+ALTER TABLE eventstore.aggregates_snapshots ADD COLUMN IF NOT EXISTS schema_version bigint NOT NULL DEFAULT 0;
+ALTER TABLE eventstore.projections_events ADD COLUMN IF NOT EXISTS schema_version bigint NOT NULL DEFAULT 0;
+
+COMMIT;
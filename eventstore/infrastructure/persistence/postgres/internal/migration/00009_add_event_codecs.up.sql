@@ -0,0 +1,8 @@
+BEGIN;
+
+/* Codec of the event data (see event.RegisterSerializer). Existing events keep the empty codec, which stands for JSON. */
+ALTER TABLE eventstore.aggregates_events ADD COLUMN IF NOT EXISTS codec text NOT NULL DEFAULT '';
+ALTER TABLE eventstore.aggregates_snapshots ADD COLUMN IF NOT EXISTS codec text NOT NULL DEFAULT '';
+ALTER TABLE eventstore.projections_events ADD COLUMN IF NOT EXISTS codec text NOT NULL DEFAULT '';
+
+COMMIT;
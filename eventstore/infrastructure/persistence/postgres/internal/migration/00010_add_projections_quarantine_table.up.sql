@@ -0,0 +1,17 @@
+BEGIN;
+
+/* Table for the events, which could not be projected after all retries of the projection */
+CREATE TABLE IF NOT EXISTS eventstore.projections_quarantine
+(
+    tenant_id      text   not null,
+    projection_id  text   not null,
+    event_id       text   not null,
+    valid_time     bigint not null,
+    event          json   not null,
+    last_error     text   not null,
+    quarantined_at bigint not null,
+
+    PRIMARY KEY (tenant_id, projection_id, event_id)
+);
+
+COMMIT;
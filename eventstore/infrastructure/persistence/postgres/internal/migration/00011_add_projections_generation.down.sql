@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE eventstore.projections DROP COLUMN IF EXISTS generation;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+/* Generation of the read model of the projection, which is increased with each blue/green rebuild (see event.ProjectionGenerations). */
+ALTER TABLE eventstore.projections ADD COLUMN IF NOT EXISTS generation bigint NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE eventstore.projections DROP COLUMN IF EXISTS last_run_at;
+ALTER TABLE eventstore.projections DROP COLUMN IF EXISTS last_error;
+ALTER TABLE eventstore.projections DROP COLUMN IF EXISTS failures;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+/* Latest execution results of the projection (see event.ProjectionHealth). The last run is stored in nanoseconds like the event times. */
+ALTER TABLE eventstore.projections ADD COLUMN IF NOT EXISTS last_run_at bigint NOT NULL DEFAULT 0;
+ALTER TABLE eventstore.projections ADD COLUMN IF NOT EXISTS last_error text NOT NULL DEFAULT '';
+ALTER TABLE eventstore.projections ADD COLUMN IF NOT EXISTS failures bigint NOT NULL DEFAULT 0;
+
+COMMIT;
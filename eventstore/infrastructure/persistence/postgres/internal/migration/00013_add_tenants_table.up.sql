@@ -0,0 +1,26 @@
+BEGIN;
+
+/* Table for the tenants and their lifecycle status (see event.TenantManagement). The times are stored in nanoseconds like the event times. */
+CREATE TABLE IF NOT EXISTS eventstore.tenants
+(
+    tenant_id  text   not null,
+    status     text   not null,
+    created_at bigint not null,
+    updated_at bigint not null,
+
+    PRIMARY KEY (tenant_id)
+);
+
+/* Existing tenants are registered as active */
+INSERT INTO eventstore.tenants (tenant_id, status, created_at, updated_at)
+SELECT tenant_id, 'active', min(create_time), max(last_transaction_time)
+FROM eventstore.aggregates
+GROUP BY tenant_id
+ON CONFLICT (tenant_id) DO NOTHING;
+
+INSERT INTO eventstore.tenants (tenant_id, status, created_at, updated_at)
+SELECT DISTINCT tenant_id, 'active', 0, 0
+FROM eventstore.projections
+ON CONFLICT (tenant_id) DO NOTHING;
+
+COMMIT;
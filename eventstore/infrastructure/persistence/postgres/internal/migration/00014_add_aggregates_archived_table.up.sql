@@ -0,0 +1,14 @@
+BEGIN;
+
+/* Table for the streams, whose events and snapshots were moved to the cold storage (see event.StreamArchiveManagement). The archive time is stored in nanoseconds like the event times. */
+CREATE TABLE IF NOT EXISTS eventstore.aggregates_archived
+(
+    tenant_id      text   not null,
+    aggregate_type text   not null,
+    aggregate_id   text   not null,
+    archive_time   bigint not null,
+
+    PRIMARY KEY (tenant_id, aggregate_type, aggregate_id)
+);
+
+COMMIT;
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/notifier"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/dbtx"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const reconnectListenerAfter = time.Second
+
+// NewNotifier uses LISTEN/NOTIFY of postgres. Each listener takes its own connection out of the pool, so that no
+// connection with a subscribed channel is ever handed out again.
+func NewNotifier(dataBaseSchema string, placeholder sq.PlaceholderFormat, trans trans.Port, db *pgxpool.Pool) notifier.Port {
+	querier := queries.NewSqlNotifier(dataBaseSchema, placeholder)
+	return &notifications{sql: querier, trans: trans, db: db}
+}
+
+type notifications struct {
+	sql   queries.SqlNotifier
+	trans trans.Port
+	db    *pgxpool.Pool
+}
+
+func (n notifications) GetTx(ctx context.Context) (dbtx.DBTX, error) {
+	t, err := n.trans.GetTX(ctx)
+	return t.(dbtx.DBTX), err
+}
+
+func (n notifications) Notify(ctx context.Context, notifications ...notifier.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	payloads, err := mapper.ToNotificationPayloads(notifications...)
+	if err != nil {
+		return err
+	}
+	stmt, args, err := n.sql.Notify(ctx, payloads...)
+	if err != nil {
+		return err
+	}
+	tx, err := n.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
+
+// Listen re-establishes the connection after errors. Notifications sent in between are lost; the affected projections
+// catch up with their next execution (e.g. ExecuteAllProjections or the next notification).
+func (n notifications) Listen(ctx context.Context, handler func(ctx context.Context, notification notifier.Notification)) error {
+	for {
+		err := n.listen(ctx, handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.Warn("listening on channel %q failed (reconnect in %v): %v", n.sql.Channel(), reconnectListenerAfter, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectListenerAfter):
+		}
+	}
+}
+
+func (n notifications) listen(ctx context.Context, handler func(ctx context.Context, notification notifier.Notification)) error {
+	pooled, err := n.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.Hijack()
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			logger.Error(fmt.Errorf("closing listener connection of channel %q failed: %w", n.sql.Channel(), err))
+		}
+	}()
+
+	if _, err = conn.Exec(ctx, n.sql.Listen(ctx)); err != nil {
+		return err
+	}
+
+	for {
+		received, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		notification, err := mapper.ToNotification(received.Payload)
+		if err != nil {
+			logger.Error(fmt.Errorf("invalid notification %q on channel %q: %w", received.Payload, received.Channel, err))
+			continue
+		}
+		handler(ctx, notification)
+	}
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/dbtx"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"time"
+)
+
+func NewOutbox(dataBaseSchema string, placeholder sq.PlaceholderFormat, trans trans.Port) outbox.Port {
+	querier := queries.NewSqlOutbox(dataBaseSchema, placeholder)
+	return &outboxMessages{sql: querier, trans: trans}
+}
+
+type outboxMessages struct {
+	sql   queries.SqlOutbox
+	trans trans.Port
+}
+
+func (o outboxMessages) GetTx(ctx context.Context) (dbtx.DBTX, error) {
+	t, err := o.trans.GetTX(ctx)
+	return t.(dbtx.DBTX), err
+}
+
+func (o outboxMessages) AddMessages(ctx context.Context, messages ...outbox.Message) error {
+	return o.upsert(ctx, messages...)
+}
+
+func (o outboxMessages) GetDueMessages(ctx context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	stmt, args, err := o.sql.GetDueMessages(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return o.selectMessages(ctx, stmt, args...)
+}
+
+func (o outboxMessages) GetDeadLetters(ctx context.Context, tenantID string) ([]outbox.Message, error) {
+	stmt, args, err := o.sql.GetDeadLetters(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return o.selectMessages(ctx, stmt, args...)
+}
+
+func (o outboxMessages) UpdateMessages(ctx context.Context, messages ...outbox.Message) error {
+	return o.upsert(ctx, messages...)
+}
+
+func (o outboxMessages) DelMessages(ctx context.Context, messages ...outbox.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	stmt, args, err := o.sql.DelMessages(ctx, messages...)
+	if err != nil {
+		return err
+	}
+	tx, err := o.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (o outboxMessages) upsert(ctx context.Context, messages ...outbox.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	stmt, args, err := o.sql.UpsertMessages(ctx, messages...)
+	if err != nil {
+		return err
+	}
+	tx, err := o.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (o outboxMessages) selectMessages(ctx context.Context, stmt string, args ...interface{}) ([]outbox.Message, error) {
+	var rows []tables.OutboxMessageRow
+	tx, err := o.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = pgxscan.Select(ctx, tx, &rows, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToOutboxMessages(rows...)
+}
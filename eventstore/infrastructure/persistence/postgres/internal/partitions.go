@@ -0,0 +1,330 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/partition"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/dbtx"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"slices"
+	"time"
+)
+
+// partitionLockTimeout limits the waiting for the exclusive locks of the partition DDL (see queries.SetLockTimeout).
+const partitionLockTimeout = 5 * time.Second
+
+// moveBatchSize limits the rows, which are moved out of the default partition by a single statement.
+const moveBatchSize = 10000
+
+// NewPartitions creates the partition port. Without partitions per type, nothing is partitioned, and the tenant
+// sub-partitions need the partitions per type.
+func NewPartitions(dataBaseSchema string, placeholder sq.PlaceholderFormat, trans trans.Port, perType, perTenant bool) (partition.Port, error) {
+	querier, err := queries.NewSqlPartitions(dataBaseSchema, placeholder)
+	if err != nil {
+		return nil, err
+	}
+	return &partitions{sql: querier, trans: trans, perType: perType, perTenant: perType && perTenant}, nil
+}
+
+type partitions struct {
+	sql       queries.SqlPartitions
+	trans     trans.Port
+	perType   bool
+	perTenant bool
+}
+
+func (p partitions) GetTx(ctx context.Context) (dbtx.DBTX, error) {
+	tx, err := p.trans.GetTX(ctx)
+	return tx.(dbtx.DBTX), err
+}
+
+func (p partitions) Enabled() bool {
+	return p.perType
+}
+
+func (p partitions) Prepare(ctx context.Context, tenantID string, aggregateTypes []string, projectionIDs []string) error {
+	if !p.perType {
+		return nil
+	}
+	for _, table := range tables.PartitionedAggregateTables {
+		for _, aggregateType := range aggregateTypes {
+			if err := p.prepare(ctx, table, aggregateType, tenantID); err != nil {
+				return fmt.Errorf("Prepare failed for aggregate type %q: %w", aggregateType, err)
+			}
+		}
+	}
+	for _, table := range tables.PartitionedProjectionTables {
+		for _, projectionID := range projectionIDs {
+			if err := p.prepare(ctx, table, projectionID, tenantID); err != nil {
+				return fmt.Errorf("Prepare failed for projection %q: %w", projectionID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (p partitions) prepare(ctx context.Context, table tables.PartitionedTableSchema, value, tenantID string) error {
+	subKey := ""
+	if p.perTenant {
+		subKey = table.SubKey
+		if err := p.ensureSubKeyInPrimaryKey(ctx, table); err != nil {
+			return err
+		}
+	}
+	if err := p.ensureListPartition(ctx, table.Name, table.Key, value, subKey); err != nil {
+		return err
+	}
+	if !p.perTenant {
+		return nil
+	}
+
+	// partitions, which were created before the tenant sub-partitions were enabled, are not sub-partitioned
+	partitionName := p.sql.DDL.GetListPartitionName(table.Name, value)
+	stmt, args, err := p.sql.DDL.IsPartitioned(partitionName)
+	if err != nil {
+		return err
+	}
+	if partitioned, err := p.getBool(ctx, stmt, args...); err != nil || !partitioned {
+		return err
+	}
+	return p.ensureListPartition(ctx, partitionName, table.SubKey, tenantID, "")
+}
+
+// ensureSubKeyInPrimaryKey adds the sub key to the primary key of the table, because postgres requires the partition
+// keys of all levels in the primary key of a partitioned table. Hence, the primary key is only replaced, once the
+// sub-partitions are enabled. This locks the whole table and rebuilds its primary key indexes.
+func (p partitions) ensureSubKeyInPrimaryKey(ctx context.Context, table tables.PartitionedTableSchema) error {
+	if len(table.PrimaryKey) == 0 {
+		return nil
+	}
+	hasSubKey, err := p.hasPrimaryKeyColumn(ctx, table.Name, table.SubKey)
+	if err != nil || hasSubKey {
+		return err
+	}
+
+	if err = p.exec(ctx, p.sql.SetLockTimeout(partitionLockTimeout)); err != nil {
+		return err
+	}
+	if err = p.exec(ctx, p.sql.Lock(table.Name)); err != nil {
+		return err
+	}
+	// another instance could have replaced the primary key in the meantime
+	if hasSubKey, err = p.hasPrimaryKeyColumn(ctx, table.Name, table.SubKey); err != nil || hasSubKey {
+		return err
+	}
+	return p.exec(ctx, p.sql.ReplacePrimaryKey(table.Name, append(slices.Clone(table.PrimaryKey), table.SubKey)...))
+}
+
+func (p partitions) hasPrimaryKeyColumn(ctx context.Context, table, column string) (bool, error) {
+	stmt, args, err := p.sql.HasPrimaryKeyColumn(table, column)
+	if err != nil {
+		return false, err
+	}
+	return p.getBool(ctx, stmt, args...)
+}
+
+// ensureListPartition creates and attaches the partition of the value, if it is not attached yet. The rows of the value
+// are moved out of the default partition beforehand, because a partition cannot be attached as long as the default
+// partition contains rows of its value. The rows are moved in batches, and only the writes of the meantime are moved
+// while the writes into the default partition are blocked.
+func (p partitions) ensureListPartition(ctx context.Context, parentTable, key, value, subKey string) error {
+	attached, err := p.isAttached(ctx, parentTable, value)
+	if err != nil || attached {
+		return err
+	}
+
+	if err = p.exec(ctx, p.sql.SetLockTimeout(partitionLockTimeout)); err != nil {
+		return err
+	}
+	if err = p.exec(ctx, p.sql.Lock(p.sql.DDL.GetListPartitionName(parentTable, value))); err != nil {
+		return err
+	}
+	// another instance could have created the partition in the meantime
+	if attached, err = p.isAttached(ctx, parentTable, value); err != nil || attached {
+		return err
+	}
+
+	stmt, err := p.sql.DDL.CreateListPartitionTable(parentTable, value, subKey)
+	if err != nil {
+		return err
+	}
+	if err = p.exec(ctx, stmt); err != nil {
+		return err
+	}
+	if subKey != "" {
+		if stmt, err = p.sql.DDL.CreateDefaultPartition(p.sql.DDL.GetListPartitionName(parentTable, value)); err != nil {
+			return err
+		}
+		if err = p.exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	defaultPartition, found, err := p.getDefaultPartition(ctx, parentTable)
+	if err != nil {
+		return err
+	}
+	if found {
+		if err = p.moveRowsOfDefaultPartition(ctx, parentTable, defaultPartition, key, value); err != nil {
+			return err
+		}
+		if err = p.exec(ctx, p.sql.LockForWrites(defaultPartition)); err != nil {
+			return err
+		}
+		if err = p.moveRowsOfDefaultPartition(ctx, parentTable, defaultPartition, key, value); err != nil {
+			return err
+		}
+	}
+
+	if stmt, err = p.sql.DDL.AttachListPartition(parentTable, value); err != nil {
+		return err
+	}
+	return p.exec(ctx, stmt)
+}
+
+func (p partitions) moveRowsOfDefaultPartition(ctx context.Context, parentTable, defaultPartition, key, value string) error {
+	stmt, args, err := p.sql.DDL.MoveRowsOfDefaultPartition(parentTable, defaultPartition, key, value, moveBatchSize)
+	if err != nil {
+		return err
+	}
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		tag, err := tx.Exec(ctx, stmt, args...)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() < moveBatchSize {
+			return nil
+		}
+	}
+}
+
+func (p partitions) isAttached(ctx context.Context, parentTable, value string) (bool, error) {
+	stmt, args, err := p.sql.DDL.IsListPartitionAttached(parentTable, value)
+	if err != nil {
+		return false, err
+	}
+	return p.getBool(ctx, stmt, args...)
+}
+
+func (p partitions) getDefaultPartition(ctx context.Context, parentTable string) (string, bool, error) {
+	stmt, args, err := p.sql.DDL.GetDefaultPartition(parentTable)
+	if err != nil {
+		return "", false, err
+	}
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	var defaultPartitions []string
+	if err = pgxscan.Select(ctx, tx, &defaultPartitions, stmt, args...); err != nil {
+		return "", false, err
+	}
+	if len(defaultPartitions) == 0 {
+		return "", false, nil
+	}
+	return defaultPartitions[0], true, nil
+}
+
+func (p partitions) GetUnpartitioned(ctx context.Context) ([]partition.UnpartitionedDTO, error) {
+	var result []partition.UnpartitionedDTO
+	for _, table := range tables.PartitionedAggregateTables {
+		unpartitioned, err := p.getUnpartitioned(ctx, table)
+		if err != nil {
+			return nil, fmt.Errorf("GetUnpartitioned failed for table %q: %w", table.Name, err)
+		}
+		result = append(result, mapper.ToUnpartitionedDTOs(false, unpartitioned...)...)
+	}
+	for _, table := range tables.PartitionedProjectionTables {
+		unpartitioned, err := p.getUnpartitioned(ctx, table)
+		if err != nil {
+			return nil, fmt.Errorf("GetUnpartitioned failed for table %q: %w", table.Name, err)
+		}
+		result = append(result, mapper.ToUnpartitionedDTOs(true, unpartitioned...)...)
+	}
+	return result, nil
+}
+
+// getUnpartitioned returns the rows of the default partitions, i.e. of the default partition of the table and (with
+// tenant sub-partitions) of the default sub-partitions of the keys.
+func (p partitions) getUnpartitioned(ctx context.Context, table tables.PartitionedTableSchema) ([]tables.UnpartitionedRow, error) {
+	leaves, err := p.getLeafPartitions(ctx, table.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []tables.UnpartitionedRow
+	for _, leaf := range mapper.ToPartitionDTOs(table.Name, leaves...) {
+		if !leaf.Default || (leaf.Key != "" && !p.perTenant) {
+			continue
+		}
+		stmt, args, err := p.sql.GetUnpartitioned(table, leaf.Partition)
+		if err != nil {
+			return nil, err
+		}
+		var rows []tables.UnpartitionedRow
+		if err = p.selectRows(ctx, &rows, stmt, args...); err != nil {
+			return nil, err
+		}
+		result = append(result, rows...)
+	}
+	return result, nil
+}
+
+func (p partitions) GetSizes(ctx context.Context) ([]partition.DTO, error) {
+	var result []partition.DTO
+	for _, table := range slices.Concat(tables.PartitionedAggregateTables, tables.PartitionedProjectionTables) {
+		leaves, err := p.getLeafPartitions(ctx, table.Name)
+		if err != nil {
+			return nil, fmt.Errorf("GetSizes failed for table %q: %w", table.Name, err)
+		}
+		result = append(result, mapper.ToPartitionDTOs(table.Name, leaves...)...)
+	}
+	return result, nil
+}
+
+func (p partitions) getLeafPartitions(ctx context.Context, table string) ([]tables.PartitionRow, error) {
+	stmt, args, err := p.sql.DDL.GetLeafPartitions(table)
+	if err != nil {
+		return nil, err
+	}
+	var rows []tables.PartitionRow
+	if err = p.selectRows(ctx, &rows, stmt, args...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (p partitions) getBool(ctx context.Context, stmt string, args ...interface{}) (result bool, err error) {
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	err = pgxscan.Get(ctx, tx, &result, stmt, args...)
+	return result, err
+}
+
+func (p partitions) selectRows(ctx context.Context, dst interface{}, stmt string, args ...interface{}) error {
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	return pgxscan.Select(ctx, tx, dst, stmt, args...)
+}
+
+func (p partitions) exec(ctx context.Context, stmt string, args ...interface{}) error {
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
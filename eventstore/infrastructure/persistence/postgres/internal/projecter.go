@@ -68,6 +68,36 @@ func (p projecter) UnLock(_ context.Context, _ ...shared.ProjectionID) error {
 	return nil
 }
 
+// LockLane is done with advisory locks as well (see Lock), but with a separate key per lane.
+func (p projecter) LockLane(ctx context.Context, id shared.ProjectionID, lane int) error {
+	var lock tables.AdvisoryLock
+
+	stmt, _, err := p.sql.LockLane(ctx, id, lane, lock.LockAlias())
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	if err = pgxscan.Get(ctx, tx, &lock, stmt); err != nil {
+		return fmt.Errorf("LockLane failed: could not query locks: %w", err)
+	}
+
+	if !lock.Locked {
+		return &event.ErrorConcurrentProjectionAccess{
+			TenantID:     id.TenantID,
+			ProjectionID: id.ProjectionID}
+	}
+	return nil
+}
+
+// UnLockLane see UnLock.
+func (p projecter) UnLockLane(_ context.Context, _ shared.ProjectionID, _ int) error {
+	return nil
+}
+
 func (p projecter) Get(ctx context.Context, ids ...shared.ProjectionID) ([]projection.DTO, []projection.NotFoundError, error) {
 	stmt, args, err := p.sql.Get(ctx, ids...)
 	if err != nil {
@@ -182,6 +212,42 @@ func (p projecter) SaveEvents(ctx context.Context, projections ...projection.DTO
 	return nil
 }
 
+func (p projecter) SaveExecutionResult(txCtx context.Context, id shared.ProjectionID, result projection.ExecutionResult) error {
+	stmt, args, err := p.sql.SaveExecutionResult(txCtx, id, result)
+	if err != nil {
+		return err
+	}
+	tx, err := p.GetTx(txCtx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(txCtx, stmt, args...)
+	return err
+}
+
+func (p projecter) GetQueueStatistics(txCtx context.Context, ids ...shared.ProjectionID) ([]projection.QueueStatistics, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	stmt, args, err := p.sql.GetQueueStatistics(txCtx, mapper.MapToNanoseconds(time.Now()), ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tables.ProjectionsQueueStatisticsRow
+	tx, err := p.GetTx(txCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err = pgxscan.Select(txCtx, tx, &rows, stmt, args...); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToQueueStatistics(rows...), nil
+}
+
 func (p projecter) GetAllForAllTenants(ctx context.Context) ([]projection.DTO, error) {
 	stmt, args, err := p.sql.GetAllForAllTenants(ctx)
 	if err != nil {
@@ -253,6 +319,49 @@ func (p projecter) ResetSince(ctx context.Context, id shared.ProjectionID, since
 	return err
 }
 
+func (p projecter) ForkQueue(ctx context.Context, id, fork shared.ProjectionID, sinceTime time.Time, eventTypes ...string) error {
+	if err := p.ResetSince(ctx, fork, sinceTime, eventTypes...); err != nil {
+		return err
+	}
+
+	stmt, args, err := p.sql.DeleteEventsQueuedIn(ctx, fork, id)
+	if err != nil {
+		return err
+	}
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (p projecter) DeleteProjection(ctx context.Context, id shared.ProjectionID) error {
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	stmt, args, err := p.sql.DeleteProjectionState(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("could not delete projection state: %w", err)
+	}
+
+	stmt, args, err = p.sql.DeleteEvents(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("could not delete projection events: %w", err)
+	}
+
+	return nil
+}
+
 func (p projecter) RemoveProjection(ctx context.Context, projectionID string) error {
 	if err := p.removeProjectionState(ctx, projectionID); err != nil {
 		return fmt.Errorf("could not remove projection state: %w", err)
@@ -262,6 +371,10 @@ func (p projecter) RemoveProjection(ctx context.Context, projectionID string) er
 		return fmt.Errorf("could not remove projection events: %w", err)
 	}
 
+	if err := p.removeProjectionQuarantine(ctx, projectionID); err != nil {
+		return fmt.Errorf("could not remove projection quarantine: %w", err)
+	}
+
 	return nil
 }
 
@@ -294,6 +407,20 @@ func (p projecter) removeProjectionEvents(ctx context.Context, projectionID stri
 
 }
 
+func (p projecter) removeProjectionQuarantine(ctx context.Context, projectionID string) error {
+	stmt, args, err := p.sql.RemoveProjectionQuarantine(ctx, projectionID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
+
 func (p projecter) DeleteEventFromQueue(txCtx context.Context, eventID string, ids ...shared.ProjectionID) error {
 	stmt, args, err := p.sql.DeleteEventFromQueue(txCtx, eventID, ids)
 	if err != nil {
@@ -333,3 +460,57 @@ func (p projecter) GetProjectionsWithEventInQueue(txCtx context.Context, id shar
 
 	return result, nil
 }
+
+func (p projecter) AddQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, events ...projection.QuarantinedEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	stmt, args, err := p.sql.AddQuarantinedEvents(txCtx, id, events...)
+	if err != nil {
+		return err
+	}
+	tx, err := p.GetTx(txCtx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(txCtx, stmt, args...)
+	return err
+}
+
+func (p projecter) GetQuarantinedEvents(txCtx context.Context, id shared.ProjectionID) ([]projection.QuarantinedEvent, error) {
+	stmt, args, err := p.sql.GetQuarantinedEvents(txCtx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tables.ProjectionsQuarantineRow
+	tx, err := p.GetTx(txCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err = pgxscan.Select(txCtx, tx, &rows, stmt, args...); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToQuarantinedEvents(rows...)
+}
+
+func (p projecter) DelQuarantinedEvents(txCtx context.Context, id shared.ProjectionID, eventIDs ...string) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	stmt, args, err := p.sql.DelQuarantinedEvents(txCtx, id, eventIDs...)
+	if err != nil {
+		return err
+	}
+	tx, err := p.GetTx(txCtx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(txCtx, stmt, args...)
+	return err
+}
@@ -206,3 +206,7 @@ func (q SqlBuilder) lower(rnge string) string {
 func (q SqlBuilder) upper(rnge string) string {
 	return fmt.Sprintf("upper(%s)", rnge)
 }
+
+func (q SqlBuilder) coalesceMax(column string, defaultValue int64) string {
+	return fmt.Sprintf("coalesce(max(%s),%d)", column, defaultValue)
+}
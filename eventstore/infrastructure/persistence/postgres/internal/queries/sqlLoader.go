@@ -9,6 +9,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/pagination"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/logger"
+	"github.com/jackc/pgx/v5"
 	"strconv"
 	"time"
 )
@@ -36,6 +37,27 @@ func (l SqlLoader) LoadAsOfTill(ctx context.Context, selector map[string]interfa
 	return l.buildLoaderQuery(selector, projectionTime, reportTime, loadWithAsOfTill{}).ToSql()
 }
 
+// LoadAsOfRange selects the event streams with as-of semantic at the end of the range, but with the most recent
+// snapshot at the start of the range.
+func (l SqlLoader) LoadAsOfRange(ctx context.Context, selector map[string]interface{}, from, till time.Time) (statement string, args []interface{}, err error) {
+	return l.buildLoaderQueryWithSnapShotTime(selector, from, till, till, loadWithAsOfSemantic{}).ToSql()
+}
+
+// LoadEventsAsOfTill selects the events with as-of-till semantic without the snapshot short-cut of buildLoaderQuery.
+func (l SqlLoader) LoadEventsAsOfTill(ctx context.Context, selector map[string]interface{}, projectionTime, reportTime time.Time) (statement string, args []interface{}, err error) {
+	aggEvt := tables.AggregateEventTable
+
+	query := l.build().
+		Select(aggEvt.AllColumnsWithPosition()...).
+		From(l.tableWithSchema(aggEvt.Name)).
+		Where(selector).
+		Where(loadWithAsOfTill{}.load(projectionTime, reportTime, aggEvt.ValidTime, aggEvt.TransactionTime)).
+		Where(sq.NotEq{aggEvt.Class: event.DeletePatch}).
+		OrderBy(aggEvt.TenantID, aggEvt.AggregateType, aggEvt.AggregateID, aggEvt.ValidTime, aggEvt.Version)
+
+	return query.ToSql()
+}
+
 func (l SqlLoader) GetAggregateState(ctx context.Context, tenantID, aggregateType, aggregateID string) (statement string, args []interface{}, err error) {
 	query := l.build().
 		Select("*").
@@ -134,7 +156,7 @@ func (l SqlLoader) GetAggregatesEvents(ctx context.Context, tenantID string, cur
 	aggEvt := tables.AggregateEventTable
 
 	query := l.build().Select(
-		aggEvt.AllColumns()...).
+		aggEvt.AllColumnsWithPosition()...).
 		From(l.tableWithSchema(tables.AggregateEventTable.Name)).
 		Where(
 			sq.Eq{aggEvt.TenantID: tenantID})
@@ -150,6 +172,96 @@ func (l SqlLoader) GetAggregatesEvents(ctx context.Context, tenantID string, cur
 
 }
 
+// GetUnsearchableDataCodec returns the codec of an event, which matches all search fields except the data, but whose
+// data is not JSON encoded, i.e. not searchable (or no row).
+func (l SqlLoader) GetUnsearchableDataCodec(ctx context.Context, tenantID string, searchFields []event.SearchField) (statement string, args []interface{}, err error) {
+	aggEvt := tables.AggregateEventTable
+
+	query := l.build().
+		Select(aggEvt.Codec).
+		From(l.tableWithSchema(aggEvt.Name)).
+		Where(sq.Eq{aggEvt.TenantID: tenantID}).
+		Where(sq.NotEq{aggEvt.Codec: ""})
+
+	var otherFields []event.SearchField
+	for _, field := range searchFields {
+		if field.Name != event.SearchData {
+			otherFields = append(otherFields, field)
+		}
+	}
+	for _, clause := range l.createSearchClause(otherFields) {
+		query = query.Where(clause)
+	}
+
+	return query.Limit(1).ToSql()
+}
+
+func (l SqlLoader) LoadFromPosition(ctx context.Context, tenantID string, position int64, limit int) (statement string, args []interface{}, err error) {
+	aggEvt := tables.AggregateEventTable
+
+	query := l.build().
+		Select(aggEvt.AllColumnsWithPosition()...).
+		From(l.tableWithSchema(aggEvt.Name)).
+		Where(sq.Eq{aggEvt.TenantID: tenantID}).
+		Where(sq.Gt{aggEvt.Position: position}).
+		OrderBy(aggEvt.Position)
+
+	return l.fetchFirstRowsOnly(query, limit).ToSql()
+}
+
+func (l SqlLoader) GetLatestTransactionTime(ctx context.Context) (statement string, args []interface{}, err error) {
+	query := l.build().
+		Select(l.coalesceMax(tables.AggregateEventTable.TransactionTime, 0)).
+		From(l.tableWithSchema(tables.AggregateEventTable.Name))
+
+	return query.ToSql()
+}
+
+func (l SqlLoader) ExportAggregates(ctx context.Context, tenantID string) (statement string, args []interface{}, err error) {
+	agg := tables.AggregateTable
+
+	query := l.build().
+		Select(agg.AllColumns()...).
+		From(l.tableWithSchema(agg.Name)).
+		Where(sq.Eq{agg.TenantID: tenantID}).
+		OrderBy(agg.AggregateType, agg.AggregateID)
+
+	return query.ToSql()
+}
+
+func (l SqlLoader) ExportEvents(ctx context.Context, tenantID string) (statement string, args []interface{}, err error) {
+	aggEvt := tables.AggregateEventTable
+
+	query := l.build().
+		Select(aggEvt.AllColumnsWithPosition()...).
+		From(l.tableWithSchema(aggEvt.Name)).
+		Where(sq.Eq{aggEvt.TenantID: tenantID}).
+		OrderBy(aggEvt.Position)
+
+	return query.ToSql()
+}
+
+func (l SqlLoader) ExportSnapShots(ctx context.Context, tenantID string) (statement string, args []interface{}, err error) {
+	snap := tables.AggregateSnapsShotTable
+
+	query := l.build().
+		Select(snap.AllColumns()...).
+		From(l.tableWithSchema(snap.Name)).
+		Where(sq.Eq{snap.TenantID: tenantID}).
+		OrderBy(snap.AggregateType, snap.AggregateID, snap.ValidTime, snap.Version)
+
+	return query.ToSql()
+}
+
+// DeclareCursor wraps a load statement into a server-side cursor. Cursors only exist within a transaction.
+func (l SqlLoader) DeclareCursor(ctx context.Context, cursor string, statement string) string {
+	return fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", pgx.Identifier{cursor}.Sanitize(), statement)
+}
+
+func (l SqlLoader) FetchCursor(ctx context.Context, cursor string, size int) string {
+	return fmt.Sprintf("FETCH FORWARD %d FROM %s", size, pgx.Identifier{cursor}.Sanitize())
+}
+
 func (l SqlLoader) createSearchClause(searchFields []event.SearchField) []sq.Sqlizer {
 	var clauses []sq.Sqlizer
 	for _, field := range searchFields {
@@ -218,7 +330,7 @@ func (l SqlLoader) buildSearchClause(field event.SearchField) (sq.Sqlizer, error
 			return l.buildComparison(table.TransactionTime, field.Operator, mapper.MapToNanoseconds(dateTime))
 		}
 	case event.SearchData:
-		// type cast always needed
+		// type cast always needed, the events of other codecs are rejected beforehand (see GetUnsearchableDataCodec)
 		return l.buildComparison(fmt.Sprintf("%s::TEXT", table.Data), field.Operator, field.Value)
 	default:
 		return nil, fmt.Errorf("unsupported search field %s", field.Name)
@@ -44,6 +44,12 @@ func (l loadWithAsOfTill) load(projectionTime time.Time, reportTime time.Time, v
 
 // ------------------------------------------BUILDER---------------------------------------------------------------------
 func (l SqlLoader) buildLoaderQuery(selector map[string]interface{}, pTime, rTime time.Time, semantic loadWithSemantic) sq.SelectBuilder {
+	return l.buildLoaderQueryWithSnapShotTime(selector, pTime, pTime, rTime, semantic)
+}
+
+// buildLoaderQueryWithSnapShotTime selects the most recent snapshot with respect to sTime instead of pTime, which is
+// used to load several projection times (sTime <= pTime) with the same snapshot.
+func (l SqlLoader) buildLoaderQueryWithSnapShotTime(selector map[string]interface{}, sTime, pTime, rTime time.Time, semantic loadWithSemantic) sq.SelectBuilder {
 	const final = "final"
 	const cteName = "mostRecentSnapShot"
 	var currentVersionColumn = tables.AggregateTable.CurrentVersion
@@ -51,7 +57,7 @@ func (l SqlLoader) buildLoaderQuery(selector map[string]interface{}, pTime, rTim
 		l.asCTE(
 			l.getMostRecentSnapShot(
 				selector,
-				semantic.load(pTime, rTime, tables.AggregateSnapsShotTable.ValidTime, tables.AggregateSnapsShotTable.TransactionTime)),
+				semantic.load(sTime, rTime, tables.AggregateSnapsShotTable.ValidTime, tables.AggregateSnapsShotTable.TransactionTime)),
 			cteName).
 			SuffixExpr(
 				l.unionAll(
@@ -100,7 +106,8 @@ func (l SqlLoader) getSnapShotDataWithAggregateCurrentVersion(cteName string, cu
 	return l.build().
 		Select(append(
 			l.withColumnsPrefix(mostRecent, tables.AggregateSnapsShotTable.AllColumns()...),
-			l.withColumnPrefix(aggregate, tables.AggregateTable.CurrentVersion))...).
+			l.withColumnPrefix(aggregate, tables.AggregateTable.CurrentVersion),
+			l.withAlias("0", tables.AggregateEventTable.Position))...). // snapshots are not part of the event log
 		From(l.joinLeftUsing(
 			l.withAlias(cteName, mostRecent),
 			l.tableWithSchemaAndAlias(tables.AggregateTable.Name, aggregate),
@@ -117,7 +124,8 @@ func (l SqlLoader) getAllEventsOfAggregateAfterSnapShots(selector map[string]int
 	stmt := l.build().
 		Select(append(
 			l.withColumnsPrefix(aggregateEvents, tables.AggregateEventTable.AllColumns()...),
-			l.withColumnsPrefix(aggRecent, tables.AggregateTable.CurrentVersion)...)...).
+			l.withColumnPrefix(aggRecent, tables.AggregateTable.CurrentVersion),
+			l.withColumnPrefix(aggregateEvents, tables.AggregateEventTable.Position))...).
 		From(l.joinUsing(
 			l.tableWithSchemaAndAlias(tables.AggregateEventTable.Name, aggregateEvents),
 			l.withAlias(
@@ -159,7 +167,8 @@ func (l SqlLoader) getAllEventsOfAggregatesWithoutSnapShots(selector map[string]
 	stmt := l.build().
 		Select(append(
 			l.withColumnsPrefix(aggregateEvents, tables.AggregateEventTable.AllColumns()...),
-			l.withColumnsPrefix(aggregateEvents, tables.AggregateTable.CurrentVersion)...)...).
+			l.withColumnPrefix(aggregateEvents, tables.AggregateTable.CurrentVersion),
+			l.withColumnPrefix(aggregateEvents, tables.AggregateEventTable.Position))...).
 		From(
 			l.joinLeftUsing(
 				l.withAlias(
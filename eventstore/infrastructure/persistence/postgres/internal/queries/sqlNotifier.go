@@ -0,0 +1,41 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const projectionNotificationChannel = "projections"
+
+func NewSqlNotifier(databaseSchema string, placeholder sq.PlaceholderFormat) SqlNotifier {
+	return SqlNotifier{SqlBuilder{
+		placeholder:    placeholder,
+		databaseSchema: databaseSchema,
+	}}
+}
+
+type SqlNotifier struct {
+	SqlBuilder
+}
+
+// Channel returns the notification channel of the projections (one channel per database schema).
+func (s SqlNotifier) Channel() string {
+	return fmt.Sprintf("%s_%s", s.databaseSchema, projectionNotificationChannel)
+}
+
+// Notify sends one notification per payload. Postgres delivers notifications only on commit of the transaction.
+func (s SqlNotifier) Notify(ctx context.Context, payloads ...string) (string, []interface{}, error) {
+	stmt, args, err := s.build().
+		Select("pg_notify(?, payload)", s.Channel()).
+		From("unnest(?::text[]) AS payload").
+		ToSql()
+
+	// the placeholder of the from clause is not known by the builder
+	return stmt, append(args, payloads), err
+}
+
+func (s SqlNotifier) Listen(ctx context.Context) string {
+	return fmt.Sprintf("LISTEN %s", pgx.Identifier{s.Channel()}.Sanitize())
+}
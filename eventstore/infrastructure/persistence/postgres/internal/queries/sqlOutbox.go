@@ -0,0 +1,86 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/outbox"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"time"
+)
+
+func NewSqlOutbox(databaseSchema string, placeholder sq.PlaceholderFormat) SqlOutbox {
+	return SqlOutbox{SqlBuilder{
+		placeholder:    placeholder,
+		databaseSchema: databaseSchema,
+	}}
+}
+
+type SqlOutbox struct {
+	SqlBuilder
+}
+
+// UpsertMessages inserts the messages or updates the state of their delivery, if they already exist.
+func (s SqlOutbox) UpsertMessages(ctx context.Context, messages ...outbox.Message) (string, []interface{}, error) {
+	out := tables.OutboxMessagesTable
+	query := s.build().
+		Insert(s.tableWithSchema(out.Name)).
+		Columns(out.AllColumns()...).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s, %s) DO UPDATE SET %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s",
+			out.TenantID, out.EventID,
+			out.Status, out.Status,
+			out.Attempts, out.Attempts,
+			out.NextAttempt, out.NextAttempt,
+			out.LastError, out.LastError))
+
+	for _, msg := range messages {
+		row, err := mapper.ToOutboxMessageRow(msg)
+		if err != nil {
+			return "", nil, err
+		}
+		query = query.Values(mapper.OutboxMessageRowToArrayOfValues(row)...)
+	}
+
+	return query.ToSql()
+}
+
+// GetDueMessages skips the messages locked by other transactions (e.g. the dispatcher of another instance).
+func (s SqlOutbox) GetDueMessages(ctx context.Context, now time.Time, limit int) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.OutboxMessagesTable.AllColumns()...).
+		From(s.tableWithSchema(tables.OutboxMessagesTable.Name)).
+		Where(sq.Eq{tables.OutboxMessagesTable.Status: string(outbox.Pending)}).
+		Where(sq.LtOrEq{tables.OutboxMessagesTable.NextAttempt: mapper.MapToNanoseconds(now)}).
+		OrderBy(tables.OutboxMessagesTable.TransactionTime).
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+}
+
+func (s SqlOutbox) GetDeadLetters(ctx context.Context, tenantID string) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.OutboxMessagesTable.AllColumns()...).
+		From(s.tableWithSchema(tables.OutboxMessagesTable.Name)).
+		Where(sq.Eq{
+			tables.OutboxMessagesTable.TenantID: tenantID,
+			tables.OutboxMessagesTable.Status:   string(outbox.DeadLetter),
+		}).
+		OrderBy(tables.OutboxMessagesTable.TransactionTime).
+		ToSql()
+}
+
+func (s SqlOutbox) DelMessages(ctx context.Context, messages ...outbox.Message) (string, []interface{}, error) {
+	some := sq.Or{}
+	for _, msg := range messages {
+		some = append(some, sq.Eq{
+			tables.OutboxMessagesTable.TenantID: msg.Event.TenantID,
+			tables.OutboxMessagesTable.EventID:  msg.Event.ID,
+		})
+	}
+
+	return s.build().
+		Delete(s.tableWithSchema(tables.OutboxMessagesTable.Name)).
+		Where(some).
+		ToSql()
+}
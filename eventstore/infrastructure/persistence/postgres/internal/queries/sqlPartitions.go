@@ -0,0 +1,84 @@
+package queries
+
+import (
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"github.com/global-soft-ba/go-eventstore/transactor/postgres/queries/partition"
+	"strings"
+	"time"
+)
+
+func NewSqlPartitions(databaseSchema string, placeholder sq.PlaceholderFormat) (SqlPartitions, error) {
+	ddl, err := partition.NewSqlBuilder(databaseSchema, placeholder)
+	if err != nil {
+		return SqlPartitions{}, fmt.Errorf("could not create partition builder: %w", err)
+	}
+	return SqlPartitions{
+		SqlBuilder: SqlBuilder{
+			placeholder:    placeholder,
+			databaseSchema: databaseSchema,
+		},
+		DDL: ddl,
+	}, nil
+}
+
+// SqlPartitions builds the statements of the partition management. The partition DDL itself is built by the DDL
+// builder of the transactor package.
+type SqlPartitions struct {
+	SqlBuilder
+	DDL *partition.Builder
+}
+
+// SetLockTimeout limits the waiting for locks until the end of the transaction. The partition DDL needs exclusive locks,
+// so that it would otherwise queue up all following requests behind long-running transactions.
+func (s SqlPartitions) SetLockTimeout(timeout time.Duration) string {
+	return fmt.Sprintf("SET LOCAL lock_timeout = %d", timeout.Milliseconds())
+}
+
+// Lock serializes the creation of a partition between several instances (until the end of the transaction).
+func (s SqlPartitions) Lock(partitionName string) string {
+	return fmt.Sprintf("SELECT pg_advisory_xact_lock(%d)", generateAdvisoryLockId(partitionName, s.databaseSchema))
+}
+
+// LockForWrites blocks the writes into the (qualified) table until the end of the transaction. Reads are still possible.
+func (s SqlPartitions) LockForWrites(table string) string {
+	return fmt.Sprintf("LOCK TABLE %s IN EXCLUSIVE MODE", table)
+}
+
+// HasPrimaryKeyColumn returns true, if the column is part of the primary key of the table.
+func (s SqlPartitions) HasPrimaryKeyColumn(table, column string) (string, []interface{}, error) {
+	return s.build().
+		Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM pg_catalog.pg_index AS i JOIN pg_catalog.pg_attribute AS a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey) "+
+			"WHERE i.indrelid = ?::regclass AND i.indisprimary AND a.attname = ?)", s.Qualified(table), column)).
+		ToSql()
+}
+
+// ReplacePrimaryKey replaces the primary key of the table (and all its partitions) by the columns. This needs an access
+// exclusive lock and rebuilds the primary key indexes.
+func (s SqlPartitions) ReplacePrimaryKey(table string, columns ...string) string {
+	escaped := make([]string, len(columns))
+	for i, column := range columns {
+		escaped[i] = s.escaped(column)
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s, ADD PRIMARY KEY (%s)",
+		s.Qualified(table), s.escaped(table+"_pkey"), strings.Join(escaped, ", "))
+}
+
+// GetUnpartitioned returns the distinct tenants and keys of the rows in the (default) partition.
+func (s SqlPartitions) GetUnpartitioned(table tables.PartitionedTableSchema, partition string) (string, []interface{}, error) {
+	return s.build().
+		Select(s.withAlias(s.escaped(table.SubKey), "tenant_id"), s.withAlias(s.escaped(table.Key), "key")).
+		Distinct().
+		From(s.Qualified(partition)).
+		ToSql()
+}
+
+func (s SqlPartitions) Qualified(table string) string {
+	return fmt.Sprintf(`%s.%s`, s.databaseSchema, s.escaped(table))
+}
+
+func (s SqlPartitions) escaped(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
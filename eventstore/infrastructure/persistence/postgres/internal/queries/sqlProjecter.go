@@ -8,6 +8,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"strconv"
 	"time"
 )
 
@@ -29,6 +30,13 @@ func (p SqlProjecter) Lock(ctx context.Context, id shared.ProjectionID, alias st
 	return query.ToSql()
 }
 
+func (p SqlProjecter) LockLane(ctx context.Context, id shared.ProjectionID, lane int, alias string) (string, []interface{}, error) {
+	key := generateAdvisoryLockId(id.TenantID, id.ProjectionID, strconv.Itoa(lane))
+	query := p.build().
+		Select(p.pgAdvisoryLockForTX(key, alias))
+	return query.ToSql()
+}
+
 func (p SqlProjecter) UnLock(ctx context.Context, id shared.ProjectionID, alias string) (string, []interface{}, error) {
 	key := generateAdvisoryLockId(id.TenantID, id.ProjectionID)
 	query := p.build().
@@ -73,7 +81,8 @@ func (p SqlProjecter) SaveStates(ctx context.Context, projections ...projection.
 		Suffix(
 			"ON CONFLICT ON CONSTRAINT projections_pkey " +
 				"DO UPDATE SET " +
-				tables.ProjectionsTable.State + "= excluded." + tables.ProjectionsTable.State,
+				tables.ProjectionsTable.State + "= excluded." + tables.ProjectionsTable.State + ", " +
+				tables.ProjectionsTable.Generation + "= excluded." + tables.ProjectionsTable.Generation,
 		)
 	for _, proj := range projections {
 		//order of columns must be same as in function AllColumns()
@@ -81,12 +90,60 @@ func (p SqlProjecter) SaveStates(ctx context.Context, projections ...projection.
 			proj.TenantID,
 			proj.ProjectionID,
 			proj.State,
+			proj.Generation,
 		)
 	}
 
 	return query.ToSql()
 }
 
+func (p SqlProjecter) SaveExecutionResult(ctx context.Context, id shared.ProjectionID, result projection.ExecutionResult) (string, []interface{}, error) {
+	query := p.build().
+		Update(p.tableWithSchema(tables.ProjectionsTable.Name)).
+		Where(sq.Eq{
+			tables.ProjectionsTable.TenantID:     id.TenantID,
+			tables.ProjectionsTable.ProjectionID: id.ProjectionID,
+		})
+
+	if result.Error == "" {
+		query = query.
+			Set(tables.ProjectionsTable.LastRunAt, mapper.MapToNanoseconds(result.ExecutedAt)).
+			Set(tables.ProjectionsTable.LastError, "").
+			Set(tables.ProjectionsTable.Failures, 0)
+	} else {
+		query = query.
+			Set(tables.ProjectionsTable.LastError, result.Error).
+			Set(tables.ProjectionsTable.Failures, sq.Expr(tables.ProjectionsTable.Failures+" + 1"))
+	}
+
+	return query.ToSql()
+}
+
+func (p SqlProjecter) GetQueueStatistics(ctx context.Context, sinceTimeStamp int64, ids ...shared.ProjectionID) (string, []interface{}, error) {
+	some := sq.Or{}
+	for _, id := range ids {
+		some = append(some, sq.Eq{
+			tables.ProjectionsEventsTable.TenantID:     id.TenantID,
+			tables.ProjectionsEventsTable.ProjectionID: id.ProjectionID,
+		})
+	}
+
+	return p.build().
+		Select(
+			tables.ProjectionsEventsTable.TenantID,
+			tables.ProjectionsEventsTable.ProjectionID,
+			p.withAlias("count(*)", tables.ProjectionsQueueStatistics.QueuedEvents),
+			p.withAlias("min("+tables.ProjectionsEventsTable.TransactionTime+")", tables.ProjectionsQueueStatistics.OldestTransactionTime),
+		).
+		From(p.tableWithSchema(tables.ProjectionsEventsTable.Name)).
+		Where(some).
+		// the same events as in GetSinceLastRun, i.e. without future and delete patches
+		Where(sq.Lt{tables.ProjectionsEventsTable.ValidTime: sinceTimeStamp}).
+		Where(sq.NotEq{tables.ProjectionsEventsTable.Class: event.DeletePatch}).
+		GroupBy(tables.ProjectionsEventsTable.TenantID, tables.ProjectionsEventsTable.ProjectionID).
+		ToSql()
+}
+
 func (p SqlProjecter) SaveProjectionEvents(ctx context.Context, events ...tables.ProjectionsEventRow) (statement string, args []interface{}, err error) {
 	query := p.build().
 		Insert(p.tableWithSchema(tables.ProjectionsEventsTable.Name)).
@@ -101,34 +158,37 @@ func (p SqlProjecter) SaveProjectionEvents(ctx context.Context, events ...tables
 }
 
 func (p SqlProjecter) GetSinceLastRun(ctx context.Context, id shared.ProjectionID, loadOpt projection.LoadOptions, sinceTimeStamp int64) (statement string, args []interface{}, err error) {
-	query :=
-		p.fetchFirstRowsOnly(
-			p.build().
-				Select(append(tables.ProjectionsEventsTable.AllColumns(), tables.ProjectionsTable.State)...).
-				From(p.joinLeftUsing(
-					p.tableWithSchema(tables.ProjectionsEventsTable.Name),
-					p.tableWithSchema(tables.ProjectionsTable.Name),
-					tables.ProjectionsTable.TenantID,
-					tables.ProjectionsTable.ProjectionID,
-				)).
-				Where(sq.Eq{
-					tables.ProjectionsTable.TenantID:     id.TenantID,
-					tables.ProjectionsTable.ProjectionID: id.ProjectionID,
-				}).
-				Where(sq.Lt{
-					tables.ProjectionsEventsTable.ValidTime: sinceTimeStamp,
-				}).
-				Where(
-					sq.NotEq{tables.AggregateEventTable.Class: event.DeletePatch}). //ignore delete patches
-				OrderBy(
-					tables.ProjectionsEventsTable.ValidTime,
-					tables.ProjectionsEventsTable.AggregateID,
-					tables.ProjectionsEventsTable.Version,
-				),
-
-			loadOpt.ChunkSize)
+	query := p.build().
+		Select(append(tables.ProjectionsEventsTable.AllColumns(), tables.ProjectionsTable.State, tables.ProjectionsTable.Generation)...).
+		From(p.joinLeftUsing(
+			p.tableWithSchema(tables.ProjectionsEventsTable.Name),
+			p.tableWithSchema(tables.ProjectionsTable.Name),
+			tables.ProjectionsTable.TenantID,
+			tables.ProjectionsTable.ProjectionID,
+		)).
+		Where(sq.Eq{
+			tables.ProjectionsTable.TenantID:     id.TenantID,
+			tables.ProjectionsTable.ProjectionID: id.ProjectionID,
+		}).
+		Where(sq.Lt{
+			tables.ProjectionsEventsTable.ValidTime: sinceTimeStamp,
+		}).
+		Where(
+			sq.NotEq{tables.AggregateEventTable.Class: event.DeletePatch}) //ignore delete patches
+
+	if loadOpt.Lanes > 1 {
+		// hashtext is stable, so that an aggregate always belongs to the same lane
+		query = query.Where(
+			"abs(hashtext("+tables.ProjectionsEventsTable.AggregateID+")::bigint) % ? = ?", loadOpt.Lanes, loadOpt.Lane)
+	}
 
-	return query.ToSql()
+	return p.fetchFirstRowsOnly(
+		query.OrderBy(
+			tables.ProjectionsEventsTable.ValidTime,
+			tables.ProjectionsEventsTable.AggregateID,
+			tables.ProjectionsEventsTable.Version,
+		),
+		loadOpt.ChunkSize).ToSql()
 }
 
 func (p SqlProjecter) DeleteRows(ctx context.Context, rows ...tables.ProjectionsEventsLoadRow) (statement string, args []interface{}, err error) {
@@ -174,6 +234,29 @@ func (p SqlProjecter) ResetSince(ctx context.Context, id shared.ProjectionID, si
 	return query.ToSql()
 }
 
+// DeleteEventsQueuedIn deletes the events from the queue of the projection id, which are still in the queue of the
+// projection other.
+func (p SqlProjecter) DeleteEventsQueuedIn(ctx context.Context, id, other shared.ProjectionID) (statement string, args []interface{}, err error) {
+	// the nested query keeps the question placeholders, which are replaced by the outer query
+	queued := sq.
+		Select(tables.ProjectionsEventsTable.ID).
+		From(p.tableWithSchema(tables.ProjectionsEventsTable.Name)).
+		Where(sq.Eq{
+			tables.ProjectionsEventsTable.TenantID:     other.TenantID,
+			tables.ProjectionsEventsTable.ProjectionID: other.ProjectionID,
+		})
+
+	query := p.build().
+		Delete(p.tableWithSchema(tables.ProjectionsEventsTable.Name)).
+		Where(sq.Eq{
+			tables.ProjectionsEventsTable.TenantID:     id.TenantID,
+			tables.ProjectionsEventsTable.ProjectionID: id.ProjectionID,
+		}).
+		Where(sq.Expr(tables.ProjectionsEventsTable.ID+" IN (?)", queued))
+
+	return query.ToSql()
+}
+
 func (p SqlProjecter) getAllEventsOfProjectionSince(ctx context.Context, id shared.ProjectionID, sinceTime time.Time, eventTypes ...string) sq.SelectBuilder {
 	selector := map[string]interface{}{
 		tables.AggregateEventTable.TenantID: id.TenantID,
@@ -191,6 +274,17 @@ func (p SqlProjecter) RemoveProjectionState(ctx context.Context, projectionID st
 	return query.ToSql()
 }
 
+func (p SqlProjecter) DeleteProjectionState(ctx context.Context, id shared.ProjectionID) (string, []interface{}, error) {
+	query := p.build().
+		Delete(p.tableWithSchema(tables.ProjectionsTable.Name)).
+		Where(sq.Eq{
+			tables.ProjectionsTable.TenantID:     id.TenantID,
+			tables.ProjectionsTable.ProjectionID: id.ProjectionID,
+		})
+
+	return query.ToSql()
+}
+
 func (p SqlProjecter) RemoveProjectionEvents(ctx context.Context, projectionID string) (string, []interface{}, error) {
 	query := p.build().
 		Delete(p.tableWithSchema(tables.ProjectionsEventsTable.Name)).
@@ -234,3 +328,52 @@ func (p SqlProjecter) GetProjectionsWithEventInQueue(ctx context.Context, id sha
 
 	return query.ToSql()
 }
+
+func (p SqlProjecter) AddQuarantinedEvents(ctx context.Context, id shared.ProjectionID, events ...projection.QuarantinedEvent) (string, []interface{}, error) {
+	query := p.build().
+		Insert(p.tableWithSchema(tables.ProjectionsQuarantineTable.Name)).
+		Columns(tables.ProjectionsQuarantineTable.AllColumns()...)
+
+	for _, evt := range events {
+		row, err := mapper.ToProjectionsQuarantineRow(id, evt)
+		if err != nil {
+			return "", nil, err
+		}
+		query = query.Values(mapper.ProjectionsQuarantineRowToArrayOfValues(row)...)
+	}
+
+	return query.ToSql()
+}
+
+func (p SqlProjecter) GetQuarantinedEvents(ctx context.Context, id shared.ProjectionID) (string, []interface{}, error) {
+	return p.build().
+		Select(tables.ProjectionsQuarantineTable.AllColumns()...).
+		From(p.tableWithSchema(tables.ProjectionsQuarantineTable.Name)).
+		Where(sq.Eq{
+			tables.ProjectionsQuarantineTable.TenantID:     id.TenantID,
+			tables.ProjectionsQuarantineTable.ProjectionID: id.ProjectionID,
+		}).
+		OrderBy(tables.ProjectionsQuarantineTable.ValidTime).
+		ToSql()
+}
+
+func (p SqlProjecter) DelQuarantinedEvents(ctx context.Context, id shared.ProjectionID, eventIDs ...string) (string, []interface{}, error) {
+	return p.build().
+		Delete(p.tableWithSchema(tables.ProjectionsQuarantineTable.Name)).
+		Where(sq.Eq{
+			tables.ProjectionsQuarantineTable.TenantID:     id.TenantID,
+			tables.ProjectionsQuarantineTable.ProjectionID: id.ProjectionID,
+			tables.ProjectionsQuarantineTable.EventID:      eventIDs,
+		}).
+		ToSql()
+}
+
+func (p SqlProjecter) RemoveProjectionQuarantine(ctx context.Context, projectionID string) (string, []interface{}, error) {
+	query := p.build().
+		Delete(p.tableWithSchema(tables.ProjectionsQuarantineTable.Name)).
+		Where(sq.Eq{
+			tables.ProjectionsQuarantineTable.ProjectionID: projectionID,
+		})
+
+	return query.ToSql()
+}
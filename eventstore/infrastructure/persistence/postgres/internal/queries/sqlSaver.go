@@ -2,6 +2,7 @@ package queries
 
 import (
 	"context"
+	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/aggregate"
@@ -69,16 +70,30 @@ func (s SqlSaver) SaveAggregates(ctx context.Context, states []aggregate.DTO) (s
 }
 
 func (s SqlSaver) SaveAggregateEvents(ctx context.Context, events []event.PersistenceEvent) (statement string, args []interface{}, err error) {
-	query := s.build().Insert(s.tableWithSchema(tables.AggregateEventTable.Name)).Columns(tables.AggregateEventTable.AllColumns()...)
+	query := s.build().Insert(s.tableWithSchema(tables.AggregateEventTable.Name)).Columns(tables.AggregateEventTable.AllColumnsWithPosition()...)
 	for _, evt := range events {
 		query = query.Values(
-			mapper.AggregateEventRowToArrayOfValues(
+			mapper.AggregateEventRowWithPositionToArrayOfValues(
 				mapper.ToAggregateEventRow(evt))...,
 		)
 	}
 	return query.ToSql()
 }
 
+// IncrementTenantPosition increments the last assigned position of the tenant by the number of events and returns
+// the new position. The row lock of the update is held until the end of the transaction, i.e. positions of a tenant
+// are assigned in commit order.
+func (s SqlSaver) IncrementTenantPosition(ctx context.Context, tenantID string, numberOfEvents int) (statement string, args []interface{}, err error) {
+	pos := tables.TenantPositionsTable
+	return s.build().
+		Insert(s.tableWithSchema(pos.Name)).
+		Columns(pos.AllColumns()...).
+		Values(tenantID, numberOfEvents).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s = %s.%s + EXCLUDED.%s RETURNING %s",
+			pos.TenantID, pos.Position, pos.Name, pos.Position, pos.Position, pos.Position)).
+		ToSql()
+}
+
 func (s SqlSaver) SaveAggregateSnapshots(ctx context.Context, snapShots []event.PersistenceEvent) (statement string, args []interface{}, err error) {
 	query := s.build().Insert(s.tableWithSchema(tables.AggregateSnapsShotTable.Name)).Columns(tables.AggregateSnapsShotTable.AllColumns()...)
 	for _, evt := range snapShots {
@@ -0,0 +1,97 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"strings"
+	"time"
+)
+
+// GetArchivableStreams returns the closed and not yet archived streams, which were closed before the given time, the
+// longest closed first.
+func (s SqlSaver) GetArchivableStreams(ctx context.Context, closedBefore time.Time, limit int) (string, []interface{}, error) {
+	agg := tables.AggregateTable
+	archived := tables.AggregatesArchivedTable
+	return s.build().
+		Select(agg.TenantID, agg.AggregateType, agg.AggregateID).
+		From(s.tableWithSchema(agg.Name) + " AS agg").
+		Where(sq.NotEq{"agg." + agg.CloseTime: mapper.MapToNanoseconds(time.Time{})}).
+		Where(sq.Lt{"agg." + agg.CloseTime: mapper.MapToNanoseconds(closedBefore)}).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s AS arc WHERE arc.%s = agg.%s AND arc.%s = agg.%s AND arc.%s = agg.%s)",
+			s.tableWithSchema(archived.Name),
+			archived.TenantID, agg.TenantID,
+			archived.AggregateType, agg.AggregateType,
+			archived.AggregateID, agg.AggregateID)).
+		OrderBy("agg." + agg.CloseTime).
+		Limit(uint64(limit)).
+		ToSql()
+}
+
+func (s SqlSaver) GetArchivedStreams(ctx context.Context, tenantID string) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.AggregatesArchivedTable.AllColumns()...).
+		From(s.tableWithSchema(tables.AggregatesArchivedTable.Name)).
+		Where(sq.Eq{tables.AggregatesArchivedTable.TenantID: tenantID}).
+		ToSql()
+}
+
+func (s SqlSaver) GetArchiveTime(ctx context.Context, id shared.AggregateID) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.AggregatesArchivedTable.ArchiveTime).
+		From(s.tableWithSchema(tables.AggregatesArchivedTable.Name)).
+		Where(sq.Eq{
+			tables.AggregatesArchivedTable.TenantID:      id.TenantID,
+			tables.AggregatesArchivedTable.AggregateType: id.AggregateType,
+			tables.AggregatesArchivedTable.AggregateID:   id.AggregateID,
+		}).
+		ToSql()
+}
+
+// DeleteAggregateEvents deletes all events of the aggregate and returns them (unordered).
+func (s SqlSaver) DeleteAggregateEvents(ctx context.Context, id shared.AggregateID) (string, []interface{}, error) {
+	return s.build().
+		Delete(s.tableWithSchema(tables.AggregateEventTable.Name)).
+		Where(sq.Eq{
+			tables.AggregateEventTable.TenantID:      id.TenantID,
+			tables.AggregateEventTable.AggregateType: id.AggregateType,
+			tables.AggregateEventTable.AggregateID:   id.AggregateID,
+		}).
+		Suffix("RETURNING " + strings.Join(tables.AggregateEventTable.AllColumnsWithPosition(), ", ")).
+		ToSql()
+}
+
+// DeleteAggregateSnapShots deletes all snapshots of the aggregate and returns them (unordered).
+func (s SqlSaver) DeleteAggregateSnapShots(ctx context.Context, id shared.AggregateID) (string, []interface{}, error) {
+	return s.build().
+		Delete(s.tableWithSchema(tables.AggregateSnapsShotTable.Name)).
+		Where(sq.Eq{
+			tables.AggregateSnapsShotTable.TenantID:      id.TenantID,
+			tables.AggregateSnapsShotTable.AggregateType: id.AggregateType,
+			tables.AggregateSnapsShotTable.AggregateID:   id.AggregateID,
+		}).
+		Suffix("RETURNING " + strings.Join(tables.AggregateSnapsShotTable.AllColumns(), ", ")).
+		ToSql()
+}
+
+func (s SqlSaver) InsertArchivedStream(ctx context.Context, id shared.AggregateID, archived time.Time) (string, []interface{}, error) {
+	return s.build().
+		Insert(s.tableWithSchema(tables.AggregatesArchivedTable.Name)).
+		Columns(tables.AggregatesArchivedTable.AllColumns()...).
+		Values(id.TenantID, id.AggregateType, id.AggregateID, mapper.MapToNanoseconds(archived)).
+		ToSql()
+}
+
+func (s SqlSaver) DeleteArchivedStream(ctx context.Context, id shared.AggregateID) (string, []interface{}, error) {
+	return s.build().
+		Delete(s.tableWithSchema(tables.AggregatesArchivedTable.Name)).
+		Where(sq.Eq{
+			tables.AggregatesArchivedTable.TenantID:      id.TenantID,
+			tables.AggregatesArchivedTable.AggregateType: id.AggregateType,
+			tables.AggregatesArchivedTable.AggregateID:   id.AggregateID,
+		}).
+		ToSql()
+}
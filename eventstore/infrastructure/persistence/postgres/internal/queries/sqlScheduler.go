@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func NewSqlScheduler(databaseSchema string, placeholder sq.PlaceholderFormat) SqlScheduler {
+	return SqlScheduler{SqlBuilder{
+		placeholder:    placeholder,
+		databaseSchema: databaseSchema,
+	}}
+}
+
+type SqlScheduler struct {
+	SqlBuilder
+}
+
+func (s SqlScheduler) GetOpenTasks(ctx context.Context, id shared.ProjectionID) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.ScheduledProjectionTasksTable.AllColumns()...).
+		From(s.tableWithSchema(tables.ScheduledProjectionTasksTable.Name)).
+		Where(sq.Eq{
+			tables.ScheduledProjectionTasksTable.TenantID:     id.TenantID,
+			tables.ScheduledProjectionTasksTable.ProjectionID: id.ProjectionID,
+		}).
+		OrderBy(tables.ScheduledProjectionTasksTable.ValidTime).
+		ToSql()
+}
+
+func (s SqlScheduler) AddTasks(ctx context.Context, tasks ...scheduler.ScheduledProjectionTask) (string, []interface{}, error) {
+	// several future patches with the same valid time need only one wake-up of the projection
+	query := s.build().
+		Insert(s.tableWithSchema(tables.ScheduledProjectionTasksTable.Name)).
+		Columns(tables.ScheduledProjectionTasksTable.AllColumns()...).
+		Suffix("ON CONFLICT ON CONSTRAINT scheduled_projection_tasks_pkey DO NOTHING")
+
+	for _, task := range tasks {
+		query = query.Values(
+			mapper.ScheduledProjectionTaskRowToArrayOfValues(
+				mapper.ToScheduledProjectionTaskRow(task))...,
+		)
+	}
+
+	return query.ToSql()
+}
+
+func (s SqlScheduler) DelTasks(ctx context.Context, tasks ...scheduler.ScheduledProjectionTask) (string, []interface{}, error) {
+	some := sq.Or{}
+	for _, task := range tasks {
+		some = append(some, sq.Eq{
+			tables.ScheduledProjectionTasksTable.TenantID:     task.Id.TenantID,
+			tables.ScheduledProjectionTasksTable.ProjectionID: task.Id.ProjectionID,
+			tables.ScheduledProjectionTasksTable.ValidTime:    mapper.MapToNanoseconds(task.Time),
+		})
+	}
+
+	return s.build().
+		Delete(s.tableWithSchema(tables.ScheduledProjectionTasksTable.Name)).
+		Where(some).
+		ToSql()
+}
@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func NewSqlSubscriptions(databaseSchema string, placeholder sq.PlaceholderFormat) SqlSubscriptions {
+	return SqlSubscriptions{SqlBuilder{
+		placeholder:    placeholder,
+		databaseSchema: databaseSchema,
+	}}
+}
+
+type SqlSubscriptions struct {
+	SqlBuilder
+}
+
+func (s SqlSubscriptions) GetCheckpoint(ctx context.Context, tenantID, subscriptionID string) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.SubscriptionCheckpointsTable.AllColumns()...).
+		From(s.tableWithSchema(tables.SubscriptionCheckpointsTable.Name)).
+		Where(sq.Eq{
+			tables.SubscriptionCheckpointsTable.TenantID:       tenantID,
+			tables.SubscriptionCheckpointsTable.SubscriptionID: subscriptionID,
+		}).
+		ToSql()
+}
+
+func (s SqlSubscriptions) SaveCheckpoint(ctx context.Context, checkpoint subscription.Checkpoint) (string, []interface{}, error) {
+	sub := tables.SubscriptionCheckpointsTable
+	return s.build().
+		Insert(s.tableWithSchema(sub.Name)).
+		Columns(sub.AllColumns()...).
+		Values(mapper.SubscriptionCheckpointRowToArrayOfValues(mapper.ToSubscriptionCheckpointRow(checkpoint))...).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s, %s) DO UPDATE SET %s = EXCLUDED.%s",
+			sub.TenantID, sub.SubscriptionID, sub.Position, sub.Position)).
+		ToSql()
+}
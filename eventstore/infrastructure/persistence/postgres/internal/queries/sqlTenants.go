@@ -0,0 +1,92 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"strings"
+	"time"
+)
+
+func NewSqlTenants(databaseSchema string, placeholder sq.PlaceholderFormat) SqlTenants {
+	return SqlTenants{SqlBuilder{
+		placeholder:    placeholder,
+		databaseSchema: databaseSchema,
+	}}
+}
+
+type SqlTenants struct {
+	SqlBuilder
+}
+
+func (s SqlTenants) Register(ctx context.Context, tenantID string, now time.Time) (string, []interface{}, error) {
+	row := mapper.ToTenantRow(tenant.DTO{TenantID: tenantID, Status: event.TenantActive, CreatedAt: now, UpdatedAt: now})
+	return s.build().
+		Insert(s.tableWithSchema(tables.TenantsTable.Name)).
+		Columns(tables.TenantsTable.AllColumns()...).
+		Values(mapper.TenantRowToArrayOfValues(row)...).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", tables.TenantsTable.TenantID)).
+		ToSql()
+}
+
+// Get locks the tenant shared, so that SetStatus waits for the end of the transaction.
+func (s SqlTenants) Get(ctx context.Context, tenantID string) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.TenantsTable.AllColumns()...).
+		From(s.tableWithSchema(tables.TenantsTable.Name)).
+		Where(sq.Eq{tables.TenantsTable.TenantID: tenantID}).
+		Suffix("FOR SHARE").
+		ToSql()
+}
+
+func (s SqlTenants) GetAll(ctx context.Context) (string, []interface{}, error) {
+	return s.build().
+		Select(tables.TenantsTable.AllColumns()...).
+		From(s.tableWithSchema(tables.TenantsTable.Name)).
+		OrderBy(tables.TenantsTable.TenantID).
+		ToSql()
+}
+
+func (s SqlTenants) SetStatus(ctx context.Context, tenantID string, status event.TenantStatus, now time.Time) (string, []interface{}, error) {
+	return s.build().
+		Update(s.tableWithSchema(tables.TenantsTable.Name)).
+		Set(tables.TenantsTable.Status, string(status)).
+		Set(tables.TenantsTable.UpdatedAt, mapper.MapToNanoseconds(now)).
+		Where(sq.Eq{tables.TenantsTable.TenantID: tenantID}).
+		ToSql()
+}
+
+// Purge deletes the data of the tenant from all tables with a single statement (data-modifying CTEs). The tenant
+// itself is deleted by the main statement.
+func (s SqlTenants) Purge(ctx context.Context, tenantID string) (string, []interface{}, error) {
+	tenantTables := []struct{ name, tenantID string }{
+		{tables.ProjectionsEventsTable.Name, tables.ProjectionsEventsTable.TenantID},
+		{tables.ProjectionsQuarantineTable.Name, tables.ProjectionsQuarantineTable.TenantID},
+		{tables.ScheduledProjectionTasksTable.Name, tables.ScheduledProjectionTasksTable.TenantID},
+		{tables.ProjectionsTable.Name, tables.ProjectionsTable.TenantID},
+		{tables.AggregateEventTable.Name, tables.AggregateEventTable.TenantID},
+		{tables.AggregateSnapsShotTable.Name, tables.AggregateSnapsShotTable.TenantID},
+		{tables.AggregateTable.Name, tables.AggregateTable.TenantID},
+		{tables.AggregatesArchivedTable.Name, tables.AggregatesArchivedTable.TenantID},
+		{tables.TenantPositionsTable.Name, tables.TenantPositionsTable.TenantID},
+		{tables.SubscriptionCheckpointsTable.Name, tables.SubscriptionCheckpointsTable.TenantID},
+		{tables.OutboxMessagesTable.Name, tables.OutboxMessagesTable.TenantID},
+	}
+
+	ctes := make([]string, len(tenantTables))
+	args := make([]interface{}, len(tenantTables))
+	for i, table := range tenantTables {
+		ctes[i] = fmt.Sprintf("purge_%d AS (DELETE FROM %s WHERE %s = ?)", i, s.tableWithSchema(table.name), table.tenantID)
+		args[i] = tenantID
+	}
+
+	return s.build().
+		Delete(s.tableWithSchema(tables.TenantsTable.Name)).
+		Prefix("WITH "+strings.Join(ctes, ", "), args...).
+		Where(sq.Eq{tables.TenantsTable.TenantID: tenantID}).
+		ToSql()
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"github.com/jackc/pgx/v5"
 	"time"
 )
 
@@ -118,6 +119,52 @@ func (s saver) Save(ctx context.Context, states []aggregate.DTO, events []event.
 	return nil
 }
 
+// Import writes the rows always with COPY, since imports are large. In contrast to Save, the snapshots are not checked
+// against the patch intervals, because they were already checked in the exporting store.
+func (s saver) Import(ctx context.Context, states []aggregate.DTO, events []event.PersistenceEvent, snapShots []event.PersistenceEvent) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "import (postgres)", map[string]interface{}{"numberOfEvents": len(events) + len(states) + len(snapShots)})
+	defer endSpan()
+
+	if err := s.copyFrom(ctx, tables.AggregateTable.Name, tables.AggregateTable.AllColumns(),
+		copy2.NewAggregateIterator(mapper.ToAggregateRows(states...)), len(states)); err != nil {
+		return fmt.Errorf("could not import aggregates: %w", err)
+	}
+
+	events, err := s.assignPositions(ctx, events)
+	if err != nil {
+		return err
+	}
+	if err = s.copyFrom(ctx, tables.AggregateEventTable.Name, tables.AggregateEventTable.AllColumnsWithPosition(),
+		copy2.NewAggregateEventWithPositionIterator(mapper.ToAggregateEventRows(events...)), len(events)); err != nil {
+		return fmt.Errorf("could not import events: %w", err)
+	}
+
+	if err = s.copyFrom(ctx, tables.AggregateSnapsShotTable.Name, tables.AggregateSnapsShotTable.AllColumns(),
+		copy2.NewAggregateEventIterator(mapper.ToAggregateEventRows(snapShots...)), len(snapShots)); err != nil {
+		return fmt.Errorf("could not import snapshots: %w", err)
+	}
+	return nil
+}
+
+func (s saver) copyFrom(ctx context.Context, table string, columns []string, rows pgx.CopyFromSource, numberOfRows int) error {
+	if numberOfRows == 0 {
+		return nil
+	}
+
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	inserted, err := tx.CopyFrom(ctx, []string{s.sql.GetDatabaseSchema(), table}, columns, rows)
+	if err != nil {
+		return err
+	}
+	if int(inserted) != numberOfRows {
+		return fmt.Errorf("only %v from %v rows would have been inserted", inserted, numberOfRows)
+	}
+	return nil
+}
+
 func (s saver) saveAggregates(ctx context.Context, states []aggregate.DTO) error {
 	ctx, endSpan := metrics.StartSpan(ctx, "save aggregates (postgres)", map[string]interface{}{"numberOfStates": len(states)})
 	defer endSpan()
@@ -144,6 +191,11 @@ func (s saver) saveAggregatesEvents(ctx context.Context, events []event.Persiste
 	ctx, endSpan := metrics.StartSpan(ctx, "save events (postgres)", map[string]interface{}{"numberOfStates": len(events)})
 	defer endSpan()
 
+	events, err := s.assignPositions(ctx, events)
+	if err != nil {
+		return err
+	}
+
 	switch len(events) {
 	case 0:
 	case 1, 2, 3, 4:
@@ -167,8 +219,8 @@ func (s saver) saveAggregatesEvents(ctx context.Context, events []event.Persiste
 		inserted, err := tx.CopyFrom(
 			ctx,
 			[]string{s.sql.GetDatabaseSchema(), tables.AggregateEventTable.Name},
-			tables.AggregateEventTable.AllColumns(),
-			copy2.NewAggregateEventIterator(mapper.ToAggregateEventRows(events...)))
+			tables.AggregateEventTable.AllColumnsWithPosition(),
+			copy2.NewAggregateEventWithPositionIterator(mapper.ToAggregateEventRows(events...)))
 		if err != nil {
 			return err
 		}
@@ -179,6 +231,51 @@ func (s saver) saveAggregatesEvents(ctx context.Context, events []event.Persiste
 	return nil
 }
 
+// assignPositions reserves the next positions in the global event log of each tenant and assigns them to the events.
+// The reservation locks the position of the tenant until the end of the transaction, so that concurrent transactions
+// of the same tenant get their positions in commit order (and a rollback leaves no gaps).
+func (s saver) assignPositions(ctx context.Context, events []event.PersistenceEvent) ([]event.PersistenceEvent, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "assign positions (postgres)", map[string]interface{}{"numberOfEvents": len(events)})
+	defer endSpan()
+
+	var tenantIDs []string
+	numberOfEvents := make(map[string]int)
+	for _, evt := range events {
+		if _, exists := numberOfEvents[evt.TenantID]; !exists {
+			tenantIDs = append(tenantIDs, evt.TenantID)
+		}
+		numberOfEvents[evt.TenantID]++
+	}
+
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// next free position per tenant
+	nextPositions := make(map[string]int64)
+	for _, tenantID := range tenantIDs {
+		stmt, args, err := s.sql.IncrementTenantPosition(ctx, tenantID, numberOfEvents[tenantID])
+		if err != nil {
+			return nil, err
+		}
+
+		var last int64
+		if err = pgxscan.Get(ctx, tx, &last, stmt, args...); err != nil {
+			return nil, fmt.Errorf("could not reserve positions for tenant %q: %w", tenantID, err)
+		}
+		nextPositions[tenantID] = last - int64(numberOfEvents[tenantID]) + 1
+	}
+
+	result := make([]event.PersistenceEvent, len(events))
+	for i, evt := range events {
+		evt.Position = nextPositions[evt.TenantID]
+		nextPositions[evt.TenantID]++
+		result[i] = evt
+	}
+	return result, nil
+}
+
 func (s saver) saveSnapShots(ctx context.Context, snapShots []event.PersistenceEvent) error {
 	ctx, endSpan := metrics.StartSpan(ctx, "save snapshots (postgres)", map[string]interface{}{"numberOfSnapshots": len(snapShots)})
 	defer endSpan()
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/dbtx"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func NewScheduler(dataBaseSchema string, placeholder sq.PlaceholderFormat, trans trans.Port) scheduler.Port {
+	querier := queries.NewSqlScheduler(dataBaseSchema, placeholder)
+	return &schedulerTasks{sql: querier, trans: trans}
+}
+
+type schedulerTasks struct {
+	sql   queries.SqlScheduler
+	trans trans.Port
+}
+
+func (s schedulerTasks) GetTx(ctx context.Context) (dbtx.DBTX, error) {
+	t, err := s.trans.GetTX(ctx)
+	return t.(dbtx.DBTX), err
+}
+
+func (s schedulerTasks) GetOpenTasks(ctx context.Context, id shared.ProjectionID) ([]scheduler.ScheduledProjectionTask, error) {
+	stmt, args, err := s.sql.GetOpenTasks(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tables.ScheduledProjectionTaskRow
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = pgxscan.Select(ctx, tx, &rows, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToScheduledProjectionTasks(rows...), nil
+}
+
+func (s schedulerTasks) AddTasks(ctx context.Context, tasks ...scheduler.ScheduledProjectionTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	stmt, args, err := s.sql.AddTasks(ctx, tasks...)
+	if err != nil {
+		return err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (s schedulerTasks) DelTasks(ctx context.Context, tasks ...scheduler.ScheduledProjectionTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	stmt, args, err := s.sql.DelTasks(ctx, tasks...)
+	if err != nil {
+		return err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"context"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/subscription"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/dbtx"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+)
+
+func NewSubscriptions(dataBaseSchema string, placeholder sq.PlaceholderFormat, trans trans.Port) subscription.Port {
+	querier := queries.NewSqlSubscriptions(dataBaseSchema, placeholder)
+	return &subscriptions{sql: querier, trans: trans}
+}
+
+type subscriptions struct {
+	sql   queries.SqlSubscriptions
+	trans trans.Port
+}
+
+func (s subscriptions) GetTx(ctx context.Context) (dbtx.DBTX, error) {
+	t, err := s.trans.GetTX(ctx)
+	return t.(dbtx.DBTX), err
+}
+
+func (s subscriptions) GetCheckpoint(ctx context.Context, tenantID, subscriptionID string) (subscription.Checkpoint, bool, error) {
+	stmt, args, err := s.sql.GetCheckpoint(ctx, tenantID, subscriptionID)
+	if err != nil {
+		return subscription.Checkpoint{}, false, err
+	}
+
+	var rows []tables.SubscriptionCheckpointRow
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return subscription.Checkpoint{}, false, err
+	}
+	err = pgxscan.Select(ctx, tx, &rows, stmt, args...)
+	if err != nil {
+		return subscription.Checkpoint{}, false, err
+	}
+	if len(rows) == 0 {
+		return subscription.Checkpoint{}, false, nil
+	}
+
+	return mapper.ToSubscriptionCheckpoint(rows[0]), true, nil
+}
+
+func (s subscriptions) SaveCheckpoint(ctx context.Context, checkpoint subscription.Checkpoint) error {
+	stmt, args, err := s.sql.SaveCheckpoint(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	tx, err := s.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
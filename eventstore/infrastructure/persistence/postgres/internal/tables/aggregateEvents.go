@@ -16,6 +16,9 @@ type AggregateEventRow struct {
 	ValidTime       int64           `db:"valid_time"`
 	FromMigration   bool            `db:"from_migration"`
 	Data            json.RawMessage `db:"data"`
+	SchemaVersion   int64           `db:"schema_version"`
+	Codec           string          `db:"codec"`
+	Position        int64           `db:"position"`
 }
 
 type AggregatePersistentEventLoadRow struct {
@@ -36,11 +39,20 @@ type AggregatePersistentEventsTableSchema struct {
 	ValidTime       string
 	FromMigration   string
 	Data            string
+	SchemaVersion   string
+	Codec           string
+	Position        string
 }
 
 // AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
 func (a AggregatePersistentEventsTableSchema) AllColumns() []string {
-	return []string{a.ID, a.TenantID, a.AggregateType, a.AggregateID, a.Version, a.Type, a.Class, a.TransactionTime, a.ValidTime, a.FromMigration, a.Data}
+	return []string{a.ID, a.TenantID, a.AggregateType, a.AggregateID, a.Version, a.Type, a.Class, a.TransactionTime, a.ValidTime, a.FromMigration, a.Data, a.SchemaVersion, a.Codec}
+}
+
+// AllColumnsWithPosition the position only exists in the aggregates events table (not in the snapshot or projection
+// events tables, which share the columns of AllColumns).
+func (a AggregatePersistentEventsTableSchema) AllColumnsWithPosition() []string {
+	return append(a.AllColumns(), a.Position)
 }
 
 var AggregateEventTable = AggregatePersistentEventsTableSchema{
@@ -56,4 +68,7 @@ var AggregateEventTable = AggregatePersistentEventsTableSchema{
 	ValidTime:       "valid_time",
 	FromMigration:   "from_migration",
 	Data:            "data",
+	SchemaVersion:   "schema_version",
+	Codec:           "codec",
+	Position:        "position",
 }
@@ -13,4 +13,6 @@ var AggregateSnapsShotTable = AggregatePersistentEventsTableSchema{
 	ValidTime:       "valid_time",
 	FromMigration:   "from_migration",
 	Data:            "data",
+	SchemaVersion:   "schema_version",
+	Codec:           "codec",
 }
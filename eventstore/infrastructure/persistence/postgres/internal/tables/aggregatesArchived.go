@@ -0,0 +1,30 @@
+package tables
+
+type AggregateArchivedRow struct {
+	TenantID      string `db:"tenant_id"`
+	AggregateType string `db:"aggregate_type"`
+	AggregateID   string `db:"aggregate_id"`
+	ArchiveTime   int64  `db:"archive_time"`
+}
+
+var AggregatesArchivedTable = AggregatesArchivedTableSchema{
+	Name:          "aggregates_archived",
+	TenantID:      "tenant_id",
+	AggregateType: "aggregate_type",
+	AggregateID:   "aggregate_id",
+	ArchiveTime:   "archive_time",
+}
+
+type AggregatesArchivedTableSchema struct {
+	Name string
+
+	TenantID      string
+	AggregateType string
+	AggregateID   string
+	ArchiveTime   string
+}
+
+// AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
+func (a AggregatesArchivedTableSchema) AllColumns() []string {
+	return []string{a.TenantID, a.AggregateType, a.AggregateID, a.ArchiveTime}
+}
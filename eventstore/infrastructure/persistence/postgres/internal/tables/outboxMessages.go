@@ -0,0 +1,46 @@
+package tables
+
+import (
+	"encoding/json"
+)
+
+type OutboxMessageRow struct {
+	TenantID        string          `db:"tenant_id"`
+	EventID         string          `db:"event_id"`
+	TransactionTime int64           `db:"transaction_time"`
+	Status          string          `db:"status"`
+	Event           json.RawMessage `db:"event"`
+	Attempts        int64           `db:"attempts"`
+	NextAttempt     int64           `db:"next_attempt"`
+	LastError       string          `db:"last_error"`
+}
+
+var OutboxMessagesTable = OutboxMessagesTableSchema{
+	Name:            "outbox_messages",
+	TenantID:        "tenant_id",
+	EventID:         "event_id",
+	TransactionTime: "transaction_time",
+	Status:          "status",
+	Event:           "event",
+	Attempts:        "attempts",
+	NextAttempt:     "next_attempt",
+	LastError:       "last_error",
+}
+
+type OutboxMessagesTableSchema struct {
+	Name string
+
+	TenantID        string
+	EventID         string
+	TransactionTime string
+	Status          string
+	Event           string
+	Attempts        string
+	NextAttempt     string
+	LastError       string
+}
+
+// AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
+func (a OutboxMessagesTableSchema) AllColumns() []string {
+	return []string{a.TenantID, a.EventID, a.TransactionTime, a.Status, a.Event, a.Attempts, a.NextAttempt, a.LastError}
+}
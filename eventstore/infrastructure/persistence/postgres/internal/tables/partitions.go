@@ -0,0 +1,40 @@
+package tables
+
+type PartitionRow struct {
+	Partition   string `db:"partition"`
+	Level       int    `db:"level"`
+	Bound       string `db:"bound"`
+	ParentBound string `db:"parent_bound"`
+	Rows        int64  `db:"rows"`
+	Bytes       int64  `db:"bytes"`
+}
+
+type PartitionedTableSchema struct {
+	Name string
+	// Key is the column of the list partitions below the default partition.
+	Key string
+	// SubKey is the column of the optional sub-partitions.
+	SubKey string
+	// PrimaryKey are the columns of the primary key without the sub key. It is empty, if the primary key already
+	// contains the sub key.
+	PrimaryKey []string
+}
+
+// PartitionedAggregateTables are partitioned by aggregate type, PartitionedProjectionTables by projection.
+var PartitionedAggregateTables = []PartitionedTableSchema{
+	{Name: AggregateTable.Name, Key: AggregateTable.AggregateType, SubKey: AggregateTable.TenantID},
+	{Name: AggregateEventTable.Name, Key: AggregateEventTable.AggregateType, SubKey: AggregateEventTable.TenantID,
+		PrimaryKey: []string{AggregateEventTable.ID, AggregateEventTable.AggregateType}},
+	{Name: AggregateSnapsShotTable.Name, Key: AggregateSnapsShotTable.AggregateType, SubKey: AggregateSnapsShotTable.TenantID,
+		PrimaryKey: []string{AggregateSnapsShotTable.ID, AggregateSnapsShotTable.AggregateType}},
+}
+
+var PartitionedProjectionTables = []PartitionedTableSchema{
+	{Name: ProjectionsEventsTable.Name, Key: ProjectionsEventsTable.ProjectionID, SubKey: ProjectionsEventsTable.TenantID,
+		PrimaryKey: []string{ProjectionsEventsTable.ProjectionID, ProjectionsEventsTable.ID}},
+}
+
+type UnpartitionedRow struct {
+	TenantID string `db:"tenant_id"`
+	Key      string `db:"key"`
+}
@@ -6,7 +6,11 @@ type ProjectionsRow struct {
 	TenantID     string    `db:"tenant_id"`
 	ProjectionID string    `db:"projection_id"`
 	State        string    `db:"state"`
+	Generation   int       `db:"generation"`
 	UpdatedAt    time.Time `db:"updated_at"`
+	LastRunAt    int64     `db:"last_run_at"`
+	LastError    string    `db:"last_error"`
+	Failures     int       `db:"failures"`
 }
 
 var ProjectionsTable = ProjectionsTableSchema{
@@ -14,7 +18,11 @@ var ProjectionsTable = ProjectionsTableSchema{
 	TenantID:     "tenant_id",
 	ProjectionID: "projection_id",
 	State:        "state",
+	Generation:   "generation",
 	UpdatedAt:    "updated_at",
+	LastRunAt:    "last_run_at",
+	LastError:    "last_error",
+	Failures:     "failures",
 }
 
 type ProjectionsTableSchema struct {
@@ -23,13 +31,17 @@ type ProjectionsTableSchema struct {
 	TenantID     string
 	ProjectionID string
 	State        string
+	Generation   string
 	UpdatedAt    string
+	LastRunAt    string
+	LastError    string
+	Failures     string
 }
 
 func (a ProjectionsTableSchema) AllColumns() []string {
-	return []string{a.TenantID, a.ProjectionID, a.State, a.UpdatedAt}
+	return []string{a.TenantID, a.ProjectionID, a.State, a.Generation, a.UpdatedAt, a.LastRunAt, a.LastError, a.Failures}
 }
 
 func (a ProjectionsTableSchema) AllInsertColumns() []string {
-	return []string{a.TenantID, a.ProjectionID, a.State}
+	return []string{a.TenantID, a.ProjectionID, a.State, a.Generation}
 }
@@ -19,11 +19,13 @@ type ProjectionsEventsTableSchema struct {
 	ValidTime       string
 	FromMigration   string
 	Data            string
+	SchemaVersion   string
+	Codec           string
 }
 
 // AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
 func (a ProjectionsEventsTableSchema) AllColumns() []string {
-	return []string{a.ProjectionID, a.ID, a.TenantID, a.AggregateType, a.AggregateID, a.Version, a.Type, a.Class, a.TransactionTime, a.ValidTime, a.FromMigration, a.Data}
+	return []string{a.ProjectionID, a.ID, a.TenantID, a.AggregateType, a.AggregateID, a.Version, a.Type, a.Class, a.TransactionTime, a.ValidTime, a.FromMigration, a.Data, a.SchemaVersion, a.Codec}
 }
 
 var ProjectionsEventsTable = ProjectionsEventsTableSchema{
@@ -40,9 +42,12 @@ var ProjectionsEventsTable = ProjectionsEventsTableSchema{
 	TransactionTime: "transaction_time",
 	FromMigration:   "from_migration",
 	Data:            "data",
+	SchemaVersion:   "schema_version",
+	Codec:           "codec",
 }
 
 type ProjectionsEventsLoadRow struct {
 	ProjectionsEventRow
-	State string `db:"state"` //must be equal to the field state in projections table
+	State      string `db:"state"`      //must be equal to the field state in projections table
+	Generation int    `db:"generation"` //must be equal to the field generation in projections table
 }
@@ -0,0 +1,43 @@
+package tables
+
+import (
+	"encoding/json"
+)
+
+type ProjectionsQuarantineRow struct {
+	TenantID      string          `db:"tenant_id"`
+	ProjectionID  string          `db:"projection_id"`
+	EventID       string          `db:"event_id"`
+	ValidTime     int64           `db:"valid_time"`
+	Event         json.RawMessage `db:"event"`
+	LastError     string          `db:"last_error"`
+	QuarantinedAt int64           `db:"quarantined_at"`
+}
+
+var ProjectionsQuarantineTable = ProjectionsQuarantineTableSchema{
+	Name:          "projections_quarantine",
+	TenantID:      "tenant_id",
+	ProjectionID:  "projection_id",
+	EventID:       "event_id",
+	ValidTime:     "valid_time",
+	Event:         "event",
+	LastError:     "last_error",
+	QuarantinedAt: "quarantined_at",
+}
+
+type ProjectionsQuarantineTableSchema struct {
+	Name string
+
+	TenantID      string
+	ProjectionID  string
+	EventID       string
+	ValidTime     string
+	Event         string
+	LastError     string
+	QuarantinedAt string
+}
+
+// AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
+func (a ProjectionsQuarantineTableSchema) AllColumns() []string {
+	return []string{a.TenantID, a.ProjectionID, a.EventID, a.ValidTime, a.Event, a.LastError, a.QuarantinedAt}
+}
@@ -0,0 +1,19 @@
+package tables
+
+type ProjectionsQueueStatisticsRow struct {
+	TenantID              string `db:"tenant_id"`
+	ProjectionID          string `db:"projection_id"`
+	QueuedEvents          int    `db:"queued_events"`
+	OldestTransactionTime int64  `db:"oldest_transaction_time"`
+}
+
+var ProjectionsQueueStatistics = ProjectionsQueueStatisticsSchema{
+	QueuedEvents:          "queued_events",
+	OldestTransactionTime: "oldest_transaction_time",
+}
+
+// ProjectionsQueueStatisticsSchema contains the aliases of the aggregated columns of the projection queue.
+type ProjectionsQueueStatisticsSchema struct {
+	QueuedEvents          string
+	OldestTransactionTime string
+}
@@ -0,0 +1,30 @@
+package tables
+
+type ScheduledProjectionTaskRow struct {
+	TenantID     string `db:"tenant_id"`
+	ProjectionID string `db:"projection_id"`
+	ValidTime    int64  `db:"valid_time"`
+	Version      int64  `db:"version"`
+}
+
+var ScheduledProjectionTasksTable = ScheduledProjectionTasksTableSchema{
+	Name:         "scheduled_projection_tasks",
+	TenantID:     "tenant_id",
+	ProjectionID: "projection_id",
+	ValidTime:    "valid_time",
+	Version:      "version",
+}
+
+type ScheduledProjectionTasksTableSchema struct {
+	Name string
+
+	TenantID     string
+	ProjectionID string
+	ValidTime    string
+	Version      string
+}
+
+// AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
+func (a ScheduledProjectionTasksTableSchema) AllColumns() []string {
+	return []string{a.TenantID, a.ProjectionID, a.ValidTime, a.Version}
+}
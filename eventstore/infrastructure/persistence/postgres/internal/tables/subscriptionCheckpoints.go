@@ -0,0 +1,27 @@
+package tables
+
+type SubscriptionCheckpointRow struct {
+	TenantID       string `db:"tenant_id"`
+	SubscriptionID string `db:"subscription_id"`
+	Position       int64  `db:"position"`
+}
+
+var SubscriptionCheckpointsTable = SubscriptionCheckpointsTableSchema{
+	Name:           "subscription_checkpoints",
+	TenantID:       "tenant_id",
+	SubscriptionID: "subscription_id",
+	Position:       "position",
+}
+
+type SubscriptionCheckpointsTableSchema struct {
+	Name string
+
+	TenantID       string
+	SubscriptionID string
+	Position       string
+}
+
+// AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
+func (a SubscriptionCheckpointsTableSchema) AllColumns() []string {
+	return []string{a.TenantID, a.SubscriptionID, a.Position}
+}
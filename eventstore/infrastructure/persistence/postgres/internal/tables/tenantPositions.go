@@ -0,0 +1,24 @@
+package tables
+
+type TenantPositionRow struct {
+	TenantID string `db:"tenant_id"`
+	Position int64  `db:"position"`
+}
+
+var TenantPositionsTable = TenantPositionsTableSchema{
+	Name:     "tenant_positions",
+	TenantID: "tenant_id",
+	Position: "position",
+}
+
+type TenantPositionsTableSchema struct {
+	Name string
+
+	TenantID string
+	Position string
+}
+
+// AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
+func (a TenantPositionsTableSchema) AllColumns() []string {
+	return []string{a.TenantID, a.Position}
+}
@@ -0,0 +1,30 @@
+package tables
+
+type TenantRow struct {
+	TenantID  string `db:"tenant_id"`
+	Status    string `db:"status"`
+	CreatedAt int64  `db:"created_at"`
+	UpdatedAt int64  `db:"updated_at"`
+}
+
+var TenantsTable = TenantsTableSchema{
+	Name:      "tenants",
+	TenantID:  "tenant_id",
+	Status:    "status",
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+}
+
+type TenantsTableSchema struct {
+	Name string
+
+	TenantID  string
+	Status    string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// AllColumns if you change order of columns you must adjust the function ...ToArrayOfValues in mapper as well.
+func (a TenantsTableSchema) AllColumns() []string {
+	return []string{a.TenantID, a.Status, a.CreatedAt, a.UpdatedAt}
+}
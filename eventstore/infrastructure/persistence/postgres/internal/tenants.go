@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/tenant"
+	trans "github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/dbtx"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/mapper"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/queries"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres/internal/tables"
+	"time"
+)
+
+func NewTenants(dataBaseSchema string, placeholder sq.PlaceholderFormat, trans trans.Port) tenant.Port {
+	querier := queries.NewSqlTenants(dataBaseSchema, placeholder)
+	return &tenants{sql: querier, trans: trans}
+}
+
+type tenants struct {
+	sql   queries.SqlTenants
+	trans trans.Port
+}
+
+func (t tenants) GetTx(ctx context.Context) (dbtx.DBTX, error) {
+	tx, err := t.trans.GetTX(ctx)
+	return tx.(dbtx.DBTX), err
+}
+
+func (t tenants) Register(ctx context.Context, tenantID string, now time.Time) (tenant.DTO, error) {
+	stmt, args, err := t.sql.Register(ctx, tenantID, now)
+	if err != nil {
+		return tenant.DTO{}, err
+	}
+	if err = t.exec(ctx, stmt, args...); err != nil {
+		return tenant.DTO{}, err
+	}
+
+	registered, found, err := t.Get(ctx, tenantID)
+	if err != nil {
+		return tenant.DTO{}, err
+	}
+	if !found {
+		return tenant.DTO{}, fmt.Errorf("tenant %q not found after registration", tenantID)
+	}
+	return registered, nil
+}
+
+func (t tenants) Get(ctx context.Context, tenantID string) (tenant.DTO, bool, error) {
+	stmt, args, err := t.sql.Get(ctx, tenantID)
+	if err != nil {
+		return tenant.DTO{}, false, err
+	}
+	result, err := t.selectTenants(ctx, stmt, args...)
+	if err != nil || len(result) == 0 {
+		return tenant.DTO{}, false, err
+	}
+	return result[0], true, nil
+}
+
+func (t tenants) GetAll(ctx context.Context) ([]tenant.DTO, error) {
+	stmt, args, err := t.sql.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.selectTenants(ctx, stmt, args...)
+}
+
+func (t tenants) SetStatus(ctx context.Context, tenantID string, status event.TenantStatus, now time.Time) error {
+	stmt, args, err := t.sql.SetStatus(ctx, tenantID, status, now)
+	if err != nil {
+		return err
+	}
+	return t.exec(ctx, stmt, args...)
+}
+
+func (t tenants) Purge(ctx context.Context, tenantID string) error {
+	stmt, args, err := t.sql.Purge(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return t.exec(ctx, stmt, args...)
+}
+
+func (t tenants) exec(ctx context.Context, stmt string, args ...interface{}) error {
+	tx, err := t.GetTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (t tenants) selectTenants(ctx context.Context, stmt string, args ...interface{}) ([]tenant.DTO, error) {
+	var rows []tables.TenantRow
+	tx, err := t.GetTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = pgxscan.Select(ctx, tx, &rows, stmt, args...); err != nil {
+		return nil, err
+	}
+	return mapper.ToTenantDTOs(rows...), nil
+}
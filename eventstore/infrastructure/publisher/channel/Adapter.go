@@ -0,0 +1,32 @@
+package channel
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/publisher"
+)
+
+// New creates an in-process publisher, which passes the events of the outbox to a channel (e.g. for tests). Publish
+// blocks, if the buffer of the channel is full.
+func New(bufferSize int) *Adapter {
+	return &Adapter{events: make(chan event.PersistenceEvent, bufferSize)}
+}
+
+var _ publisher.Port = (*Adapter)(nil)
+
+type Adapter struct {
+	events chan event.PersistenceEvent
+}
+
+func (a *Adapter) Publish(ctx context.Context, evt event.PersistenceEvent) error {
+	select {
+	case a.events <- evt:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Adapter) Events() <-chan event.PersistenceEvent {
+	return a.events
+}
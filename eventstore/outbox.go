@@ -0,0 +1,21 @@
+package eventstore
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+)
+
+func (e eventStore) GetDeadLetters(ctx context.Context, tenantID string) ([]event.DeadLetter, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetDeadLetters (store)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	return e.outboxer.GetDeadLetters(ctx, tenantID)
+}
+
+func (e eventStore) RequeueDeadLetters(ctx context.Context, tenantID string, eventIDs ...string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "RequeueDeadLetters (store)", map[string]interface{}{"tenantID": tenantID, "eventIDs": eventIDs})
+	defer endSpan()
+
+	return e.outboxer.RequeueDeadLetters(ctx, tenantID, eventIDs...)
+}
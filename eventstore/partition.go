@@ -0,0 +1,21 @@
+package eventstore
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+)
+
+func (e eventStore) MigrateDefaultPartitions(ctx context.Context) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "MigrateDefaultPartitions (store)", nil)
+	defer endSpan()
+
+	return e.partitions.MigrateDefaultPartitions(ctx)
+}
+
+func (e eventStore) GetPartitionSizes(ctx context.Context) ([]event.PartitionSize, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetPartitionSizes (store)", nil)
+	defer endSpan()
+
+	return e.partitions.GetPartitionSizes(ctx)
+}
@@ -47,6 +47,9 @@ func (e eventStore) StopProjection(ctx context.Context, tenantID, projectionID s
 func (e eventStore) RebuildAllProjection(ctx context.Context, tenantID string) chan error {
 	ctx, endSpan := metrics.StartSpan(ctx, "RebuildAllProjection (store)", map[string]interface{}{"tenantID": tenantID})
 	defer endSpan()
+	if errCh := e.rehydrateTenant(ctx, tenantID); errCh != nil {
+		return errCh
+	}
 	errCh := make(chan error, len(e.registries.ProjectionRegistry.All())+1)
 
 	go func(resultCh chan error) {
@@ -70,6 +73,9 @@ func (e eventStore) RebuildAllProjection(ctx context.Context, tenantID string) c
 func (e eventStore) RebuildAllProjectionSince(ctx context.Context, tenantID string, sinceTime time.Time) chan error {
 	ctx, endSpan := metrics.StartSpan(ctx, "RebuildAllProjectionSince (store)", map[string]interface{}{"tenantID": tenantID, "sinceTime": sinceTime})
 	defer endSpan()
+	if errCh := e.rehydrateTenant(ctx, tenantID); errCh != nil {
+		return errCh
+	}
 	errCh := make(chan error, len(e.registries.ProjectionRegistry.All())+1)
 
 	go func(resultCh chan error) {
@@ -95,6 +101,9 @@ func (e eventStore) RebuildProjection(ctx context.Context, tenantID, projectionI
 	ctx, endSpan := metrics.StartSpan(ctx, "RebuildProjection (store)", map[string]interface{}{"tenantID": tenantID, "projectionID": projectionID})
 	defer endSpan()
 
+	if errCh := e.rehydrateTenant(ctx, tenantID); errCh != nil {
+		return errCh
+	}
 	return e.projecter.Rebuild(ctx, shared.ProjectionID{TenantID: tenantID, ProjectionID: projectionID}, time.Time{})
 }
 
@@ -102,9 +111,22 @@ func (e eventStore) RebuildProjectionSince(ctx context.Context, tenantID, projec
 	ctx, endSpan := metrics.StartSpan(ctx, "RebuildProjectionSince (store)", map[string]interface{}{"tenantID": tenantID, "projectionID": projectionID, "sinceTime": sinceTime})
 	defer endSpan()
 
+	if errCh := e.rehydrateTenant(ctx, tenantID); errCh != nil {
+		return errCh
+	}
 	return e.projecter.Rebuild(ctx, shared.ProjectionID{TenantID: tenantID, ProjectionID: projectionID}, sinceTime)
 }
 
+func (e eventStore) RebuildProjectionBlueGreen(ctx context.Context, tenantID, projectionID string) chan error {
+	ctx, endSpan := metrics.StartSpan(ctx, "RebuildProjectionBlueGreen (store)", map[string]interface{}{"tenantID": tenantID, "projectionID": projectionID})
+	defer endSpan()
+
+	if errCh := e.rehydrateTenant(ctx, tenantID); errCh != nil {
+		return errCh
+	}
+	return e.projecter.RebuildBlueGreen(ctx, shared.ProjectionID{TenantID: tenantID, ProjectionID: projectionID})
+}
+
 func (e eventStore) GetProjectionStates(ctx context.Context, tenantID string, projectionID ...string) ([]event.ProjectionState, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "GetProjectionState", map[string]interface{}{"tenantID": tenantID, "projectionID": projectionID})
 	defer endSpan()
@@ -119,9 +141,58 @@ func (e eventStore) GetAllProjectionStates(ctx context.Context, tenantID string)
 	return e.projecter.GetAllProjectionStates(ctx, tenantID)
 }
 
+func (e eventStore) GetProjectionHealth(ctx context.Context, tenantID string, projectionID ...string) ([]event.ProjectionHealth, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetProjectionHealth (store)", map[string]interface{}{"tenantID": tenantID, "projectionIDs": projectionID})
+	defer endSpan()
+
+	return e.projecter.GetProjectionHealth(ctx, tenantID, projectionID...)
+}
+
+func (e eventStore) GetAllProjectionHealth(ctx context.Context, tenantID string) ([]event.ProjectionHealth, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetAllProjectionHealth (store)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	return e.projecter.GetAllProjectionHealth(ctx, tenantID)
+}
+
 func (e eventStore) RemoveProjection(ctx context.Context, projectionID string) error {
 	ctx, endSpan := metrics.StartSpan(ctx, "RemoveProjection", map[string]interface{}{"projectionID": projectionID})
 	defer endSpan()
 
 	return e.projecter.RemoveProjection(ctx, projectionID)
 }
+
+func (e eventStore) GetQuarantinedEvents(ctx context.Context, tenantID, projectionID string) ([]event.QuarantinedEvent, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetQuarantinedEvents (store)", map[string]interface{}{"tenantID": tenantID, "projectionID": projectionID})
+	defer endSpan()
+
+	return e.projecter.GetQuarantinedEvents(ctx, shared.ProjectionID{TenantID: tenantID, ProjectionID: projectionID})
+}
+
+func (e eventStore) ReplayQuarantinedEvents(ctx context.Context, tenantID, projectionID string, eventIDs ...string) (chan error, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "ReplayQuarantinedEvents (store)", map[string]interface{}{"tenantID": tenantID, "projectionID": projectionID, "eventIDs": eventIDs})
+	defer endSpan()
+
+	return e.projecter.ReplayQuarantinedEvents(ctx, shared.ProjectionID{TenantID: tenantID, ProjectionID: projectionID}, eventIDs...)
+}
+
+func (e eventStore) DiscardQuarantinedEvents(ctx context.Context, tenantID, projectionID string, eventIDs ...string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "DiscardQuarantinedEvents (store)", map[string]interface{}{"tenantID": tenantID, "projectionID": projectionID, "eventIDs": eventIDs})
+	defer endSpan()
+
+	return e.projecter.DiscardQuarantinedEvents(ctx, shared.ProjectionID{TenantID: tenantID, ProjectionID: projectionID}, eventIDs...)
+}
+
+// rehydrateTenant restores the archived streams of the tenant before a rebuild, because the projections read the
+// events of the event store only (see services.StreamArchiveService.RehydrateTenant). It returns nil on success,
+// otherwise a closed channel with the error.
+func (e eventStore) rehydrateTenant(ctx context.Context, tenantID string) chan error {
+	err := e.streamArchiver.RehydrateTenant(ctx, tenantID)
+	if err == nil {
+		return nil
+	}
+	errCh := make(chan error, 1)
+	errCh <- fmt.Errorf("rebuild failed for tenant %q: %w", tenantID, err)
+	close(errCh)
+	return errCh
+}
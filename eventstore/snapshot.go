@@ -20,3 +20,17 @@ func (e eventStore) GetPatchFreePeriodsForInterval(ctx context.Context, tenantID
 
 	return e.loader.GetPatchFreePeriodsForInterval(ctx, tenantID, aggregateType, aggregateID, start, end)
 }
+
+func (e eventStore) GetSnapshotCoverage(ctx context.Context, tenantID, aggregateType string) (event.SnapshotCoverage, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "GetSnapshotCoverage (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType})
+	defer endSpan()
+
+	return e.snapshotter.GetSnapshotCoverage(ctx, tenantID, aggregateType)
+}
+
+func (e eventStore) WaitForSnapshots(ctx context.Context) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "WaitForSnapshots (store)", nil)
+	defer endSpan()
+
+	return e.snapshotter.WaitForSnapshotsInBackground(ctx)
+}
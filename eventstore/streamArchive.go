@@ -0,0 +1,20 @@
+package eventstore
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+)
+
+func (e eventStore) ArchiveClosedStreams(ctx context.Context) (int, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "ArchiveClosedStreams (store)", nil)
+	defer endSpan()
+
+	return e.streamArchiver.ArchiveClosedStreams(ctx)
+}
+
+func (e eventStore) RestoreArchivedStream(ctx context.Context, tenantID, aggregateType, aggregateID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "RestoreArchivedStream (store)", map[string]interface{}{"tenantID": tenantID, "aggregateType": aggregateType, "aggregateID": aggregateID})
+	defer endSpan()
+
+	return e.streamArchiver.RestoreArchivedStream(ctx, tenantID, aggregateType, aggregateID)
+}
@@ -0,0 +1,14 @@
+package eventstore
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+)
+
+func (e eventStore) Subscribe(ctx context.Context, tenantID, subscriptionID string, from int64, handler event.SubscriptionHandler, eventTypes ...string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "Subscribe (store)", map[string]interface{}{"tenantID": tenantID, "subscriptionID": subscriptionID, "from": from, "eventTypes": eventTypes})
+	defer endSpan()
+
+	return e.subscriber.Subscribe(ctx, tenantID, subscriptionID, from, handler, eventTypes...)
+}
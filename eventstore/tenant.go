@@ -0,0 +1,50 @@
+package eventstore
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
+	"io"
+)
+
+func (e eventStore) ExportTenant(ctx context.Context, tenantID string, w io.Writer) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "ExportTenant (store)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	return e.archiver.ExportTenant(ctx, tenantID, w)
+}
+
+func (e eventStore) ImportTenant(ctx context.Context, r io.Reader) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "ImportTenant (store)", nil)
+	defer endSpan()
+
+	return e.archiver.ImportTenant(ctx, r)
+}
+
+func (e eventStore) ListTenants(ctx context.Context) ([]event.Tenant, error) {
+	ctx, endSpan := metrics.StartSpan(ctx, "ListTenants (store)", nil)
+	defer endSpan()
+
+	return e.tenants.ListTenants(ctx)
+}
+
+func (e eventStore) SuspendTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "SuspendTenant (store)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	return e.tenants.SuspendTenant(ctx, tenantID)
+}
+
+func (e eventStore) ResumeTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "ResumeTenant (store)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	return e.tenants.ResumeTenant(ctx, tenantID)
+}
+
+func (e eventStore) PurgeTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "PurgeTenant (store)", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+
+	return e.tenants.PurgeTenant(ctx, tenantID)
+}
@@ -129,3 +129,17 @@ func (p *Projection) FinishRebuild(ctx context.Context, tenantID string) error {
 	logger.Info("Finished rebuild of projection %q for tenant %q", p.ID(), tenantID)
 	return nil
 }
+
+// PurgeTenant drops the read model of the tenant, after the tenant was purged in the event store (see
+// event.ProjectionPurge).
+func (p *Projection) PurgeTenant(ctx context.Context, tenantID string) error {
+	ctx, endSpan := metrics.StartSpan(ctx, "purge-tenant-of-item-projection", map[string]interface{}{"tenantID": tenantID})
+	defer endSpan()
+	err := p.persistence.PurgeTenant(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("could not purge tenant of %q projection: %w", p.ID(), err)
+	}
+
+	logger.Info("Purged tenant %q of projection %q", tenantID, p.ID())
+	return nil
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/instrumentation/port/metrics"
-	"reflect"
 	"time"
 )
 
@@ -16,18 +15,20 @@ func NewReadRepository(ctx context.Context, eventStore event.EventStore) *ReadRe
 
 	return &ReadRepository{
 		eventStore: eventStore,
+		items:      event.NewRepository[item.Item](eventStore, event.EventApplierFunc[item.Item](item.LoadFromEventStream)),
 	}
 }
 
 type ReadRepository struct {
 	eventStore event.EventStore
+	items      *event.Repository[item.Item]
 }
 
 func (r *ReadRepository) GetAllItems(ctx context.Context, tenantID string) ([]item.Item, error) {
 	ctx, endSpan := metrics.StartSpan(ctx, "get-items", map[string]interface{}{"tenantID": tenantID})
 	defer endSpan()
 
-	eventStreams, err := event.LoadAllOfAggregateTypeAsOf(ctx, tenantID, reflect.TypeOf(item.Item{}).Name(), time.Now(), r.eventStore)
+	eventStreams, err := event.LoadAllOfAggregateTypeAsOf(ctx, tenantID, r.items.AggregateType(), time.Now(), r.eventStore)
 	if err != nil {
 		return nil, fmt.Errorf("could not load accounts from event store: %w", err)
 	}
@@ -48,9 +49,9 @@ func (r *ReadRepository) GetItem(ctx context.Context, tenantID, itemID string, s
 	if start.IsZero() {
 		start = time.Now()
 	}
-	eventStream, version, err := event.LoadAggregateAsOf(ctx, tenantID, reflect.TypeOf(item.Item{}).Name(), itemID, start, r.eventStore)
+	i, err := r.items.GetAsOf(ctx, tenantID, itemID, start)
 	if err != nil {
 		return item.Item{}, fmt.Errorf("could not load item %q from event store: %w", itemID, err)
 	}
-	return item.LoadFromEventStream(version, eventStream...)
+	return i, nil
 }
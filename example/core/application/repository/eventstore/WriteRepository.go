@@ -12,24 +12,19 @@ func NewWriteRepository(ctx context.Context, eventStore event.EventStore) *Write
 	defer endSpan()
 
 	return &WriteRepository{
-		eventStore: eventStore,
+		items: event.NewRepository[item.Item](eventStore, event.EventApplierFunc[item.Item](item.LoadFromEventStream)),
 	}
 }
 
 type WriteRepository struct {
-	eventStore event.EventStore
+	items *event.Repository[item.Item]
 }
 
 func (r *WriteRepository) SaveItems(ctx context.Context, items ...item.Item) error {
 	ctx, endSpan := metrics.StartSpan(ctx, "save-items", map[string]interface{}{"numberOfItems": len(items)})
 	defer endSpan()
 
-	var aggregates []event.AggregateWithEventSourcingSupport
-
-	for _, i := range items {
-		aggregates = append(aggregates, i)
-	}
-	_, err := event.SaveAggregates(ctx, r.eventStore, aggregates...)
+	_, err := r.items.SaveAll(ctx, items...)
 	return err
 }
 
@@ -37,6 +32,6 @@ func (r *WriteRepository) SaveItem(ctx context.Context, item item.Item) error {
 	ctx, endSpan := metrics.StartSpan(ctx, "save-item", nil)
 	defer endSpan()
 
-	_, err := event.SaveAggregates(ctx, r.eventStore, item)
+	_, err := r.items.SaveAll(ctx, item)
 	return err
 }
@@ -25,6 +25,9 @@ type StateResponseDTO struct {
 	InputQueueLength        int      `json:"inputQueueLength"`
 	ProjectionType          string   `json:"projectionType"`
 	RetryDurations          []string `json:"retryDurations"`
+	Quarantine              bool     `json:"quarantine"`
+	Lanes                   int      `json:"lanes"`
+	Generation              int      `json:"generation"`
 }
 
 func FromEventStoreProjectionStates(states []event.ProjectionState) (dtos []StateResponseDTO) {
@@ -51,5 +54,8 @@ func FromEventStoreProjectionState(state event.ProjectionState) StateResponseDTO
 		InputQueueLength:        state.InputQueueLength,
 		ProjectionType:          string(state.ProjectionType),
 		RetryDurations:          durations,
+		Quarantine:              state.Quarantine,
+		Lanes:                   state.Lanes,
+		Generation:              state.Generation,
 	}
 }
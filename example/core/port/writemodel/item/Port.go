@@ -13,6 +13,8 @@ type Port interface {
 
 	PrepareRebuild(txCtx context.Context, tenantID string) error
 	FinishRebuild(txCtx context.Context, tenantID string) error
+
+	PurgeTenant(txCtx context.Context, tenantID string) error
 }
 
 type DTO struct {
@@ -118,3 +118,13 @@ func (a *adapter) FinishRebuild(txCtx context.Context, tenantID string) error {
 	logger.Info("Finished rebuild of item postgres projection for tenantID %q", tenantID)
 	return nil
 }
+
+func (a *adapter) PurgeTenant(txCtx context.Context, tenantID string) error {
+	err := a.DropTenantIntern(txCtx, tenantID, tables.ItemTableName)
+	if err != nil {
+		return fmt.Errorf("could not purge tenant of item postgres projection: %w", err)
+	}
+
+	logger.Info("Purged tenant %q of item postgres projection", tenantID)
+	return nil
+}
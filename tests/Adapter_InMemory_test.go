@@ -84,6 +84,12 @@ func TestSaveAggregateWithEphemeralEventTypes(t *testing.T) {
 	}, cleanRegistries)
 }
 
+func TestSaveAggregateWithHybridLogicalClock(t *testing.T) {
+	testSaveAggregateWithHybridLogicalClock(t, func() persistence.Port {
+		return NewTestAdapter()
+	}, cleanRegistries)
+}
+
 func TestSaveAggregateWithSnapshots(t *testing.T) {
 	testSaveAggregateWithSnapShot(t, func() persistence.Port {
 		return NewTestAdapter()
@@ -148,6 +154,14 @@ func TestExecuteAllExistingProjections(t *testing.T) {
 	testExecuteAllExistingProjections(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
 }
 
+func TestScheduledFuturePatches(t *testing.T) {
+	testScheduledFuturePatches(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestScheduledFuturePatchesAfterRestart(t *testing.T) {
+	testScheduledFuturePatchesAfterRestart(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
 func TestLoadAggregateAsAt(t *testing.T) {
 	testLoadAggregateAsAt(t, func() event.EventStore {
 		return NewEventStore(context.Background(), NewTestAdapter())
@@ -202,6 +216,18 @@ func TestLoadAllAggregatesAsOfTill(t *testing.T) {
 	}, cleanRegistries)
 }
 
+func TestLoadFromPosition(t *testing.T) {
+	testLoadFromPosition(t, func() event.EventStore {
+		return NewEventStore(context.Background(), NewTestAdapter())
+	}, cleanRegistries)
+}
+
+func TestSubscribe(t *testing.T) {
+	testSubscribe(t, func() persistence.Port {
+		return NewTestAdapter()
+	}, cleanRegistries)
+}
+
 func TestGetAggregateState(t *testing.T) {
 	testGetAggregateStates(t, func() event.EventStore {
 		return NewEventStore(context.Background(), NewTestAdapter())
@@ -224,6 +250,18 @@ func TestGetPatchFreePeriodsForInterval(t *testing.T) {
 	testGetPatchFreePeriodsForInterval(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
 }
 
+func TestOutbox(t *testing.T) {
+	testOutbox(t, func() persistence.Port {
+		return NewTestAdapter()
+	}, cleanRegistries)
+}
+
+func TestProjectionNotifications(t *testing.T) {
+	testProjectionNotifications(t, func() persistence.Port {
+		return NewTestAdapter()
+	}, cleanRegistries)
+}
+
 func TestGetProjectionStates(t *testing.T) {
 	testGetProjectionStates(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
 }
@@ -242,4 +280,103 @@ func TestRemoveProjection(t *testing.T) {
 func TestDeleteEvent(t *testing.T) {
 	testDeleteEvents(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
 	testProjectionsAfterDeleteEvents(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+	testRevisionDeleteEvents(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestProjectionQuarantine(t *testing.T) {
+	testProjectionRetry(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+	testProjectionQuarantine(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestProjectionLanes(t *testing.T) {
+	testProjectionLanes(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+	testProjectionLanesOption(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+	testProjectionLaneConflict(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestProjectionBlueGreenRebuild(t *testing.T) {
+	testProjectionBlueGreenRebuild(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+	testProjectionBlueGreenRebuildWithoutGenerations(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestProjectionHealth(t *testing.T) {
+	testProjectionHealth(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestSnapshotPolicy(t *testing.T) {
+	testSnapshotPolicy(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+	testSnapshotPolicyOption(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+	testSnapshotPolicyShutdown(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestStreamAll(t *testing.T) {
+	testStreamAll(t, func() event.EventStore {
+		return NewEventStore(context.Background(), NewTestAdapter())
+	}, cleanRegistries)
+}
+
+func TestRepository(t *testing.T) {
+	testRepository(t, func() event.EventStore {
+		return NewEventStore(context.Background(), NewTestAdapter())
+	}, cleanRegistries)
+}
+
+func TestDiff(t *testing.T) {
+	testDiff(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestLoadAllOfAggregateTypeAsOfSeries(t *testing.T) {
+	testLoadAllOfAggregateTypeAsOfSeries(t, func() event.EventStore {
+		return NewEventStore(context.Background(), NewTestAdapter())
+	}, cleanRegistries)
+}
+
+func TestSaveWithExpectations(t *testing.T) {
+	testSaveWithExpectations(t, func() event.EventStore {
+		return NewEventStore(context.Background(), NewTestAdapter())
+	}, cleanRegistries)
+}
+
+func TestTenantArchive(t *testing.T) {
+	testTenantArchive(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestTenantLifecycle(t *testing.T) {
+	testTenantLifecycle(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestPartitions(t *testing.T) {
+	// the in memory adapter has no partitions (disabled), but both stores need the same storage
+	adp := NewTestAdapter()
+	testPartitions(t, func() persistence.Port { return adp }, func() persistence.Port { return adp }, cleanRegistries)
+}
+
+func TestStreamArchive(t *testing.T) {
+	testStreamArchive(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestStreamLifecycle(t *testing.T) {
+	testStreamLifecycle(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestStreamLifecycleWithRevisionDelete(t *testing.T) {
+	testStreamLifecycleWithRevisionDelete(t, func() persistence.Port { return NewTestAdapter() }, cleanRegistries)
+}
+
+func TestUpcasting(t *testing.T) {
+	testUpcasting(t, func() event.EventStore {
+		return NewEventStore(context.Background(), NewTestAdapter())
+	}, cleanRegistries)
+}
+
+func TestSerializer(t *testing.T) {
+	testSerializer(t, func() persistence.Port {
+		return NewTestAdapter()
+	}, cleanRegistries)
+}
+
+func TestPersonalData(t *testing.T) {
+	testPersonalData(t, func() persistence.Port {
+		return NewTestAdapter()
+	}, cleanRegistries)
 }
@@ -105,6 +105,16 @@ func NewTestSQLAdapter(pool *pgxpool.Pool) persistence.Port {
 	return adp
 }
 
+func NewTestPartitionedSQLAdapter(pool *pgxpool.Pool) persistence.Port {
+	var opt = postgres.Options{AutoMigrate: false, Partitions: postgres.PartitionOptions{PerType: true, PerTenant: true}}
+	adp, err := postgres.New(pool, opt)
+	if err != nil {
+		panic("error in start up sql adapter")
+	}
+
+	return adp
+}
+
 func NewTestSlowSQLAdapter(pool *pgxpool.Pool) persistence.Port {
 	adp, err := postgres.NewSlowAdapter(pool)
 	if err != nil {
@@ -149,6 +159,21 @@ func cleanUp(pool *pgxpool.Pool) {
 	cleanRegistries()
 }
 
+// dropPartitions drops the partitions of the partitioned tables, which were created in tests (see TestPartitionsSQL).
+func dropPartitions(pool *pgxpool.Pool) {
+	_, err := pool.Exec(context.Background(),
+		"DO $$ DECLARE partition text; BEGIN "+
+			"FOR partition IN SELECT inhrelid::regclass::text FROM pg_inherits "+
+			"WHERE inhparent IN ('eventstore.aggregates'::regclass, 'eventstore.aggregates_events'::regclass, "+
+			"'eventstore.aggregates_snapshots'::regclass, 'eventstore.projections_events'::regclass) "+
+			"AND inhrelid::regclass::text NOT LIKE '%partdefault' "+
+			"LOOP EXECUTE 'DROP TABLE ' || partition; END LOOP; END $$;",
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func cleanUpDb(pool *pgxpool.Pool) {
 	_, err := pool.Exec(context.Background(),
 		"BEGIN;  "+
@@ -157,6 +182,13 @@ func cleanUpDb(pool *pgxpool.Pool) {
 			"TRUNCATE eventstore.aggregates_events ;"+
 			"TRUNCATE eventstore.projections ;"+
 			"TRUNCATE eventstore.projections_events ;"+
+			"TRUNCATE eventstore.projections_quarantine ;"+
+			"TRUNCATE eventstore.scheduled_projection_tasks ;"+
+			"TRUNCATE eventstore.tenant_positions ;"+
+			"TRUNCATE eventstore.subscription_checkpoints ;"+
+			"TRUNCATE eventstore.outbox_messages ;"+
+			"TRUNCATE eventstore.tenants ;"+
+			"TRUNCATE eventstore.aggregates_archived ;"+
 			"select pg_advisory_unlock_all();"+
 			"COMMIT;",
 	)
@@ -208,6 +240,10 @@ func TestSaveAggregateWithEphemeralEventTypesSQL(t *testing.T) {
 	testSaveAggregateWithEphemeralEventTypes(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
 }
 
+func TestSaveAggregateWithHybridLogicalClockSQL(t *testing.T) {
+	testSaveAggregateWithHybridLogicalClock(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
 func TestSaveAggregateWithSnapshotsSQL(t *testing.T) {
 	testSaveAggregateWithSnapShot(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
 }
@@ -270,6 +306,14 @@ func TestExecuteAllExistingProjectionsSQL(t *testing.T) {
 	testExecuteAllExistingProjections(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
 }
 
+func TestScheduledFuturePatchesSQL(t *testing.T) {
+	testScheduledFuturePatches(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestScheduledFuturePatchesAfterRestartSQL(t *testing.T) {
+	testScheduledFuturePatchesAfterRestart(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
 func TestLoadAggregateAsAtSQL(t *testing.T) {
 	testLoadAggregateAsAt(t, func() event.EventStore { return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool)) }, func() { cleanUp(pool) })
 }
@@ -320,6 +364,16 @@ func TestLoadAllAggregatesAsOfTillSQL(t *testing.T) {
 	}, func() { cleanUp(pool) })
 }
 
+func TestLoadFromPositionSQL(t *testing.T) {
+	testLoadFromPosition(t, func() event.EventStore {
+		return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool))
+	}, func() { cleanUp(pool) })
+}
+
+func TestSubscribeSQL(t *testing.T) {
+	testSubscribe(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
 func TestGetAggregateStateSQL(t *testing.T) {
 	testGetAggregateStates(t, func() event.EventStore {
 		return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool))
@@ -344,6 +398,14 @@ func TestGetPatchFreePeriodsForIntervalSQL(t *testing.T) {
 	}, func() { cleanUp(pool) })
 }
 
+func TestOutboxSQL(t *testing.T) {
+	testOutbox(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestProjectionNotificationsSQL(t *testing.T) {
+	testProjectionNotifications(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
 func TestGetProjectionStatesSQL(t *testing.T) {
 	testGetProjectionStates(t, func() persistence.Port {
 		return NewTestSQLAdapter(pool)
@@ -362,4 +424,97 @@ func TestRemoveProjectionSQL(t *testing.T) {
 func TestDeleteEventSQL(t *testing.T) {
 	testDeleteEvents(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
 	testProjectionsAfterDeleteEvents(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+	testRevisionDeleteEvents(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestProjectionQuarantineSQL(t *testing.T) {
+	testProjectionRetry(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+	testProjectionQuarantine(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestProjectionLanesSQL(t *testing.T) {
+	testProjectionLanes(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+	testProjectionLanesOption(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestProjectionBlueGreenRebuildSQL(t *testing.T) {
+	testProjectionBlueGreenRebuild(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+	testProjectionBlueGreenRebuildWithoutGenerations(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestProjectionHealthSQL(t *testing.T) {
+	testProjectionHealth(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestSnapshotPolicySQL(t *testing.T) {
+	testSnapshotPolicy(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+	testSnapshotPolicyOption(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+	testSnapshotPolicyShutdown(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestStreamAllSQL(t *testing.T) {
+	testStreamAll(t, func() event.EventStore {
+		return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool))
+	}, func() { cleanUp(pool) })
+}
+
+func TestRepositorySQL(t *testing.T) {
+	testRepository(t, func() event.EventStore {
+		return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool))
+	}, func() { cleanUp(pool) })
+}
+
+func TestDiffSQL(t *testing.T) {
+	testDiff(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestLoadAllOfAggregateTypeAsOfSeriesSQL(t *testing.T) {
+	testLoadAllOfAggregateTypeAsOfSeries(t, func() event.EventStore {
+		return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool))
+	}, func() { cleanUp(pool) })
+}
+
+func TestSaveWithExpectationsSQL(t *testing.T) {
+	testSaveWithExpectations(t, func() event.EventStore {
+		return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool))
+	}, func() { cleanUp(pool) })
+}
+
+func TestTenantArchiveSQL(t *testing.T) {
+	testTenantArchive(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestTenantLifecycleSQL(t *testing.T) {
+	testTenantLifecycle(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestPartitionsSQL(t *testing.T) {
+	testPartitions(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() persistence.Port { return NewTestPartitionedSQLAdapter(pool) }, func() {
+		cleanUp(pool)
+		dropPartitions(pool)
+	})
+}
+
+func TestStreamArchiveSQL(t *testing.T) {
+	testStreamArchive(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestStreamLifecycleSQL(t *testing.T) {
+	testStreamLifecycle(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestStreamLifecycleWithRevisionDeleteSQL(t *testing.T) {
+	testStreamLifecycleWithRevisionDelete(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestUpcastingSQL(t *testing.T) {
+	testUpcasting(t, func() event.EventStore { return NewEventStoreSQL(context.Background(), NewTestSQLAdapter(pool)) }, func() { cleanUp(pool) })
+}
+
+func TestSerializerSQL(t *testing.T) {
+	testSerializer(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
+}
+
+func TestPersonalDataSQL(t *testing.T) {
+	testPersonalData(t, func() persistence.Port { return NewTestSQLAdapter(pool) }, func() { cleanUp(pool) })
 }
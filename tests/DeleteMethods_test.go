@@ -699,6 +699,70 @@ func testProjectionsAfterDeleteEvents(t *testing.T, adapter func() persistence.P
 	}
 }
 
+func testRevisionDeleteEvents(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+
+	projectionTypeOne := newTestProjectionTypeOne("projection_1", tenantID, 0, 1)
+	store, err, _ := eventstore.New(adapter(),
+		eventstore.WithDeleteStrategy("forTestConcreteAggregate", event.RevisionDelete),
+		eventstore.WithProjection(projectionTypeOne),
+		eventstore.WithProjectionType(projectionTypeOne.ID(), event.CCS),
+	)
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)),
+	}))
+	if err != nil {
+		t.Fatalf("save aggregate failed: %s", err)
+	}
+	assert.NoError(t, <-errCh)
+	beforeDelete := time.Now()
+	projectionTypeOne.(*forTestProjection).ForTestReset(nil, 0, 0, 0)
+
+	// execute
+	err = store.DeleteEvent(ctx, tenantID, "forTestConcreteAggregate", "1", _getEventID(t, store, tenantID, "1", 3))
+	assert.NoError(t, err)
+
+	// assert
+	gotEventStream, version, err := event.LoadAggregateAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now(), store)
+	if err != nil {
+		t.Errorf("retrieving aggregate failed: %s", err)
+	}
+	testdata.AssertEqualStream(t, gotEventStream, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+	})
+	assert.Equalf(t, 4, version, "version not as expected")
+
+	gotEventStream, _, err = event.LoadAggregateAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", beforeDelete, store)
+	if err != nil {
+		t.Errorf("retrieving aggregate failed: %s", err)
+	}
+	assert.Len(t, gotEventStream, 3, "deleted event not loaded before the delete")
+
+	gotProjectionEvents := projectionTypeOne.(*forTestProjection).ForTestGetEvents()
+	if assert.Len(t, gotProjectionEvents, 1) {
+		assert.Equal(t, event.DeleteRevision, gotProjectionEvents[0].GetClass())
+	}
+
+	err = store.DeleteEvent(ctx, tenantID, "forTestConcreteAggregate", "1", _getEventID(t, store, tenantID, "1", 3))
+	assert.ErrorContains(t, err, "already deleted", "second delete of the same event")
+	_, version, err = event.LoadAggregateAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now(), store)
+	assert.NoError(t, err)
+	assert.Equalf(t, 4, version, "second delete wrote a tombstone")
+
+	err = store.DeleteEvent(ctx, tenantID, "forTestConcreteAggregate", "1", _getEventID(t, store, tenantID, "1", 1))
+	assert.Error(t, err, "delete of create event")
+}
+
 func _getEventID(t *testing.T, store event.EventStore, tenantID, aggregateID string, version int) string {
 	evtToDelete, _, err := store.GetAggregatesEvents(context.Background(), tenantID, event.PageDTO{
 		PageSize:   1,
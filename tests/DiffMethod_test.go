@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testDiff(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	aggregateType := "forTestConcreteAggregate"
+
+	store, err, _ := eventstore.New(adapter(), eventstore.WithDeleteStrategy(aggregateType, event.RevisionDelete))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+	_, err = store.SaveAll(ctx, tenantID, []event.PersistenceEvents{
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0()),
+				resetVersionForSave(ForTestEvent1()),
+				resetVersionForSave(ForTestEvent2()),
+				resetVersionForSave(ForTestEventPatch()),
+				resetVersionForSave(ForTestEventFuturePatch()),
+			},
+			Version: 0,
+		},
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0_Aggregate2()),
+			},
+			Version: 0,
+		},
+	})
+	if err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+
+	beforePatch := time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)
+	afterPatch := time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)
+	afterFuturePatch := time.Date(2021, 1, 1, 1, 1, 1, 4, time.UTC)
+	projectionTime := time.Now()
+
+	t.Run("historical patch entered", func(t *testing.T) {
+		diff, err := store.DiffAsOfTill(ctx, tenantID, aggregateType, "1", projectionTime, beforePatch, afterPatch)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "1", diff.AggregateID)
+		assert.Empty(t, diff.Left)
+		if assert.Len(t, diff.Entered, 1) && assert.Len(t, diff.Entered[event.HistoricalPatch], 1) {
+			assert.Equal(t, "Patch", diff.Entered[event.HistoricalPatch][0].ID)
+		}
+	})
+
+	t.Run("historical patch left", func(t *testing.T) {
+		diff, err := store.DiffAsOfTill(ctx, tenantID, aggregateType, "1", projectionTime, afterPatch, beforePatch)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Empty(t, diff.Entered)
+		if assert.Len(t, diff.Left[event.HistoricalPatch], 1) {
+			assert.Equal(t, "Patch", diff.Left[event.HistoricalPatch][0].ID)
+		}
+	})
+
+	t.Run("future patch outside of projection time", func(t *testing.T) {
+		diff, err := store.DiffAsOfTill(ctx, tenantID, aggregateType, "1", projectionTime, afterPatch, afterFuturePatch)
+		assert.NoError(t, err)
+		assert.True(t, diff.IsEmpty())
+
+		diff, err = store.DiffAsOfTill(ctx, tenantID, aggregateType, "1", time.Date(2051, 1, 1, 1, 1, 1, 0, time.UTC), afterPatch, afterFuturePatch)
+		assert.NoError(t, err)
+		assert.Len(t, diff.Entered[event.FuturePatch], 1)
+	})
+
+	t.Run("equal report times", func(t *testing.T) {
+		diff, err := store.DiffAsOfTill(ctx, tenantID, aggregateType, "1", projectionTime, afterPatch, afterPatch)
+		assert.NoError(t, err)
+		assert.True(t, diff.IsEmpty())
+	})
+
+	t.Run("all of aggregate type", func(t *testing.T) {
+		diffs, err := store.DiffAllOfAggregateTypeAsOfTill(ctx, tenantID, aggregateType, projectionTime, beforePatch, afterPatch)
+		if !assert.NoError(t, err) {
+			return
+		}
+		if assert.Len(t, diffs, 1, "unchanged aggregates are not part of the result") {
+			assert.Equal(t, "1", diffs[0].AggregateID)
+		}
+
+		diffs, err = store.DiffAllOfAggregateTypeAsOfTill(ctx, tenantID, aggregateType, projectionTime, time.Date(2020, 1, 1, 1, 1, 1, 0, time.UTC), afterPatch)
+		assert.NoError(t, err)
+		assert.Len(t, diffs, 2)
+
+		diffs, err = store.DiffAllOfAggregateTypeAsOfTill(ctx, "unknown tenant", aggregateType, projectionTime, beforePatch, afterPatch)
+		assert.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("revision delete", func(t *testing.T) {
+		beforeDelete := time.Now()
+		err := store.DeleteEvent(ctx, tenantID, aggregateType, "1", "2")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		diff, err := store.DiffAsOfTill(ctx, tenantID, aggregateType, "1", time.Now(), beforeDelete, time.Now())
+		if !assert.NoError(t, err) {
+			return
+		}
+		if assert.Len(t, diff.Left[event.InstantEvent], 1) {
+			assert.Equal(t, "2", diff.Left[event.InstantEvent][0].ID)
+		}
+		assert.Len(t, diff.Entered[event.DeleteRevision], 1)
+	})
+}
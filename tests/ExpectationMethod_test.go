@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testSaveWithExpectations(t *testing.T, eventStoreFactory func() event.EventStore, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	store := eventStoreFactory()
+
+	save := func(expect event.Expectation, version int, changes ...event.IEvent) error {
+		_, err := event.SaveAggregates(ctx, store, event.WithExpectation(newForTestConcreteAggregate("1", "", version, tenantID, changes), expect))
+		return err
+	}
+	assertViolated := func(t *testing.T, err error, expect event.Expectation, version int, closed bool) {
+		t.Helper()
+		var errViolated *event.ErrorExpectationViolated
+		if assert.ErrorAs(t, err, &errViolated) {
+			assert.Equal(t, expect, errViolated.Expected)
+			assert.Equal(t, version, errViolated.Version)
+			assert.Equal(t, closed, errViolated.Closed)
+			assert.Equal(t, "forTestConcreteAggregate", errViolated.AggregateType)
+		}
+	}
+
+	t.Run("expect no stream", func(t *testing.T) {
+		assert.NoError(t, save(event.ExpectNoStream(), 0, ForTestMakeCreateEventNow("1", tenantID)))
+
+		// idempotent create-if-absent
+		err := save(event.ExpectNoStream(), 0, ForTestMakeCreateEventNow("1", tenantID))
+		assertViolated(t, err, event.ExpectNoStream(), 1, false)
+	})
+
+	t.Run("expect version", func(t *testing.T) {
+		// the version of the aggregate is ignored
+		assert.NoError(t, save(event.ExpectVersion(1), 0, ForTestMakeEventNow("1", tenantID)))
+
+		err := save(event.ExpectVersion(1), 2, ForTestMakeEventNow("1", tenantID))
+		assertViolated(t, err, event.ExpectVersion(1), 2, false)
+	})
+
+	t.Run("expect any", func(t *testing.T) {
+		assert.NoError(t, save(event.ExpectAny(), 0, ForTestMakeEventNow("1", tenantID)))
+
+		// without expectation the outdated version fails with the Fail strategy
+		var errConcurrent *event.ErrorConcurrentModification
+		assert.ErrorAs(t, save(event.Expectation{}, 0, ForTestMakeEventNow("1", tenantID)), &errConcurrent)
+	})
+
+	t.Run("expect closed", func(t *testing.T) {
+		err := save(event.ExpectClosed(), 3, ForTestMakeEventNow("1", tenantID))
+		assertViolated(t, err, event.ExpectClosed(), 3, false)
+
+		assert.NoError(t, save(event.ExpectVersion(3), 0, ForTestMakeCloseEventWithoutMigration("1", tenantID, time.Now().Add(time.Hour))))
+		assert.NoError(t, save(event.ExpectClosed(), 0, ForTestMakeEventNow("1", tenantID)))
+
+		_, err = event.SaveAggregates(ctx, store, event.WithExpectation(newForTestConcreteAggregate("unknown", "", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEventNow("unknown", tenantID),
+		}), event.ExpectClosed()))
+		assertViolated(t, err, event.ExpectClosed(), 0, false)
+	})
+
+	t.Run("expectations per aggregate", func(t *testing.T) {
+		// the save of all aggregates fails, if one expectation is violated
+		_, err := event.SaveAggregates(ctx, store,
+			event.WithExpectation(newForTestConcreteAggregate("2", "", 0, tenantID, []event.IEvent{ForTestMakeCreateEventNow("2", tenantID)}), event.ExpectNoStream()),
+			event.WithExpectation(newForTestConcreteAggregate("1", "", 0, tenantID, []event.IEvent{ForTestMakeEventNow("1", tenantID)}), event.ExpectVersion(1)),
+		)
+		assertViolated(t, err, event.ExpectVersion(1), 5, true)
+
+		_, _, err = store.LoadAsOf(ctx, tenantID, "forTestConcreteAggregate", "2", time.Now())
+		var errEmpty *event.ErrorEmptyEventStream
+		assert.ErrorAs(t, err, &errEmpty)
+	})
+
+	t.Run("cross tenant guard", func(t *testing.T) {
+		evt, err := event.NewPersistenceEvent(ctx, ForTestMakeCreateEventNow("3", "another tenant"), "forTestConcreteAggregate")
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = store.SaveAll(ctx, tenantID, []event.PersistenceEvents{{Events: []event.PersistenceEvent{evt}, Expect: event.ExpectNoStream()}})
+		assert.Error(t, err)
+	})
+}
@@ -900,3 +900,98 @@ func testLoadAllAggregatesAsOfTill(t *testing.T, eventStoreFactory func() event.
 		})
 	}
 }
+
+func testLoadFromPosition(t *testing.T, eventStoreFactory func() event.EventStore, cleanUp func()) {
+	cleanUp()
+	tenantID := "0000-0000-0000"
+	otherTenantID := "0000-0000-0001"
+
+	type want struct {
+		aggregateID string
+		version     int
+		position    int64
+	}
+	type args struct {
+		tenantID string
+		position int64
+		limit    int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []want
+		wantErr bool
+	}{
+		{
+			name: "load entire log of tenant",
+			args: args{tenantID: tenantID, position: 0, limit: 10},
+			want: []want{{"1", 1, 1}, {"1", 2, 2}, {"1", 3, 3}, {"2", 1, 4}, {"1", 4, 5}},
+		},
+		{
+			name: "load from position",
+			args: args{tenantID: tenantID, position: 3, limit: 10},
+			want: []want{{"2", 1, 4}, {"1", 4, 5}},
+		},
+		{
+			name: "load from position with limit",
+			args: args{tenantID: tenantID, position: 1, limit: 2},
+			want: []want{{"1", 2, 2}, {"1", 3, 3}},
+		},
+		{
+			name: "load from last position",
+			args: args{tenantID: tenantID, position: 5, limit: 10},
+			want: nil,
+		},
+		{
+			name: "positions are independent per tenant",
+			args: args{tenantID: otherTenantID, position: 0, limit: 10},
+			want: []want{{"1", 1, 1}},
+		},
+		{
+			name:    "invalid limit",
+			args:    args{tenantID: tenantID, position: 0, limit: 0},
+			wantErr: true,
+		},
+	}
+
+	store := eventStoreFactory()
+	defer cleanUp()
+	for _, aggregates := range [][]event.AggregateWithEventSourcingSupport{
+		{newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+			ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+			ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)),
+		})},
+		{newForTestConcreteAggregate("2", "Name", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEvent("2", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 5, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 5, time.UTC)),
+		})},
+		// the log is in commit order and not in transaction time order
+		{newForTestConcreteAggregate("1", "Name", 3, tenantID, []event.IEvent{
+			ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 4, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 4, time.UTC)),
+		})},
+		{newForTestConcreteAggregate("1", "Name", 0, otherTenantID, []event.IEvent{
+			ForTestMakeCreateEvent("1", otherTenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+		})},
+	} {
+		if _, err := event.SaveAggregates(context.Background(), store, aggregates...); err != nil {
+			t.Fatalf("test preparation %v failed: %v", t.Name(), err)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.LoadFromPosition(context.Background(), tt.args.tenantID, tt.args.position, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadFromPosition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("LoadFromPosition() got %d events, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				if got[i].AggregateID != w.aggregateID || got[i].Version != w.version || got[i].Position != w.position {
+					t.Errorf("LoadFromPosition() event %d got (%s, %d, %d), want (%s, %d, %d)", i, got[i].AggregateID, got[i].Version, got[i].Position, w.aggregateID, w.version, w.position)
+				}
+			}
+		})
+	}
+}
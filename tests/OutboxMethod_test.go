@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/publisher/channel"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errForTestPublisher = errors.New("publisher error")
+
+// forTestPublisher fails the given number of times, before it passes the events to the channel publisher.
+type forTestPublisher struct {
+	*channel.Adapter
+	mu       sync.Mutex
+	failures int
+}
+
+func (p *forTestPublisher) Publish(ctx context.Context, evt event.PersistenceEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errForTestPublisher
+	}
+	return p.Adapter.Publish(ctx, evt)
+}
+
+func (p *forTestPublisher) setFailures(failures int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failures = failures
+}
+
+func forTestReceive(publisher *forTestPublisher, n int, timeOut time.Duration) []string {
+	var got []string
+	for len(got) < n {
+		select {
+		case evt := <-publisher.Events():
+			got = append(got, evt.Type)
+		case <-time.After(timeOut):
+			return got
+		}
+	}
+	return got
+}
+
+func testOutbox(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	tenantID := "0000-0000-0000"
+	outboxType := event.EventType(ForTestMakeEvent3("1", tenantID, time.Now(), time.Now(), ""))
+
+	tests := []struct {
+		name            string
+		failures        int
+		maxAttempts     int
+		want            []string
+		wantDeadLetters int
+	}{
+		{
+			name:        "deliver events of outbox event types",
+			maxAttempts: 3,
+			want:        []string{outboxType},
+		},
+		{
+			name:        "retry failed delivery",
+			failures:    2,
+			maxAttempts: 3,
+			want:        []string{outboxType},
+		},
+		{
+			name:            "move into dead letters after max attempts",
+			failures:        3,
+			maxAttempts:     3,
+			want:            nil,
+			wantDeadLetters: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer cleanUp()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			publisher := &forTestPublisher{Adapter: channel.New(10), failures: tt.failures}
+			store, err, _ := eventstore.New(adapter(),
+				eventstore.WithOutbox(outboxType),
+				eventstore.WithOutboxPublisher(ctx, publisher),
+				eventstore.WithOutboxPollInterval(10*time.Millisecond),
+				eventstore.WithOutboxRetry(tt.maxAttempts, time.Millisecond),
+			)
+			if err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+			_, err = event.SaveAggregate(context.Background(), store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+				ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+				ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+				ForTestMakeEvent3("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), "property"),
+			}))
+			if err != nil {
+				t.Fatalf("SaveAggregate() error = %v", err)
+			}
+
+			got := forTestReceive(publisher, len(tt.want), 500*time.Millisecond)
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("Publish() got events %v, want %v", got, tt.want)
+			}
+			if extra := forTestReceive(publisher, 1, 50*time.Millisecond); len(extra) != 0 {
+				t.Errorf("Publish() got unexpected events %v", extra)
+			}
+
+			deadLetters, err := store.GetDeadLetters(context.Background(), tenantID)
+			if err != nil {
+				t.Fatalf("GetDeadLetters() error = %v", err)
+			}
+			if len(deadLetters) != tt.wantDeadLetters {
+				t.Fatalf("GetDeadLetters() got %d dead letters, want %d", len(deadLetters), tt.wantDeadLetters)
+			}
+			if tt.wantDeadLetters == 0 {
+				return
+			}
+
+			if deadLetters[0].Attempts != tt.maxAttempts || deadLetters[0].LastError != errForTestPublisher.Error() {
+				t.Errorf("GetDeadLetters() got %d attempts with error %q, want %d attempts with error %q", deadLetters[0].Attempts, deadLetters[0].LastError, tt.maxAttempts, errForTestPublisher)
+			}
+
+			publisher.setFailures(0)
+			if err = store.RequeueDeadLetters(context.Background(), tenantID, deadLetters[0].Event.ID); err != nil {
+				t.Fatalf("RequeueDeadLetters() error = %v", err)
+			}
+			if got = forTestReceive(publisher, 1, 500*time.Millisecond); len(got) != 1 || got[0] != outboxType {
+				t.Errorf("Publish() after requeue got events %v, want %v", got, []string{outboxType})
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// testPartitions saves with the adapter (without partitions) first, so that the rows are stored in the default
+// partitions, and afterward with the partitioned adapter.
+func testPartitions(t *testing.T, adapter, partitionedAdapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantA := "0000-0000-0000"
+	tenantB := "1111-1111-1111"
+	aggregateType := "forTestConcreteAggregate"
+	t1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	save := func(store event.EventStore, tenantID, aggregateID string) error {
+		aggregate, _ := newForTestConcreteAggregate(aggregateID, "", 0, tenantID, nil).ApplyEvent(ForTestMakeCreateEvent(aggregateID, tenantID, t1, t1))
+		_, err := event.SaveAggregate(ctx, store, aggregate)
+		return err
+	}
+
+	unpartitioned, err, _ := eventstore.New(adapter())
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+	if err = save(unpartitioned, tenantA, "1"); err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+
+	adp := partitionedAdapter()
+	store, err, _ := eventstore.New(adp)
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	t.Run("save into partitions", func(t *testing.T) {
+		assert.NoError(t, save(store, tenantB, "1"))
+	})
+
+	t.Run("migrate default partitions", func(t *testing.T) {
+		assert.NoError(t, store.MigrateDefaultPartitions(ctx))
+		// a repeated migration is accepted
+		assert.NoError(t, store.MigrateDefaultPartitions(ctx))
+
+		for _, tenantID := range []string{tenantA, tenantB} {
+			states, err := store.GetAggregateStatesForAggregateType(ctx, tenantID, aggregateType)
+			assert.NoError(t, err)
+			assert.Len(t, states, 1)
+		}
+	})
+
+	t.Run("partition sizes", func(t *testing.T) {
+		sizes, err := store.GetPartitionSizes(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !adp.PartitionPort().Enabled() {
+			assert.Empty(t, sizes)
+			return
+		}
+
+		tenants := map[string]bool{}
+		for _, size := range sizes {
+			assert.NotEmpty(t, size.Partition)
+			if size.Table == "aggregates_events" && size.Key == aggregateType && !size.Default {
+				tenants[size.TenantID] = true
+			}
+		}
+		assert.Equal(t, map[string]bool{tenantA: true, tenantB: true}, tenants)
+	})
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/keyStore/memory"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// forTestEvent6 contains personal data of the data subject Person.
+type forTestEvent6 struct {
+	event.Event
+	Person string
+	Email  string `personal:"true"`
+}
+
+func (e forTestEvent6) GetPersonalDataSubjectID() string {
+	return e.Person
+}
+
+func init() {
+	event.RegisterEventAndAggregate(forTestEvent6{}, reflect.TypeOf(forTestConcreteAggregate{}).Name())
+}
+
+func ForTestMakeEvent6(aggregateID, tenantID string, transactionTimestamp, validTimestamp time.Time, person, email string) event.IEvent {
+	e := event.NewMigrationEvent(aggregateID, tenantID, validTimestamp, transactionTimestamp, event.InstantEvent)
+	return &forTestEvent6{Event: e, Person: person, Email: email}
+}
+
+func testPersonalData(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := "0000-0000-0000"
+	ctx := context.Background()
+
+	store, err, _ := eventstore.New(adapter(), eventstore.WithKeyStore(memory.New()))
+	if err != nil {
+		t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+	}
+	_, err = event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+		ForTestMakeEvent6("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), "alice", "alice@example.com"),
+		ForTestMakeEvent6("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), "bob", "bob@example.com"),
+	}))
+	if err != nil {
+		t.Fatalf("SaveAggregate() error = %v", err)
+	}
+
+	persisted, _, err := store.LoadAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now())
+	if err != nil {
+		t.Fatalf("LoadAsAt() error = %v", err)
+	}
+	for _, evt := range persisted {
+		if bytes.Contains(evt.Data, []byte("@example.com")) {
+			t.Errorf("LoadAsAt() got unencrypted personal data %s", evt.Data)
+		}
+	}
+
+	assertEmails := func(want []string, wantRedacted [][]string) {
+		eventStream, _, err := event.LoadAggregateAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now(), store)
+		if err != nil {
+			t.Fatalf("LoadAggregateAsAt() error = %v", err)
+		}
+		for i := range want {
+			got := eventStream[i+1].(*forTestEvent6)
+			if got.Email != want[i] || !reflect.DeepEqual(got.GetRedactedFields(), wantRedacted[i]) {
+				t.Errorf("LoadAggregateAsAt() got email %q with redacted fields %v, want %q with %v", got.Email, got.GetRedactedFields(), want[i], wantRedacted[i])
+			}
+		}
+	}
+	assertEmails([]string{"alice@example.com", "bob@example.com"}, [][]string{nil, nil})
+
+	if err = event.ShredPersonalData(ctx, tenantID, "alice"); err != nil {
+		t.Fatalf("ShredPersonalData() error = %v", err)
+	}
+	assertEmails([]string{"", "bob@example.com"}, [][]string{{"Email"}, nil})
+}
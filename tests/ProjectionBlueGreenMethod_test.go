@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// forTestGenerationalProjection records the valid times of the projected events per generation and aggregate.
+type forTestGenerationalProjection struct {
+	mu          sync.Mutex
+	id          string
+	live        int
+	generations map[event.ProjectionGeneration]map[string][]time.Time
+}
+
+func newForTestGenerationalProjection(id string) *forTestGenerationalProjection {
+	return &forTestGenerationalProjection{id: id, generations: make(map[event.ProjectionGeneration]map[string][]time.Time)}
+}
+
+func (f *forTestGenerationalProjection) ID() string {
+	return f.id
+}
+
+func (f *forTestGenerationalProjection) EventTypes() []string {
+	return []string{event.EventType(&forTestEvent{})}
+}
+
+func (f *forTestGenerationalProjection) ChunkSize() int {
+	return 2
+}
+
+func (f *forTestGenerationalProjection) Execute(_ context.Context, _ []event.IEvent) error {
+	panic("projections with generations are executed with ExecuteGeneration")
+}
+
+func (f *forTestGenerationalProjection) PrepareRebuild(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *forTestGenerationalProjection) FinishRebuild(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *forTestGenerationalProjection) PrepareGeneration(_ context.Context, _ string, generation event.ProjectionGeneration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.generations[generation] = make(map[string][]time.Time)
+	return nil
+}
+
+func (f *forTestGenerationalProjection) ExecuteGeneration(_ context.Context, generation event.ProjectionGeneration, events []event.IEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.generations[generation] == nil {
+		f.generations[generation] = make(map[string][]time.Time)
+	}
+	for _, evt := range events {
+		f.generations[generation][evt.GetAggregateID()] = append(f.generations[generation][evt.GetAggregateID()], evt.GetValidTime().UTC())
+	}
+	return nil
+}
+
+func (f *forTestGenerationalProjection) SwitchGeneration(_ context.Context, _ string, generation event.ProjectionGeneration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.live = generation.Number
+	f.generations[event.ProjectionGeneration{Number: generation.Number}] = f.generations[generation]
+	delete(f.generations, generation)
+	return nil
+}
+
+// forTestLiveValidTimes returns the valid times of the live read model.
+func (f *forTestGenerationalProjection) forTestLiveValidTimes() (int, map[string][]time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	validTimes := make(map[string][]time.Time)
+	for aggregateID, v := range f.generations[event.ProjectionGeneration{Number: f.live}] {
+		validTimes[aggregateID] = append([]time.Time(nil), v...)
+	}
+	return f.live, validTimes
+}
+
+func testProjectionBlueGreenRebuild(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+
+	proj := newForTestGenerationalProjection("projection_1")
+	store, err, _ := eventstore.New(adapter(), eventstore.WithProjection(proj))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	projected := []time.Time{
+		time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC),
+		time.Date(2021, 1, 1, 1, 1, 2, 0, time.UTC),
+		time.Date(2021, 1, 1, 1, 1, 3, 0, time.UTC),
+	}
+	errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, projected[0], projected[0]),
+		ForTestMakeEvent("1", tenantID, projected[1], projected[1]),
+		ForTestMakeEvent("1", tenantID, projected[2], projected[2]),
+	}))
+	if err != nil {
+		t.Fatalf("save aggregate failed: %s", err)
+	}
+	assert.NoError(t, <-errCh)
+	assert.NoError(t, store.ExecuteAllProjections(ctx, proj.ID()))
+	live, validTimes := proj.forTestLiveValidTimes()
+	assert.Equal(t, 0, live)
+	assert.Equal(t, map[string][]time.Time{"1": projected}, validTimes)
+
+	// the events of the stopped projection are still in its queue during the rebuild
+	assert.NoError(t, store.StopProjection(ctx, tenantID, proj.ID()))
+	queued := []time.Time{
+		time.Date(2021, 1, 1, 1, 1, 4, 0, time.UTC),
+		time.Date(2021, 1, 1, 1, 1, 5, 0, time.UTC),
+	}
+	errCh, err = event.SaveAggregate(ctx, store, newForTestConcreteAggregate("2", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("2", tenantID, queued[0], queued[0]),
+		ForTestMakeEvent("2", tenantID, queued[1], queued[1]),
+	}))
+	if err != nil {
+		t.Fatalf("save aggregate failed: %s", err)
+	}
+	<-errCh // the stopped projection is not executed
+
+	assert.NoError(t, <-store.RebuildProjectionBlueGreen(ctx, tenantID, proj.ID()))
+	live, validTimes = proj.forTestLiveValidTimes()
+	assert.Equal(t, 1, live)
+	assert.Equal(t, map[string][]time.Time{"1": projected}, validTimes)
+
+	states, err := store.GetAllProjectionStates(ctx, tenantID)
+	if assert.NoError(t, err) && assert.Len(t, states, 1, "shadow of the rebuild not removed") {
+		assert.Equal(t, 1, states[0].Generation)
+		assert.Equal(t, "Stopped", states[0].State)
+	}
+
+	// the queued events are projected into the new generation only
+	errCh, err = store.StartProjection(ctx, tenantID, proj.ID())
+	if assert.NoError(t, err) {
+		for err = range errCh {
+			assert.NoError(t, err)
+		}
+	}
+	live, validTimes = proj.forTestLiveValidTimes()
+	assert.Equal(t, 1, live)
+	assert.Equal(t, map[string][]time.Time{"1": projected, "2": queued}, validTimes)
+
+	// a second rebuild switches to the next generation
+	assert.NoError(t, <-store.RebuildProjectionBlueGreen(ctx, tenantID, proj.ID()))
+	live, validTimes = proj.forTestLiveValidTimes()
+	assert.Equal(t, 2, live)
+	assert.Equal(t, map[string][]time.Time{"1": projected, "2": queued}, validTimes)
+
+	// concurrent rebuilds are executed one after another by the worker of the projection
+	errChs := make(chan chan error, 3)
+	for range cap(errChs) {
+		go func() { errChs <- store.RebuildProjectionBlueGreen(ctx, tenantID, proj.ID()) }()
+	}
+	for range cap(errChs) {
+		assert.NoError(t, <-<-errChs)
+	}
+	live, validTimes = proj.forTestLiveValidTimes()
+	assert.Equal(t, 5, live)
+	assert.Equal(t, map[string][]time.Time{"1": projected, "2": queued}, validTimes)
+}
+
+func testProjectionBlueGreenRebuildWithoutGenerations(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+
+	proj := &forTestLaneProjection{id: "projection_1", validTimes: make(map[string][]time.Time)}
+	store, err, _ := eventstore.New(adapter(), eventstore.WithProjection(proj))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+	errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Now(), time.Now()),
+	}))
+	if err != nil {
+		t.Fatalf("save aggregate failed: %s", err)
+	}
+	assert.NoError(t, <-errCh)
+
+	assert.Error(t, <-store.RebuildProjectionBlueGreen(ctx, tenantID, proj.ID()))
+
+	states, err := store.GetAllProjectionStates(ctx, tenantID)
+	if assert.NoError(t, err) && assert.Len(t, states, 1) {
+		assert.Equal(t, 0, states[0].Generation)
+		assert.Equal(t, "Running", states[0].State)
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testProjectionHealth(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+
+	proj := &forTestPoisonProjection{id: "projection_1", poisoned: "poison"}
+	store, err, _ := eventstore.New(adapter(), eventstore.WithProjection(proj))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	save := func(aggregateID string, nanoSec int) error {
+		errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate(aggregateID, "Name", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEvent(aggregateID, tenantID, time.Date(2021, 1, 1, 1, 1, 1, nanoSec, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, nanoSec, time.UTC)),
+		}))
+		if err != nil {
+			t.Fatalf("save aggregate failed: %s", err)
+		}
+		return <-errCh
+	}
+	getHealth := func() event.ProjectionHealth {
+		health, err := store.GetProjectionHealth(ctx, tenantID, proj.ID())
+		if err != nil {
+			t.Fatalf("get projection health failed: %s", err)
+		}
+		if len(health) != 1 {
+			t.Fatalf("expected health of a single projection, got %d", len(health))
+		}
+		return health[0]
+	}
+
+	// successful execution
+	assert.NoError(t, save("1", 1))
+	health := getHealth()
+	assert.Equal(t, tenantID, health.TenantID)
+	assert.Equal(t, proj.ID(), health.ProjectionID)
+	assert.Equal(t, "Running", health.State)
+	assert.Zero(t, health.QueuedEvents)
+	assert.True(t, health.OldestQueuedEvent.IsZero())
+	assert.Zero(t, health.Lag())
+	assert.False(t, health.LastSuccessfulRun.IsZero())
+	assert.Empty(t, health.LastError)
+	assert.Zero(t, health.ConsecutiveFailures)
+	assert.Positive(t, health.WorkerQueueLength)
+	lastSuccessfulRun := health.LastSuccessfulRun
+
+	// failed executions block the queue
+	assert.Error(t, save("poison", 2))
+	assert.Error(t, save("2", 3))
+	health = getHealth()
+	assert.Equal(t, 2, health.QueuedEvents)
+	assert.False(t, health.OldestQueuedEvent.IsZero())
+	assert.Positive(t, health.Lag())
+	assert.Equal(t, lastSuccessfulRun, health.LastSuccessfulRun)
+	assert.Contains(t, health.LastError, "provoked error")
+	assert.Equal(t, 2, health.ConsecutiveFailures)
+
+	// a successful execution resets the failures
+	proj.forTestPoison("")
+	assert.NoError(t, save("3", 4))
+	health = getHealth()
+	assert.Zero(t, health.QueuedEvents)
+	assert.True(t, health.LastSuccessfulRun.After(lastSuccessfulRun))
+	assert.Empty(t, health.LastError)
+	assert.Zero(t, health.ConsecutiveFailures)
+
+	all, err := store.GetAllProjectionHealth(ctx, tenantID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []event.ProjectionHealth{health}, all)
+	}
+}
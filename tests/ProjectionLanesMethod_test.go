@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// forTestLaneProjection records the valid times of the projected events per aggregate.
+type forTestLaneProjection struct {
+	mu         sync.Mutex
+	id         string
+	validTimes map[string][]time.Time
+}
+
+func (f *forTestLaneProjection) ID() string {
+	return f.id
+}
+
+func (f *forTestLaneProjection) EventTypes() []string {
+	return []string{event.EventType(&forTestEvent{})}
+}
+
+func (f *forTestLaneProjection) ChunkSize() int {
+	return 3
+}
+
+func (f *forTestLaneProjection) Execute(_ context.Context, events []event.IEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, evt := range events {
+		f.validTimes[evt.GetAggregateID()] = append(f.validTimes[evt.GetAggregateID()], evt.GetValidTime().UTC())
+	}
+	return nil
+}
+
+func (f *forTestLaneProjection) PrepareRebuild(_ context.Context, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.validTimes = make(map[string][]time.Time)
+	return nil
+}
+
+func (f *forTestLaneProjection) FinishRebuild(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *forTestLaneProjection) forTestValidTimes() map[string][]time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	validTimes := make(map[string][]time.Time)
+	for aggregateID, v := range f.validTimes {
+		validTimes[aggregateID] = append([]time.Time(nil), v...)
+	}
+	return validTimes
+}
+
+func testProjectionLanes(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+
+	proj := &forTestLaneProjection{id: "projection_1", validTimes: make(map[string][]time.Time)}
+	store, err, _ := eventstore.New(adapter(),
+		eventstore.WithProjection(proj),
+		eventstore.WithProjectionType(proj.ID(), event.ESS),
+		eventstore.WithProjectionLanes(proj.ID(), 4),
+	)
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	want := make(map[string][]time.Time)
+	var errChs []chan error
+	for i := 0; i < 8; i++ {
+		aggregateID := strconv.Itoa(i)
+		validTimes := []time.Time{
+			time.Date(2021, 1, 1, 1, 1, 1, i, time.UTC),
+			time.Date(2021, 1, 1, 1, 1, 2, i, time.UTC),
+			time.Date(2021, 1, 1, 1, 1, 3, i, time.UTC),
+		}
+		errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate(aggregateID, "Name", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEvent(aggregateID, tenantID, validTimes[0], validTimes[0]),
+			ForTestMakeEvent(aggregateID, tenantID, validTimes[1], validTimes[1]),
+			ForTestMakeEvent(aggregateID, tenantID, validTimes[2], validTimes[2]),
+		}))
+		if err != nil {
+			t.Fatalf("save aggregate failed: %s", err)
+		}
+		errChs = append(errChs, errCh)
+		want[aggregateID] = validTimes
+	}
+	for _, errCh := range errChs {
+		assert.NoError(t, <-errCh)
+	}
+	assert.NoError(t, store.ExecuteAllProjections(ctx, proj.ID()))
+	assert.Equal(t, want, proj.forTestValidTimes(), "events not projected in order per aggregate")
+
+	states, err := store.GetProjectionStates(ctx, tenantID, proj.ID())
+	if assert.NoError(t, err) && assert.Len(t, states, 1) {
+		assert.Equal(t, 4, states[0].Lanes)
+	}
+
+	// the rebuild locks all lanes of the projection
+	assert.NoError(t, <-store.RebuildProjection(ctx, tenantID, proj.ID()))
+	assert.Equal(t, want, proj.forTestValidTimes(), "events not rebuild in order per aggregate")
+}
+
+func testProjectionLanesOption(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+
+	_, err, _ := eventstore.New(adapter(), eventstore.WithProjectionLanes("projection_1", 0))
+	assert.Error(t, err)
+}
+
+// testProjectionLaneConflict holds a lane of the projection outside the store. The in memory locks are not released with
+// a transaction, so that a failed lock of the projection must release its locks itself.
+func testProjectionLaneConflict(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+	aggregateType := "forTestConcreteAggregate"
+
+	adp := adapter()
+	proj := &forTestLaneProjection{id: "projection_1", validTimes: make(map[string][]time.Time)}
+	store, err, _ := eventstore.New(adp,
+		eventstore.WithProjection(proj),
+		eventstore.WithProjectionType(proj.ID(), event.ESS),
+		eventstore.WithProjectionLanes(proj.ID(), 4),
+		eventstore.WithDeleteStrategy(aggregateType, event.HardDelete),
+	)
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+	errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 2, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 2, 0, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 3, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 3, 0, time.UTC)),
+	}))
+	if err != nil {
+		t.Fatalf("save aggregate failed: %s", err)
+	}
+	assert.NoError(t, <-errCh)
+
+	id := shared.NewProjectionID(tenantID, proj.ID())
+	if err = adp.ProjectionPort().LockLane(ctx, id, 2); err != nil {
+		t.Fatalf("lock of lane failed: %s", err)
+	}
+	assert.Error(t, store.DeleteEvent(ctx, tenantID, aggregateType, "1", _getEventID(t, store, tenantID, "1", 2)))
+
+	// the projection and its other lanes are not locked anymore
+	assert.NoError(t, adp.ProjectionPort().UnLockLane(ctx, id, 2))
+	assert.NoError(t, store.DeleteEvent(ctx, tenantID, aggregateType, "1", _getEventID(t, store, tenantID, "1", 3)))
+}
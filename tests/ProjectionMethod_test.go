@@ -8,6 +8,7 @@ import (
 	"github.com/global-soft-ba/go-eventstore/eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/domain/model/projection"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/scheduler"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/shared"
 	"github.com/global-soft-ba/go-eventstore/tests/testdata"
 	"github.com/google/uuid"
@@ -2338,3 +2339,309 @@ func testRemoveProjection(t *testing.T, adapter func() persistence.Port, cleanUp
 		})
 	}
 }
+
+func testScheduledFuturePatches(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	tenantID := uuid.New().String()
+
+	waitingTime := 100 * time.Millisecond
+
+	type args struct {
+		ctx        context.Context
+		aggregate  func(savePoint time.Time) event.AggregateWithEventSourcingSupport
+		eventStore func(adp persistence.Port) (event.EventStore, event.Projection)
+		afterSave  func(store event.EventStore) error
+	}
+	tests := []struct {
+		name             string
+		args             args
+		wantBeforeWakeUp int32
+		wantAfterWakeUp  int32
+	}{
+		{
+			name: "future patch is projected when valid time is reached",
+			args: args{
+				ctx: context.Background(),
+				aggregate: func(savePoint time.Time) event.AggregateWithEventSourcingSupport {
+					return newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+						ForTestMakeCreateEventNow("1", tenantID),
+						ForTestMakeEventWithValidTime("1", tenantID, savePoint.Add(waitingTime)),
+					})
+				},
+				eventStore: func(adp persistence.Port) (event.EventStore, event.Projection) {
+					proj := newTestProjectionTypeOne("projection_1", tenantID, 0, 10)
+					store, err, errCh := eventstore.New(adp, eventstore.WithProjection(proj))
+					if err != nil {
+						t.Errorf("test case preparation %v failed:%v", t.Name(), err)
+					}
+					if err = <-errCh; err != nil {
+						t.Errorf("test case preparation %v failed:%v", t.Name(), err)
+					}
+					return store, proj
+				},
+			},
+			wantBeforeWakeUp: 1,
+			wantAfterWakeUp:  2,
+		},
+		{
+			name: "future patches with the same valid time are projected together",
+			args: args{
+				ctx: context.Background(),
+				aggregate: func(savePoint time.Time) event.AggregateWithEventSourcingSupport {
+					return newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+						ForTestMakeCreateEventNow("1", tenantID),
+						ForTestMakeEventWithValidTime("1", tenantID, savePoint.Add(waitingTime)),
+						ForTestMakeEventWithValidTime("1", tenantID, savePoint.Add(waitingTime)),
+					})
+				},
+				eventStore: func(adp persistence.Port) (event.EventStore, event.Projection) {
+					proj := newTestProjectionTypeOne("projection_1", tenantID, 0, 10)
+					store, err, errCh := eventstore.New(adp, eventstore.WithProjection(proj))
+					if err != nil {
+						t.Errorf("test case preparation %v failed:%v", t.Name(), err)
+					}
+					if err = <-errCh; err != nil {
+						t.Errorf("test case preparation %v failed:%v", t.Name(), err)
+					}
+					return store, proj
+				},
+			},
+			wantBeforeWakeUp: 1,
+			wantAfterWakeUp:  3,
+		},
+		{
+			name: "future patch of a suspended and resumed tenant is projected when valid time is reached",
+			args: args{
+				ctx: context.Background(),
+				aggregate: func(savePoint time.Time) event.AggregateWithEventSourcingSupport {
+					return newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+						ForTestMakeCreateEventNow("1", tenantID),
+						ForTestMakeEventWithValidTime("1", tenantID, savePoint.Add(waitingTime)),
+					})
+				},
+				eventStore: func(adp persistence.Port) (event.EventStore, event.Projection) {
+					proj := newTestProjectionTypeOne("projection_1", tenantID, 0, 10)
+					store, err, errCh := eventstore.New(adp, eventstore.WithProjection(proj))
+					if err != nil {
+						t.Errorf("test case preparation %v failed:%v", t.Name(), err)
+					}
+					if err = <-errCh; err != nil {
+						t.Errorf("test case preparation %v failed:%v", t.Name(), err)
+					}
+					return store, proj
+				},
+				afterSave: func(store event.EventStore) error {
+					if err := store.SuspendTenant(context.Background(), tenantID); err != nil {
+						return err
+					}
+					return store.ResumeTenant(context.Background(), tenantID)
+				},
+			},
+			wantBeforeWakeUp: 1,
+			wantAfterWakeUp:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adp := adapter()
+			store, proj := tt.args.eventStore(adp)
+			defer cleanUp()
+
+			savePoint := time.Now()
+			errCh, err := event.SaveAggregate(tt.args.ctx, store, tt.args.aggregate(savePoint))
+			if err != nil {
+				t.Fatalf("SaveAggregate() failed: %v", err)
+			}
+			if err = <-errCh; err != nil {
+				t.Errorf("SaveAggregate() projection failed: %v", err)
+			}
+
+			if got := proj.(*forTestProjection).eventCounter.Load(); got != tt.wantBeforeWakeUp {
+				t.Errorf("projected events before valid time = %v, want %v", got, tt.wantBeforeWakeUp)
+			}
+			if tt.args.afterSave != nil {
+				if err = tt.args.afterSave(store); err != nil {
+					t.Fatalf("afterSave() failed: %v", err)
+				}
+			}
+
+			// no further events are saved, so only the scheduler can wake up the projection
+			time.Sleep(3 * waitingTime)
+
+			if got := proj.(*forTestProjection).eventCounter.Load(); got != tt.wantAfterWakeUp {
+				t.Errorf("projected events after valid time = %v, want %v", got, tt.wantAfterWakeUp)
+			}
+
+			var openTasks []scheduler.ScheduledProjectionTask
+			err = adp.Transactor().WithoutTX(tt.args.ctx, func(txCtx context.Context) (err error) {
+				openTasks, err = adp.SchedulerPort().GetOpenTasks(txCtx, shared.NewProjectionID(tenantID, proj.ID()))
+				return err
+			})
+			if err != nil {
+				t.Errorf("GetOpenTasks() failed: %v", err)
+			}
+			if len(openTasks) != 0 {
+				t.Errorf("GetOpenTasks() got %v open tasks, want none", len(openTasks))
+			}
+		})
+	}
+}
+
+// testScheduledFuturePatchesAfterRestart stops the timers of the first store (by the suspension of the tenant) before
+// the valid time of the future patch is reached, so that only the restarted store can wake up the projection.
+func testScheduledFuturePatchesAfterRestart(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.New().String()
+	ctx := context.Background()
+	waitingTime := 200 * time.Millisecond
+
+	adp := adapter()
+	newStore := func(proj event.Projection) event.EventStore {
+		store, err, errCh := eventstore.New(adp, eventstore.WithProjection(proj))
+		if err != nil {
+			t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+		}
+		if err = <-errCh; err != nil {
+			t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+		}
+		return store
+	}
+
+	store := newStore(newTestProjectionTypeOne("projection_1", tenantID, 0, 10))
+	errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEventNow("1", tenantID),
+		ForTestMakeEventWithValidTime("1", tenantID, time.Now().Add(waitingTime)),
+	}))
+	if err != nil {
+		t.Fatalf("SaveAggregate() failed: %v", err)
+	}
+	if err = <-errCh; err != nil {
+		t.Errorf("SaveAggregate() projection failed: %v", err)
+	}
+
+	// shutdown of the first store, the tenant stays active in the database
+	if err = store.SuspendTenant(ctx, tenantID); err != nil {
+		t.Fatalf("SuspendTenant() failed: %v", err)
+	}
+	err = adp.Transactor().WithinTX(ctx, func(txCtx context.Context) error {
+		return adp.TenantPort().SetStatus(txCtx, tenantID, event.TenantActive, time.Now())
+	})
+	if err != nil {
+		t.Fatalf("SetStatus() failed: %v", err)
+	}
+
+	proj := newTestProjectionTypeOne("projection_1", tenantID, 0, 10)
+	newStore(proj)
+	if got := proj.(*forTestProjection).eventCounter.Load(); got != 0 {
+		t.Errorf("projected events before valid time = %v, want %v", got, 0)
+	}
+
+	// no further events are saved, so only the re-armed task can wake up the projection
+	time.Sleep(3 * waitingTime)
+
+	if got := proj.(*forTestProjection).eventCounter.Load(); got != 1 {
+		t.Errorf("projected events after valid time = %v, want %v", got, 1)
+	}
+
+	var openTasks []scheduler.ScheduledProjectionTask
+	err = adp.Transactor().WithoutTX(ctx, func(txCtx context.Context) (err error) {
+		openTasks, err = adp.SchedulerPort().GetOpenTasks(txCtx, shared.NewProjectionID(tenantID, proj.ID()))
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, openTasks, "executed task not deleted")
+}
+
+// forTestFailingProjection simulates an instance (pod) of the event store, which is not able to execute the projection
+// itself. The events can only be projected by another instance.
+type forTestFailingProjection struct {
+	event.Projection
+}
+
+func (f forTestFailingProjection) Execute(_ context.Context, _ []event.IEvent) error {
+	return fmt.Errorf("provoked error")
+}
+
+func testProjectionNotifications(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	tenantID := "0000-0000-0000"
+	waitingTime := 500 * time.Millisecond
+
+	type args struct {
+		// listeningStore is the instance which is able to execute the projection
+		listeningStore func(ctx context.Context, adp persistence.Port, proj event.Projection) (event.EventStore, error, chan error)
+	}
+	tests := []struct {
+		name string
+		args args
+		want int32
+	}{
+		{
+			name: "projection is woken up by the notification of another instance",
+			args: args{
+				listeningStore: func(ctx context.Context, adp persistence.Port, proj event.Projection) (event.EventStore, error, chan error) {
+					return eventstore.New(adp, eventstore.WithProjection(proj), eventstore.WithProjectionNotifications(ctx))
+				},
+			},
+			want: 2,
+		},
+		{
+			name: "projection is not woken up without listening for notifications",
+			args: args{
+				listeningStore: func(ctx context.Context, adp persistence.Port, proj event.Projection) (event.EventStore, error, chan error) {
+					return eventstore.New(adp, eventstore.WithProjection(proj))
+				},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			defer cleanUp()
+			adp := adapter()
+
+			proj := newTestProjectionTypeOne("projection_1", tenantID, 0, 10)
+			_, err, errCh := tt.args.listeningStore(ctx, adp, proj)
+			if err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+			if err = <-errCh; err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+
+			savingStore, err, errCh := eventstore.New(adp, eventstore.WithProjection(forTestFailingProjection{newTestProjectionTypeOne("projection_1", tenantID, 0, 10)}))
+			if err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+			if err = <-errCh; err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+
+			errCh, err = event.SaveAggregate(context.Background(), savingStore, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+				ForTestMakeCreateEventNow("1", tenantID),
+				ForTestMakeEventNow("1", tenantID),
+			}))
+			if err != nil {
+				t.Fatalf("SaveAggregate() failed: %v", err)
+			}
+			// the saving instance is not able to execute the projection
+			for range errCh {
+			}
+
+			deadline := time.Now().Add(waitingTime)
+			for proj.(*forTestProjection).eventCounter.Load() < tt.want && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if tt.want == 0 {
+				time.Sleep(waitingTime)
+			}
+
+			if got := proj.(*forTestProjection).eventCounter.Load(); got != tt.want {
+				t.Errorf("projected events of listening instance = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
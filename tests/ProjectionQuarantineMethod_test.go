@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// forTestPoisonProjection fails for all events of the poisoned aggregate.
+type forTestPoisonProjection struct {
+	mu       sync.Mutex
+	id       string
+	poisoned string
+	events   []event.IEvent
+}
+
+func (f *forTestPoisonProjection) ID() string {
+	return f.id
+}
+
+func (f *forTestPoisonProjection) EventTypes() []string {
+	return []string{event.EventType(&forTestEvent{})}
+}
+
+func (f *forTestPoisonProjection) ChunkSize() int {
+	return 10
+}
+
+func (f *forTestPoisonProjection) Execute(_ context.Context, events []event.IEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, evt := range events {
+		if evt.GetAggregateID() == f.poisoned {
+			return fmt.Errorf("provoked error for aggregate %q", f.poisoned)
+		}
+	}
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func (f *forTestPoisonProjection) PrepareRebuild(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *forTestPoisonProjection) FinishRebuild(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *forTestPoisonProjection) forTestPoison(aggregateID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.poisoned = aggregateID
+}
+
+func (f *forTestPoisonProjection) forTestAggregateIDs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ids []string
+	for _, evt := range f.events {
+		ids = append(ids, evt.GetAggregateID())
+	}
+	return ids
+}
+
+func testProjectionRetry(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+
+	// the first execution fails
+	proj := newTestProjectionTypeOneWithExecuteFail("projection_1", tenantID, 10, true, 1)
+	store, err, _ := eventstore.New(adapter(),
+		eventstore.WithProjection(proj),
+		eventstore.WithProjectionRetry(proj.ID(), 2, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+	}))
+	if err != nil {
+		t.Fatalf("save aggregate failed: %s", err)
+	}
+	assert.NoError(t, <-errCh)
+	assert.Len(t, proj.(*forTestProjection).ForTestGetEvents(), 2, "events not projected after retry")
+
+	states, err := store.GetProjectionStates(ctx, tenantID, proj.ID())
+	if assert.NoError(t, err) && assert.Len(t, states, 1) {
+		assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond}, states[0].RetryDurations)
+	}
+}
+
+func testProjectionQuarantine(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+
+	proj := &forTestPoisonProjection{id: "projection_1", poisoned: "poison"}
+	store, err, _ := eventstore.New(adapter(),
+		eventstore.WithProjection(proj),
+		eventstore.WithProjectionRetry(proj.ID(), 1, time.Millisecond),
+		eventstore.WithProjectionQuarantine(proj.ID()),
+	)
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	save := func(aggregateID string, nanoSec int) {
+		errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate(aggregateID, "Name", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEvent(aggregateID, tenantID, time.Date(2021, 1, 1, 1, 1, 1, nanoSec, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, nanoSec, time.UTC)),
+		}))
+		if err != nil {
+			t.Fatalf("save aggregate failed: %s", err)
+		}
+		assert.NoError(t, <-errCh)
+	}
+	save("1", 1)
+	save("poison", 2)
+	save("2", 3)
+
+	assert.Equal(t, []string{"1", "2"}, proj.forTestAggregateIDs(), "events not projected besides the poisoned event")
+	quarantined, err := store.GetQuarantinedEvents(ctx, tenantID, proj.ID())
+	if assert.NoError(t, err) && assert.Len(t, quarantined, 1) {
+		assert.Equal(t, "poison", quarantined[0].Event.AggregateID)
+		assert.Equal(t, proj.ID(), quarantined[0].ProjectionID)
+		assert.Contains(t, quarantined[0].LastError, "provoked error")
+	}
+
+	// replay
+	proj.forTestPoison("")
+	errCh, err := store.ReplayQuarantinedEvents(ctx, tenantID, proj.ID(), quarantined[0].Event.ID)
+	if assert.NoError(t, err) {
+		assert.NoError(t, <-errCh)
+	}
+	assert.Equal(t, []string{"1", "2", "poison"}, proj.forTestAggregateIDs())
+	quarantined, err = store.GetQuarantinedEvents(ctx, tenantID, proj.ID())
+	assert.NoError(t, err)
+	assert.Empty(t, quarantined)
+
+	// discard
+	proj.forTestPoison("poison2")
+	save("poison2", 4)
+	quarantined, err = store.GetQuarantinedEvents(ctx, tenantID, proj.ID())
+	if assert.NoError(t, err) && assert.Len(t, quarantined, 1) {
+		assert.NoError(t, store.DiscardQuarantinedEvents(ctx, tenantID, proj.ID(), quarantined[0].Event.ID))
+	}
+	quarantined, err = store.GetQuarantinedEvents(ctx, tenantID, proj.ID())
+	assert.NoError(t, err)
+	assert.Empty(t, quarantined)
+	assert.Equal(t, []string{"1", "2", "poison"}, proj.forTestAggregateIDs())
+
+	assert.Error(t, store.DiscardQuarantinedEvents(ctx, tenantID, proj.ID(), "unknown"))
+}
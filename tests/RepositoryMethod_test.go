@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testRepository(t *testing.T, eventStoreFactory func() event.EventStore, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	store := eventStoreFactory()
+
+	var applied []event.IEvent
+	repo := event.NewRepository[forTestConcreteAggregate](store, event.EventApplierFunc[forTestConcreteAggregate](
+		func(version int, eventStream ...event.IEvent) (forTestConcreteAggregate, error) {
+			applied = eventStream
+			return forTestConcreteAggregate{}.LoadFromEventStream(version, eventStream...)
+		}))
+	assert.Equal(t, "forTestConcreteAggregate", repo.AggregateType())
+	assert.Equal(t, repo.AggregateType(), event.NewRepository[*forTestConcreteAggregate](store, nil).AggregateType())
+
+	t1 := time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)
+	t2 := time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)
+	t3 := time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)
+
+	// save a new aggregate
+	created := newForTestConcreteAggregate("1", "", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, t1, t1),
+		ForTestMakeEvent("1", tenantID, t2, t2),
+	})
+	saved, errCh, err := repo.Save(ctx, created)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, "1", saved.GetID())
+	assert.Equal(t, 2, saved.GetVersion())
+	assert.Empty(t, saved.GetUnsavedChanges())
+
+	// the version is carried forward
+	changed, err := saved.ApplyEvent(ForTestMakeEvent("1", tenantID, t3, t3))
+	if !assert.NoError(t, err) {
+		return
+	}
+	saved, _, err = repo.Save(ctx, changed)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 3, saved.GetVersion())
+
+	// an outdated aggregate fails with the Fail strategy
+	outdated, _ := newForTestConcreteAggregate("1", "", 2, tenantID, nil).ApplyEvent(ForTestMakeEvent("1", tenantID, t3, t3))
+	saved, _, err = repo.Save(ctx, outdated)
+	var errConcurrent *event.ErrorConcurrentModification
+	assert.ErrorAs(t, err, &errConcurrent)
+	assert.Zero(t, saved)
+
+	// temporal loads
+	got, err := repo.Get(ctx, tenantID, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, got.GetVersion())
+	assert.Len(t, applied, 3)
+
+	_, err = repo.GetAsAt(ctx, tenantID, "1", t1)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+
+	_, err = repo.GetAsOf(ctx, tenantID, "1", t2)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 2)
+
+	_, err = repo.GetAsOfTill(ctx, tenantID, "1", t3, t2)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 2)
+
+	// snapshot events are passed to the applier as first event of the stream
+	t4 := time.Date(2021, 1, 1, 1, 1, 1, 4, time.UTC)
+	withSnapshot, _ := got.ApplyEvent(ForTestMakeSnapshot("1", tenantID, t4, t4))
+	saved, _, err = repo.Save(ctx, withSnapshot)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 3, saved.GetVersion())
+	if assert.Len(t, applied, 1) {
+		assert.Equal(t, event.SnapShot, applied[0].GetClass())
+	}
+
+	// an aggregate with only future events is returned as stored
+	future := time.Now().Add(time.Hour)
+	saved, _, err = repo.Save(ctx, newForTestConcreteAggregate("2", "", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("2", tenantID, time.Now(), future),
+	}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2", saved.GetID())
+		assert.Equal(t, 1, saved.GetVersion())
+	}
+
+	// unknown aggregate
+	_, err = repo.Get(ctx, tenantID, "unknown")
+	var errEmpty *event.ErrorEmptyEventStream
+	assert.ErrorAs(t, err, &errEmpty)
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"github.com/global-soft-ba/go-eventstore"
 	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/consistentClock"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
 	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence/transactor"
+	clock "github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/consistentClock"
 	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/persistence/postgres"
 	"github.com/global-soft-ba/go-eventstore/tests/testdata"
 	"reflect"
@@ -1621,10 +1623,11 @@ func testSaveAggregates(t *testing.T, adapter func() persistence.Port, cleanUp f
 			if !tt.wantErr && err != nil {
 				t.Error(err)
 			}
-			//hack for UUID
+			//hack for UUID and log position (positions are tested in testLoadFromPosition)
 			for sId, stream := range eventStream {
 				for eId, pEvent := range stream.Events {
 					tt.expected[sId].Events[eId].ID = pEvent.ID
+					tt.expected[sId].Events[eId].Position = pEvent.Position
 				}
 			}
 
@@ -2174,3 +2177,90 @@ func testSaveAggregatesConcurrently(t *testing.T, adapter func() persistence.Por
 		})
 	}
 }
+
+func testSaveAggregateWithHybridLogicalClock(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	tenantID := "0000-0000-0000"
+	persistedTime := time.Now().Add(time.Hour).UTC()
+
+	type args struct {
+		maxSkew time.Duration
+		policy  clock.SkewPolicy
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantInitErr bool
+	}{
+		{
+			name: "wall clock behind persisted transaction time (refuse)",
+			args: args{
+				maxSkew: time.Minute,
+				policy:  clock.RefuseOnSkew,
+			},
+			wantInitErr: true,
+		},
+		{
+			name: "wall clock behind persisted transaction time (warn)",
+			args: args{
+				maxSkew: time.Minute,
+				policy:  clock.WarnOnSkew,
+			},
+			wantInitErr: false,
+		},
+		{
+			name: "wall clock behind persisted transaction time (no skew check)",
+			args: args{
+				maxSkew: 0,
+				policy:  clock.RefuseOnSkew,
+			},
+			wantInitErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer cleanUp()
+			adp := adapter()
+
+			store, err, _ := eventstore.New(adp)
+			if err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+			_, err = event.SaveAggregate(context.Background(), store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+				ForTestMakeCreateEvent("1", tenantID, persistedTime, persistedTime),
+			}))
+			if err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+
+			store, err, _ = eventstore.New(adp, eventstore.WithConsistentClock(clock.NewHybridLogicalClock(tt.args.maxSkew, tt.args.policy)))
+			if (err != nil) != tt.wantInitErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantInitErr)
+			}
+			if tt.wantInitErr {
+				if !errors.Is(err, &consistentClock.ErrorClockSkew{}) {
+					t.Errorf("New() wrong error type got %v want %v", err, reflect.TypeOf(&consistentClock.ErrorClockSkew{}))
+				}
+				return
+			}
+
+			_, err = event.SaveAggregate(context.Background(), store, newForTestConcreteAggregate("1", "Name", 1, tenantID, []event.IEvent{
+				ForTestMakeEventNow("1", tenantID),
+			}))
+			if err != nil {
+				t.Fatalf("SaveAggregate() error = %v", err)
+			}
+
+			events, _, err := store.LoadAsAt(context.Background(), tenantID, "forTestConcreteAggregate", "1", persistedTime.Add(time.Hour))
+			if err != nil {
+				t.Fatalf("LoadAsAt() error = %v", err)
+			}
+			if len(events) != 2 {
+				t.Fatalf("LoadAsAt() got %d events, want %d", len(events), 2)
+			}
+			if !events[1].TransactionTime.After(persistedTime) {
+				t.Errorf("transaction time %v of new event is not after persisted transaction time %v", events[1].TransactionTime, persistedTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// forTestEvent5 is encoded by the binary forTestGobSerializer.
+type forTestEvent5 struct {
+	event.Event
+	Title string
+}
+
+func init() {
+	event.RegisterEventAndAggregate(forTestEvent5{}, reflect.TypeOf(forTestConcreteAggregate{}).Name())
+}
+
+func ForTestMakeEvent5(aggregateID, tenantID string, transactionTimestamp, validTimestamp time.Time, title string) event.IEvent {
+	e := event.NewMigrationEvent(aggregateID, tenantID, validTimestamp, transactionTimestamp, event.InstantEvent)
+	return &forTestEvent5{Event: e, Title: title}
+}
+
+type forTestGobSerializer struct{}
+
+func (f forTestGobSerializer) Codec() string {
+	return "gob"
+}
+
+func (f forTestGobSerializer) Marshal(evt any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(evt)
+	return buf.Bytes(), err
+}
+
+func (f forTestGobSerializer) Unmarshal(data []byte, evt any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(evt)
+}
+
+func testSerializer(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := "0000-0000-0000"
+	ctx := context.Background()
+
+	store, err, _ := eventstore.New(adapter(), eventstore.WithSerializer(forTestGobSerializer{}, event.EventType(forTestEvent5{})))
+	if err != nil {
+		t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+	}
+	_, err = event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+		ForTestMakeEvent3("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), "searchable"),
+		ForTestMakeEvent5("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), "searchable"),
+	}))
+	if err != nil {
+		t.Fatalf("SaveAggregate() error = %v", err)
+	}
+
+	persisted, _, err := store.LoadAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now())
+	if err != nil {
+		t.Fatalf("LoadAsAt() error = %v", err)
+	}
+	if gotCodecs := []string{persisted[0].Codec, persisted[1].Codec, persisted[2].Codec}; !reflect.DeepEqual(gotCodecs, []string{"", "", "gob"}) {
+		t.Errorf("LoadAsAt() got codecs %v, want %v", gotCodecs, []string{"", "", "gob"})
+	}
+	if !json.Valid(persisted[2].Data) {
+		t.Errorf("LoadAsAt() got invalid JSON data %q", persisted[2].Data)
+	}
+
+	eventStream, _, err := event.LoadAggregateAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now(), store)
+	if err != nil {
+		t.Fatalf("LoadAggregateAsAt() error = %v", err)
+	}
+	if got, ok := eventStream[2].(*forTestEvent5); !ok || got.Title != "searchable" || got.GetAggregateID() != "1" {
+		t.Errorf("LoadAggregateAsAt() got event %+v, want %T with title %q", eventStream[2], &forTestEvent5{}, "searchable")
+	}
+
+	// only the data of JSON encoded events is searchable
+	_, _, err = store.GetAggregatesEvents(ctx, tenantID, event.PageDTO{
+		PageSize:     10,
+		SearchFields: []event.SearchField{{Name: event.SearchData, Value: "searchable", Operator: event.SearchMatch}},
+	})
+	if err == nil {
+		t.Errorf("GetAggregatesEvents() error = nil, want error for the data of codec %q", "gob")
+	}
+
+	found, _, err := store.GetAggregatesEvents(ctx, tenantID, event.PageDTO{
+		PageSize: 10,
+		SearchFields: []event.SearchField{
+			{Name: event.SearchAggregateEventType, Value: event.EventType(forTestEvent3{}), Operator: event.SearchEqual},
+			{Name: event.SearchData, Value: "searchable", Operator: event.SearchMatch},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetAggregatesEvents() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Type != event.EventType(forTestEvent3{}) {
+		t.Errorf("GetAggregatesEvents() got %d events, want 1 event of type %q", len(found), event.EventType(forTestEvent3{}))
+	}
+}
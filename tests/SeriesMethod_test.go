@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/transactor/postgres/temporal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testLoadAllOfAggregateTypeAsOfSeries(t *testing.T, eventStoreFactory func() event.EventStore, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	aggregateType := "forTestConcreteAggregate"
+
+	store := eventStoreFactory()
+	_, err := store.SaveAll(ctx, tenantID, []event.PersistenceEvents{
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0()),
+				resetVersionForSave(ForTestEvent1()),
+				resetVersionForSave(ForTestEvent2()),
+				resetVersionForSave(ForTestEventPatch()),
+				resetVersionForSave(ForTestEventFuturePatch()),
+			},
+			Version: 0,
+		},
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0_Aggregate2()),
+			},
+			Version: 0,
+		},
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0_Aggregate3()),
+			},
+			Version: 0,
+		},
+	})
+	if err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+
+	t1 := time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)
+	t2 := time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)
+	t3 := time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)
+
+	// an aggregate with a snapshot (of another tenant)
+	snapshotTenantID := "0000-0000-0001"
+	withSnapshot, _ := newForTestConcreteAggregate("5", "", 0, snapshotTenantID, nil).ApplyEvent(ForTestMakeCreateEvent("5", snapshotTenantID, t1, t1))
+	withSnapshot, _ = withSnapshot.ApplyEvent(ForTestMakeEvent("5", snapshotTenantID, t2, t2))
+	withSnapshot, _ = withSnapshot.ApplyEvent(ForTestMakeSnapshot("5", snapshotTenantID, t2, t2))
+	withSnapshot, _ = withSnapshot.ApplyEvent(ForTestMakeEvent("5", snapshotTenantID, t3, t3))
+	if _, err = event.SaveAggregate(ctx, store, withSnapshot); err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+
+	projectionTimes := []time.Time{
+		time.Date(2051, 1, 1, 1, 1, 1, 0, time.UTC),
+		t2,
+		time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC),
+		t3,
+		t2,
+	}
+	wantProjectionTimes := []time.Time{projectionTimes[2], t2, t3, projectionTimes[0]}
+
+	t.Run("slices equal single loads", func(t *testing.T) {
+		series, err := store.LoadAllOfAggregateTypeAsOfSeries(ctx, tenantID, aggregateType, projectionTimes)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, series, 2, "aggregate 3 is of another type")
+		for _, s := range series {
+			assert.Equal(t, wantProjectionTimes, s.ProjectionTimes)
+			assertEqualSeries(t, store, tenantID, aggregateType, s)
+		}
+	})
+
+	t.Run("snapshot at the earliest projection time", func(t *testing.T) {
+		series, err := store.LoadAllOfAggregateTypeAsOfSeries(ctx, snapshotTenantID, aggregateType, []time.Time{t3, t2})
+		if !assert.NoError(t, err) || !assert.Len(t, series, 1) {
+			return
+		}
+		assert.Equal(t, event.SnapShot, series[0].Events[0].Class)
+		assert.Equal(t, []int{1, 2}, series[0].Ends)
+		assertEqualSeries(t, store, snapshotTenantID, aggregateType, series[0])
+	})
+
+	t.Run("fold series", func(t *testing.T) {
+		series, err := event.LoadAllOfAggregateTypeAsOfSeries(ctx, tenantID, aggregateType, projectionTimes, store)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, s := range series {
+			counts, err := event.FoldSeries(s, 0, func(count int, events ...event.IEvent) (int, error) {
+				return count + len(events), nil
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			for i := range s.ProjectionTimes {
+				assert.Equal(t, len(s.At(i)), counts[i])
+			}
+
+			count := 0
+			for projectionTime, eventStream := range s.All() {
+				assert.Equal(t, s.ProjectionTimes[count], projectionTime)
+				assert.Equal(t, s.At(count), eventStream)
+				count++
+			}
+			assert.Equal(t, len(s.ProjectionTimes), count)
+		}
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		series, err := store.LoadAllOfAggregateTypeAsOfSeries(ctx, "unknown tenant", aggregateType, projectionTimes)
+		assert.NoError(t, err)
+		assert.Empty(t, series)
+
+		_, err = store.LoadAllOfAggregateTypeAsOfSeries(ctx, tenantID, aggregateType, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("projection times between", func(t *testing.T) {
+		got, err := event.ProjectionTimesBetween(time.Date(2021, 1, 15, 1, 0, 0, 0, time.UTC), time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC), temporal.Month)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		}, got)
+
+		got, err = event.ProjectionTimesBetween(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2021, 1, 3, 11, 0, 0, 0, time.UTC), temporal.Day)
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+
+		_, err = event.ProjectionTimesBetween(t1, t2, "fortnight")
+		assert.Error(t, err)
+
+		_, err = event.ProjectionTimesBetween(t2, t1, temporal.Day)
+		assert.Error(t, err)
+	})
+}
+
+// assertEqualSeries compares the event streams of the series with the ones of LoadAllOfAggregateTypeAsOf.
+func assertEqualSeries(t *testing.T, store event.EventStore, tenantID, aggregateType string, series event.PersistenceEventsSeries) {
+	t.Helper()
+	aggregateID := series.Events[0].AggregateID
+	for i, projectionTime := range series.ProjectionTimes {
+		want, err := store.LoadAllOfAggregateTypeAsOf(context.Background(), tenantID, aggregateType, projectionTime)
+		if err != nil {
+			var errEmpty *event.ErrorEmptyEventStream
+			assert.ErrorAs(t, err, &errEmpty)
+		}
+		assert.Equalf(t, forTestEventsOfAggregate(want, aggregateID), series.At(i), "aggregate %q at %s", aggregateID, projectionTime)
+	}
+}
+
+func forTestEventsOfAggregate(eventStreams []event.PersistenceEvents, aggregateID string) []event.PersistenceEvent {
+	for _, eventStream := range eventStreams {
+		if eventStream.Events[0].AggregateID == aggregateID {
+			return eventStream.Events
+		}
+	}
+	return []event.PersistenceEvent{}
+}
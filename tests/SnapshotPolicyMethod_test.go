@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"context"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// forTestSnapshotter records the number of events, from which it created the snapshots.
+type forTestSnapshotter struct {
+	mu     sync.Mutex
+	events map[string]int
+}
+
+func (f *forTestSnapshotter) Snapshot(_ context.Context, tenantID, aggregateID string, events []event.IEvent) (event.IEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events[aggregateID] = len(events)
+	return &forTestEvent{Event: event.NewSnapShot(aggregateID, tenantID)}, nil
+}
+
+func (f *forTestSnapshotter) forTestEvents(aggregateID string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.events[aggregateID]
+}
+
+func testSnapshotPolicy(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+	aggregateType := "forTestConcreteAggregate"
+
+	snapshotter := &forTestSnapshotter{events: make(map[string]int)}
+	store, err, _ := eventstore.New(adapter(), eventstore.WithSnapshotPolicy(aggregateType, snapshotter, event.SnapshotPolicy{EveryNEvents: 3}))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	save := func(aggregateID string, version int, events ...event.IEvent) {
+		errCh, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate(aggregateID, "Name", version, tenantID, events))
+		if err != nil {
+			t.Fatalf("save aggregate failed: %s", err)
+		}
+		assert.NoError(t, <-errCh)
+	}
+	getCoverage := func() event.SnapshotCoverage {
+		coverage, err := store.GetSnapshotCoverage(ctx, tenantID, aggregateType)
+		if err != nil {
+			t.Fatalf("get snapshot coverage failed: %s", err)
+		}
+		return coverage
+	}
+
+	// the policy is not due
+	save("1", 0,
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+	)
+	// a snapshot, which is created wrongly in the background, is detected by the number of events of the snapshotter
+	// after the next save
+	coverage := getCoverage()
+	assert.Equal(t, 1, coverage.Aggregates)
+	assert.Zero(t, coverage.SnapshotAggregates)
+	assert.Equal(t, 2, coverage.EventsSinceSnapshots)
+	assert.Zero(t, coverage.CreatedSnapshots)
+
+	// the policy is due
+	save("1", 2, ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)))
+	assert.Eventually(t, func() bool { return getCoverage().CreatedSnapshots == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, snapshotter.forTestEvents("1"))
+
+	events, version, err := store.LoadAsAt(ctx, tenantID, aggregateType, "1", time.Now())
+	if assert.NoError(t, err) && assert.Len(t, events, 1) {
+		assert.Equal(t, event.HistoricalSnapShot, events[0].Class)
+		assert.Equal(t, 3, version)
+	}
+	coverage = getCoverage()
+	assert.Equal(t, 1, coverage.SnapshotAggregates)
+	assert.Zero(t, coverage.EventsSinceSnapshots)
+	assert.Equal(t, 1.0, coverage.Ratio())
+
+	// the policy is due, but the stream has no patch-free period because of a pending future patch
+	save("2", 0,
+		ForTestMakeCreateEvent("2", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		ForTestMakeEvent("2", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+		ForTestMakePatchEvent("2", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC), time.Date(2099, 1, 1, 1, 1, 1, 1, time.UTC)),
+		ForTestMakeEvent("2", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 4, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 4, time.UTC)),
+	)
+	assert.Eventually(t, func() bool { return getCoverage().SkippedSnapshots == 1 }, time.Second, 10*time.Millisecond)
+	coverage = getCoverage()
+	assert.Equal(t, 2, coverage.Aggregates)
+	assert.Equal(t, 1, coverage.SnapshotAggregates)
+	assert.Equal(t, 1, coverage.CreatedSnapshots)
+	assert.Equal(t, 0.5, coverage.Ratio())
+	assert.Zero(t, snapshotter.forTestEvents("2"))
+}
+
+func testSnapshotPolicyOption(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+
+	_, err, _ := eventstore.New(adapter(), eventstore.WithSnapshotPolicy("forTestConcreteAggregate", nil, event.SnapshotPolicy{EveryNEvents: 3}))
+	assert.Error(t, err)
+	_, err, _ = eventstore.New(adapter(), eventstore.WithSnapshotPolicy("forTestConcreteAggregate", &forTestSnapshotter{}, event.SnapshotPolicy{}))
+	assert.Error(t, err)
+}
+
+func testSnapshotPolicyShutdown(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := uuid.NewString()
+	ctx := context.Background()
+	aggregateType := "forTestConcreteAggregate"
+
+	lifecycleCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	snapshotter := &forTestSnapshotter{events: make(map[string]int)}
+	store, err, _ := eventstore.New(adapter(),
+		eventstore.WithSnapshotPolicy(aggregateType, snapshotter, event.SnapshotPolicy{EveryNEvents: 1}),
+		eventstore.WithSnapshotContext(lifecycleCtx))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	save := func(aggregateID string) {
+		_, err := event.SaveAggregate(ctx, store, newForTestConcreteAggregate(aggregateID, "Name", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEvent(aggregateID, tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		}))
+		if err != nil {
+			t.Fatalf("save aggregate failed: %s", err)
+		}
+	}
+	getCreated := func() int {
+		coverage, err := store.GetSnapshotCoverage(ctx, tenantID, aggregateType)
+		if err != nil {
+			t.Fatalf("get snapshot coverage failed: %s", err)
+		}
+		return coverage.CreatedSnapshots
+	}
+
+	save("1")
+	assert.NoError(t, store.WaitForSnapshots(ctx))
+	assert.Equal(t, 1, getCreated())
+
+	// no snapshots are started after the shutdown
+	cancel()
+	save("2")
+	assert.NoError(t, store.WaitForSnapshots(ctx))
+	assert.Equal(t, 1, getCreated())
+	assert.Zero(t, snapshotter.forTestEvents("2"))
+}
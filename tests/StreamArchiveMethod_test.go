@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/global-soft-ba/go-eventstore/eventstore/infrastructure/archive/filesystem"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testStreamArchive(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	aggregateType := "forTestConcreteAggregate"
+
+	adp := adapter()
+	archive := filesystem.New(t.TempDir())
+	store, err, _ := eventstore.New(adp,
+		eventstore.WithStreamArchive(archive, time.Hour, event.RehydrateArchivedStreams),
+		eventstore.WithDeleteStrategy(aggregateType, event.HardDelete))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+	rejecting, err, _ := eventstore.New(adp, eventstore.WithStreamArchive(archive, time.Hour, event.RejectArchivedStreams))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	closed := newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+		ForTestMakeCloseEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)),
+	})
+	open := newForTestConcreteAggregate("2", "Name", 0, tenantID, []event.IEvent{
+		ForTestMakeCreateEvent("2", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+	})
+	for _, aggregate := range []forTestConcreteAggregate{closed, open} {
+		if _, err = event.SaveAggregate(ctx, store, aggregate); err != nil {
+			t.Fatalf("save aggregate failed: %s", err)
+		}
+	}
+
+	wantEventStream, wantVersion, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), store)
+	if err != nil {
+		t.Fatalf("load aggregate failed: %s", err)
+	}
+	closeEventID := _getEventID(t, store, tenantID, "1", 3)
+
+	archiveClosedStream := func(t *testing.T) {
+		archived, err := store.ArchiveClosedStreams(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, archived)
+
+		events, _, err := store.GetAggregatesEvents(ctx, tenantID, event.PageDTO{PageSize: 100})
+		assert.NoError(t, err)
+		for _, evt := range events {
+			assert.Equal(t, "2", evt.AggregateID)
+		}
+	}
+
+	t.Run("archive closed streams", func(t *testing.T) {
+		archiveClosedStream(t)
+
+		// archived streams are not archived again
+		archived, err := store.ArchiveClosedStreams(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, archived)
+
+		// the aggregate state remains
+		states, err := store.GetAggregateStatesForAggregateType(ctx, tenantID, aggregateType)
+		assert.NoError(t, err)
+		assert.Len(t, states, 2)
+	})
+
+	t.Run("reject archived stream", func(t *testing.T) {
+		_, _, err := event.LoadAggregateAsOf(ctx, tenantID, aggregateType, "1", time.Now(), rejecting)
+		var errArchived *event.ErrorStreamArchived
+		if assert.True(t, errors.As(err, &errArchived)) {
+			assert.Equal(t, "1", errArchived.AggregateID)
+			assert.False(t, errArchived.ArchivedAt.IsZero())
+		}
+	})
+
+	t.Run("load rehydrates archived stream", func(t *testing.T) {
+		gotEventStream, gotVersion, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), store)
+		if assert.NoError(t, err) {
+			assert.Equal(t, wantEventStream, gotEventStream)
+			assert.Equal(t, wantVersion, gotVersion)
+		}
+
+		// the rehydrated stream can be loaded without rehydration
+		_, _, err = event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), rejecting)
+		assert.NoError(t, err)
+	})
+
+	t.Run("restore archived stream", func(t *testing.T) {
+		archiveClosedStream(t)
+		if !assert.NoError(t, rejecting.RestoreArchivedStream(ctx, tenantID, aggregateType, "1")) {
+			return
+		}
+
+		gotEventStream, _, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), rejecting)
+		if assert.NoError(t, err) {
+			assert.Equal(t, wantEventStream, gotEventStream)
+		}
+
+		// streams, which are not archived, are ignored
+		assert.NoError(t, rejecting.RestoreArchivedStream(ctx, tenantID, aggregateType, "2"))
+	})
+
+	t.Run("save rehydrates archived stream", func(t *testing.T) {
+		archiveClosedStream(t)
+
+		// historical patches of closed streams are still allowed
+		patch := newForTestConcreteAggregate("1", "Name", wantVersion, tenantID, []event.IEvent{
+			ForTestMakePatchEvent("1", tenantID, time.Now(), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+		})
+		if _, err := event.SaveAggregate(ctx, store, patch); !assert.NoError(t, err) {
+			return
+		}
+
+		gotEventStream, gotVersion, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), rejecting)
+		if assert.NoError(t, err) {
+			assert.Len(t, gotEventStream, len(wantEventStream)+1)
+			assert.Equal(t, wantVersion+1, gotVersion)
+		}
+	})
+
+	t.Run("delete close event restores archived stream", func(t *testing.T) {
+		archiveClosedStream(t)
+		if !assert.NoError(t, store.DeleteEvent(ctx, tenantID, aggregateType, "1", closeEventID)) {
+			return
+		}
+
+		gotEventStream, _, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), store)
+		if assert.NoError(t, err) {
+			assert.Len(t, gotEventStream, len(wantEventStream))
+			assert.NotContains(t, gotEventStream, wantEventStream[len(wantEventStream)-1])
+		}
+
+		// the reopened stream is not archivable anymore
+		archived, err := store.ArchiveClosedStreams(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, archived)
+	})
+
+	t.Run("no archive configured", func(t *testing.T) {
+		withoutArchive, err, _ := eventstore.New(adp)
+		if assert.NoError(t, err) {
+			_, err = withoutArchive.ArchiveClosedStreams(ctx)
+			assert.ErrorContains(t, err, "no stream archive configured")
+		}
+	})
+
+	t.Run("export and import restore archived stream", func(t *testing.T) {
+		exported := newForTestConcreteAggregate("3", "Name", 0, tenantID, []event.IEvent{
+			ForTestMakeCreateEvent("3", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+			ForTestMakeCloseEvent("3", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC)),
+		})
+		if _, err := event.SaveAggregate(ctx, store, exported); !assert.NoError(t, err) {
+			return
+		}
+		archived, err := store.ArchiveClosedStreams(ctx)
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, archived) {
+			return
+		}
+
+		var errArchived *event.ErrorStreamArchived
+		assert.True(t, errors.As(rejecting.ExportTenant(ctx, tenantID, &bytes.Buffer{}), &errArchived))
+
+		var buf bytes.Buffer
+		if !assert.NoError(t, store.ExportTenant(ctx, tenantID, &buf)) {
+			return
+		}
+		wantEventStream, wantVersion, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "3", time.Now(), rejecting)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		cleanUp()
+		imported, err, _ := eventstore.New(adapter())
+		if !assert.NoError(t, err) || !assert.NoError(t, imported.ImportTenant(ctx, &buf)) {
+			return
+		}
+		gotEventStream, gotVersion, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "3", time.Now(), imported)
+		if assert.NoError(t, err) {
+			assert.Len(t, gotEventStream, len(wantEventStream))
+			assert.Equal(t, wantVersion, gotVersion)
+		}
+	})
+}
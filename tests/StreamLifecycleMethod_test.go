@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testStreamLifecycle(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.WithValue(context.Background(), event.CtxKeyUserID, "user-1")
+	tenantID := "0000-0000-0000"
+	aggregateType := "forTestConcreteAggregate"
+
+	store, err, _ := eventstore.New(adapter(), eventstore.WithDeleteStrategy(aggregateType, event.HardDelete))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		aggregate := newForTestConcreteAggregate(id, "Name", 0, tenantID, []event.IEvent{ForTestMakeCreateEventNow(id, tenantID)})
+		if _, err = event.SaveAggregate(ctx, store, aggregate); err != nil {
+			t.Fatalf("save aggregate failed: %s", err)
+		}
+	}
+
+	getStates := func(t *testing.T, status ...event.StreamStatus) []string {
+		states, err := store.GetAggregateStatesForAggregateType(ctx, tenantID, aggregateType, status...)
+		assert.NoError(t, err)
+		var ids []string
+		for _, state := range states {
+			ids = append(ids, state.AggregateID)
+		}
+		return ids
+	}
+	saveEvent := func(id string) error {
+		_, version, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, id, time.Now(), store)
+		if err != nil {
+			return err
+		}
+		aggregate := newForTestConcreteAggregate(id, "Name", version, tenantID, []event.IEvent{ForTestMakeEventNow(id, tenantID)})
+		_, err = event.SaveAggregate(ctx, store, aggregate)
+		return err
+	}
+
+	var closedAt time.Time
+	t.Run("close stream", func(t *testing.T) {
+		if !assert.NoError(t, store.CloseAggregate(ctx, tenantID, aggregateType, "1", time.Time{})) {
+			return
+		}
+		closedAt = time.Now()
+
+		assert.ElementsMatch(t, []string{"1"}, getStates(t, event.ClosedStreams))
+		assert.ElementsMatch(t, []string{"2"}, getStates(t, event.OpenStreams))
+		assert.ElementsMatch(t, []string{"1", "2"}, getStates(t))
+		assert.ElementsMatch(t, []string{"1", "2"}, getStates(t, event.OpenStreams, event.ClosedStreams))
+
+		eventStream, _, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), store)
+		if assert.NoError(t, err) && assert.Len(t, eventStream, 2) {
+			closed, ok := eventStream[1].(*event.StreamClosed)
+			if assert.True(t, ok) {
+				assert.Equal(t, "user-1", closed.UserID)
+				assert.Equal(t, event.CloseStreamEvent, closed.GetClass())
+			}
+		}
+	})
+
+	t.Run("reject writes to closed stream", func(t *testing.T) {
+		var errClosed *event.ErrorStreamClosed
+		if assert.True(t, errors.As(saveEvent("1"), &errClosed)) {
+			assert.Equal(t, "1", errClosed.AggregateID)
+			assert.False(t, errClosed.CloseTime.IsZero())
+		}
+
+		errClosed = nil
+		assert.True(t, errors.As(store.CloseAggregate(ctx, tenantID, aggregateType, "1", time.Time{}), &errClosed))
+	})
+
+	t.Run("states till close time", func(t *testing.T) {
+		states, err := store.GetAggregateStatesForAggregateTypeTill(ctx, tenantID, aggregateType, time.Now().Add(-time.Hour), event.ClosedStreams)
+		assert.NoError(t, err)
+		assert.Empty(t, states)
+	})
+
+	t.Run("reopen stream", func(t *testing.T) {
+		if !assert.NoError(t, store.ReopenAggregate(ctx, tenantID, aggregateType, "1", time.Time{})) {
+			return
+		}
+
+		assert.Empty(t, getStates(t, event.ClosedStreams))
+		assert.NoError(t, saveEvent("1"))
+
+		eventStream, _, err := event.LoadAggregateAsAt(ctx, tenantID, aggregateType, "1", time.Now(), store)
+		if assert.NoError(t, err) && assert.Len(t, eventStream, 4) {
+			reopened, ok := eventStream[2].(*event.StreamReopened)
+			if assert.True(t, ok) {
+				assert.Equal(t, "user-1", reopened.UserID)
+			}
+		}
+	})
+
+	t.Run("states till close time of reopened stream", func(t *testing.T) {
+		states, err := store.GetAggregateStatesForAggregateTypeTill(ctx, tenantID, aggregateType, closedAt, event.ClosedStreams)
+		if assert.NoError(t, err) && assert.Len(t, states, 1) {
+			assert.Equal(t, "1", states[0].AggregateID)
+		}
+
+		states, err = store.GetAggregateStatesForAggregateTypeTill(ctx, tenantID, aggregateType, time.Now(), event.ClosedStreams)
+		assert.NoError(t, err)
+		assert.Empty(t, states)
+	})
+
+	t.Run("delete close event", func(t *testing.T) {
+		if !assert.NoError(t, store.CloseAggregate(ctx, tenantID, aggregateType, "1", time.Time{})) {
+			return
+		}
+
+		// the first close event is followed by a reopen event, so that its deletion would reopen the closed stream
+		assert.Error(t, store.DeleteEvent(ctx, tenantID, aggregateType, "1", _getEventID(t, store, tenantID, "1", 2)))
+		assert.ElementsMatch(t, []string{"1"}, getStates(t, event.ClosedStreams))
+
+		assert.NoError(t, store.DeleteEvent(ctx, tenantID, aggregateType, "1", _getEventID(t, store, tenantID, "1", 5)))
+		assert.Empty(t, getStates(t, event.ClosedStreams))
+	})
+
+	t.Run("reject reopen of open stream", func(t *testing.T) {
+		assert.Error(t, store.ReopenAggregate(ctx, tenantID, aggregateType, "2", time.Time{}))
+		assert.Error(t, store.ReopenAggregate(ctx, tenantID, aggregateType, "unknown", time.Time{}))
+	})
+}
+
+func testStreamLifecycleWithRevisionDelete(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	aggregateType := "forTestConcreteAggregate"
+
+	store, err, _ := eventstore.New(adapter(), eventstore.WithDeleteStrategy(aggregateType, event.RevisionDelete))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+	aggregate := newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{ForTestMakeCreateEventNow("1", tenantID)})
+	if _, err = event.SaveAggregate(ctx, store, aggregate); err != nil {
+		t.Fatalf("save aggregate failed: %s", err)
+	}
+	if err = store.CloseAggregate(ctx, tenantID, aggregateType, "1", time.Time{}); err != nil {
+		t.Fatalf("close aggregate failed: %s", err)
+	}
+	closedAt := time.Now()
+	if err = store.ReopenAggregate(ctx, tenantID, aggregateType, "1", time.Time{}); err != nil {
+		t.Fatalf("reopen aggregate failed: %s", err)
+	}
+	if err = store.CloseAggregate(ctx, tenantID, aggregateType, "1", time.Time{}); err != nil {
+		t.Fatalf("close aggregate failed: %s", err)
+	}
+
+	getClosedTill := func(t *testing.T, until time.Time) []string {
+		states, err := store.GetAggregateStatesForAggregateTypeTill(ctx, tenantID, aggregateType, until, event.ClosedStreams)
+		assert.NoError(t, err)
+		var ids []string
+		for _, state := range states {
+			ids = append(ids, state.AggregateID)
+		}
+		return ids
+	}
+
+	// the first close event is followed by a reopen event, so that its deletion would reopen the closed stream
+	assert.Error(t, store.DeleteEvent(ctx, tenantID, aggregateType, "1", _getEventID(t, store, tenantID, "1", 2)))
+	assert.ElementsMatch(t, []string{"1"}, getClosedTill(t, closedAt))
+	assert.ElementsMatch(t, []string{"1"}, getClosedTill(t, time.Now()))
+
+	// the deleted close event does not count for the historical status anymore
+	assert.NoError(t, store.DeleteEvent(ctx, tenantID, aggregateType, "1", _getEventID(t, store, tenantID, "1", 4)))
+	assert.ElementsMatch(t, []string{"1"}, getClosedTill(t, closedAt))
+	assert.Empty(t, getClosedTill(t, time.Now()))
+}
@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/stretchr/testify/assert"
+	"iter"
+	"sort"
+	"testing"
+	"time"
+)
+
+func testStreamAll(t *testing.T, eventStoreFactory func() event.EventStore, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	aggregateType := "forTestConcreteAggregate"
+
+	store := eventStoreFactory()
+	// more events than fit into a single fetch of the cursor
+	_, err := store.SaveAll(ctx, tenantID, []event.PersistenceEvents{
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0()),
+				resetVersionForSave(ForTestEvent1()),
+				resetVersionForSave(ForTestEvent2()),
+				resetVersionForSave(ForTestEventPatch()),
+				resetVersionForSave(ForTestEventFuturePatch()),
+			},
+			Version: 0,
+		},
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0_Aggregate2()),
+			},
+			Version: 0,
+		},
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0_Aggregate3()),
+			},
+			Version: 0,
+		},
+		{
+			Events:  forTestManyEvents(tenantID, aggregateType, "4", 1500),
+			Version: 0,
+		},
+	})
+	if err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+
+	projectionTimes := map[string]time.Time{
+		"before patches":   time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC),
+		"within stream":    time.Date(2021, 1, 1, 1, 1, 1, 5000, time.UTC),
+		"after all events": time.Now(),
+	}
+	reportTime := time.Date(2021, 1, 1, 1, 1, 1, 3, time.UTC)
+
+	for name, projectionTime := range projectionTimes {
+		t.Run(name, func(t *testing.T) {
+			assertEqualStreams(t, store.StreamAllOfAggregateTypeAsAt(ctx, tenantID, aggregateType, projectionTime))(
+				store.LoadAllOfAggregateTypeAsAt(ctx, tenantID, aggregateType, projectionTime))
+			assertEqualStreams(t, store.StreamAllOfAggregateTypeAsOf(ctx, tenantID, aggregateType, projectionTime))(
+				store.LoadAllOfAggregateTypeAsOf(ctx, tenantID, aggregateType, projectionTime))
+			assertEqualStreams(t, store.StreamAllOfAggregateTypeAsOfTill(ctx, tenantID, aggregateType, projectionTime, reportTime))(
+				store.LoadAllOfAggregateTypeAsOfTill(ctx, tenantID, aggregateType, projectionTime, reportTime))
+			assertEqualStreams(t, store.StreamAllAsAt(ctx, tenantID, projectionTime))(
+				store.LoadAllAsAt(ctx, tenantID, projectionTime))
+			assertEqualStreams(t, store.StreamAllAsOf(ctx, tenantID, projectionTime))(
+				store.LoadAllAsOf(ctx, tenantID, projectionTime))
+			assertEqualStreams(t, store.StreamAllAsOfTill(ctx, tenantID, projectionTime, reportTime))(
+				store.LoadAllAsOfTill(ctx, tenantID, projectionTime, reportTime))
+		})
+	}
+
+	t.Run("deserialized event streams", func(t *testing.T) {
+		want, err := event.LoadAllOfAggregateTypeAsOf(ctx, tenantID, aggregateType, time.Now(), store)
+		if err != nil {
+			t.Fatalf("load failed: %s", err)
+		}
+
+		var got []event.EventStream
+		for eventStream, err := range event.StreamAllOfAggregateTypeAsOf(ctx, tenantID, aggregateType, time.Now(), store) {
+			if err != nil {
+				t.Fatalf("stream failed: %s", err)
+			}
+			got = append(got, eventStream)
+		}
+		sortEventStreams(got)
+		sortEventStreams(want)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		count := 0
+		for _, err := range store.StreamAllOfAggregateTypeAsAt(ctx, "unknown tenant", aggregateType, time.Now()) {
+			assert.NoError(t, err)
+			count++
+		}
+		assert.Zero(t, count)
+	})
+
+	t.Run("stop iteration", func(t *testing.T) {
+		count := 0
+		for _, err := range store.StreamAllAsOf(ctx, tenantID, time.Now()) {
+			assert.NoError(t, err)
+			count++
+			break
+		}
+		assert.Equal(t, 1, count)
+
+		// the connection of the stopped iteration is released
+		_, err := store.LoadAllAsOf(ctx, tenantID, time.Now())
+		assert.NoError(t, err)
+	})
+}
+
+func forTestManyEvents(tenantID, aggregateType, aggregateID string, count int) []event.PersistenceEvent {
+	events := make([]event.PersistenceEvent, count)
+	for i := range events {
+		class := event.InstantEvent
+		if i == 0 {
+			class = event.CreateStreamEvent
+		}
+		events[i] = event.PersistenceEvent{
+			ID:              fmt.Sprintf("%s_%d", aggregateID, i),
+			AggregateID:     aggregateID,
+			TenantID:        tenantID,
+			AggregateType:   aggregateType,
+			Version:         i,
+			Type:            "github.com/global-soft-ba/go-eventstore/tests/forTestEvent",
+			TransactionTime: time.Date(2021, 1, 1, 1, 1, 1, 10*i, time.UTC),
+			ValidTime:       time.Date(2021, 1, 1, 1, 1, 1, 10*i, time.UTC),
+			Data:            json.RawMessage(`{"UserID":""}`),
+			Class:           class,
+			FromMigration:   true,
+		}
+	}
+	return events
+}
+
+// assertEqualStreams compares the streamed event streams with the loaded ones. An empty load result is an
+// ErrorEmptyEventStream, which corresponds to a stream without elements.
+func assertEqualStreams(t *testing.T, stream iter.Seq2[event.PersistenceEvents, error]) func(want []event.PersistenceEvents, err error) {
+	return func(want []event.PersistenceEvents, err error) {
+		t.Helper()
+		if err != nil {
+			var errEmpty *event.ErrorEmptyEventStream
+			if !assert.ErrorAs(t, err, &errEmpty) {
+				return
+			}
+		}
+
+		var got []event.PersistenceEvents
+		for eventStream, err := range stream {
+			if !assert.NoError(t, err) {
+				return
+			}
+			got = append(got, eventStream)
+		}
+
+		sortPersistenceEvents(got)
+		sortPersistenceEvents(want)
+		assert.Equal(t, want, got)
+	}
+}
+
+func sortPersistenceEvents(eventStreams []event.PersistenceEvents) {
+	sort.Slice(eventStreams, func(i, j int) bool {
+		return eventStreams[i].Events[0].AggregateID < eventStreams[j].Events[0].AggregateID
+	})
+}
+
+func sortEventStreams(eventStreams []event.EventStream) {
+	sort.Slice(eventStreams, func(i, j int) bool {
+		return eventStreams[i].Stream[0].GetAggregateID() < eventStreams[j].Stream[0].GetAggregateID()
+	})
+}
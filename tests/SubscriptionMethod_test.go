@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var errForTestHandler = errors.New("handler error")
+
+// forTestSubscribe subscribes until n events are handled or the handler fails at the given position. It returns the
+// positions of the handled events.
+func forTestSubscribe(store event.EventStore, tenantID, subscriptionID string, from int64, n int, failAt int64, eventTypes ...string) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []int64
+	err := store.Subscribe(ctx, tenantID, subscriptionID, from, func(ctx context.Context, evt event.PersistenceEvent) error {
+		if evt.Position == failAt {
+			return errForTestHandler
+		}
+		got = append(got, evt.Position)
+		if len(got) == n {
+			cancel()
+		}
+		return nil
+	}, eventTypes...)
+
+	return got, err
+}
+
+func testSubscribe(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	tenantID := "0000-0000-0000"
+	subscriptionID := "subscription"
+
+	type step struct {
+		from       int64
+		n          int
+		failAt     int64
+		eventTypes []string
+		want       []int64
+		wantErr    error
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name:  "all events",
+			steps: []step{{from: 0, n: 3, want: []int64{1, 2, 3}, wantErr: context.Canceled}},
+		},
+		{
+			name:  "filter by event type",
+			steps: []step{{from: 0, n: 1, eventTypes: []string{event.EventType(ForTestMakeEvent3("1", tenantID, time.Now(), time.Now(), ""))}, want: []int64{3}, wantErr: context.Canceled}},
+		},
+		{
+			name:  "start from position",
+			steps: []step{{from: 1, n: 2, want: []int64{2, 3}, wantErr: context.Canceled}},
+		},
+		{
+			name: "resume after checkpoint",
+			steps: []step{
+				{from: 0, n: 2, want: []int64{1, 2}, wantErr: context.Canceled},
+				{from: 0, n: 1, want: []int64{3}, wantErr: context.Canceled},
+			},
+		},
+		{
+			name: "resume after failed handler",
+			steps: []step{
+				{from: 0, n: 3, failAt: 2, want: []int64{1}, wantErr: errForTestHandler},
+				{from: 0, n: 2, want: []int64{2, 3}, wantErr: context.Canceled},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer cleanUp()
+			store, err, _ := eventstore.New(adapter(), eventstore.WithSubscriptionPollInterval(10*time.Millisecond))
+			if err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+			_, err = event.SaveAggregate(context.Background(), store, newForTestConcreteAggregate("1", "Name", 0, tenantID, []event.IEvent{
+				ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+				ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+				ForTestMakeEvent3("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), "property"),
+			}))
+			if err != nil {
+				t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+			}
+
+			for i, s := range tt.steps {
+				got, err := forTestSubscribe(store, tenantID, subscriptionID, s.from, s.n, s.failAt, s.eventTypes...)
+				if !errors.Is(err, s.wantErr) {
+					t.Fatalf("Subscribe() step %d error = %v, wantErr %v", i, err, s.wantErr)
+				}
+				if !reflect.DeepEqual(got, s.want) {
+					t.Errorf("Subscribe() step %d got positions %v, want %v", i, got, s.want)
+				}
+			}
+		})
+	}
+
+	t.Run("new events are delivered", func(t *testing.T) {
+		defer cleanUp()
+		store, err, _ := eventstore.New(adapter(), eventstore.WithSubscriptionPollInterval(10*time.Millisecond))
+		if err != nil {
+			t.Fatalf("test case preparation %v failed:%v", t.Name(), err)
+		}
+
+		type result struct {
+			got []int64
+			err error
+		}
+		resCh := make(chan result, 1)
+		go func() {
+			got, err := forTestSubscribe(store, tenantID, subscriptionID, 0, 2, 0)
+			resCh <- result{got, err}
+		}()
+
+		for version, evt := range []event.IEvent{
+			ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)),
+			ForTestMakeEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC)),
+		} {
+			time.Sleep(20 * time.Millisecond)
+			_, err = event.SaveAggregate(context.Background(), store, newForTestConcreteAggregate("1", "Name", version, tenantID, []event.IEvent{evt}))
+			if err != nil {
+				t.Fatalf("SaveAggregate() error = %v", err)
+			}
+		}
+
+		res := <-resCh
+		if !errors.Is(res.err, context.Canceled) {
+			t.Fatalf("Subscribe() error = %v, wantErr %v", res.err, context.Canceled)
+		}
+		if !reflect.DeepEqual(res.got, []int64{1, 2}) {
+			t.Errorf("Subscribe() got positions %v, want %v", res.got, []int64{1, 2})
+		}
+	})
+}
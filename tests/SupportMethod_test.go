@@ -687,6 +687,7 @@ func testGetProjectionStates(t *testing.T, adapter func() persistence.Port, clea
 					InputQueueLength:        100,
 					ProjectionType:          event.ECS,
 					RetryDurations:          nil,
+					Lanes:                   1,
 				},
 			},
 		},
@@ -735,6 +736,7 @@ func testGetProjectionStates(t *testing.T, adapter func() persistence.Port, clea
 					InputQueueLength:        100,
 					ProjectionType:          event.CCS,
 					RetryDurations:          nil,
+					Lanes:                   1,
 				},
 			},
 		},
@@ -811,6 +813,7 @@ func testGetProjectionStates(t *testing.T, adapter func() persistence.Port, clea
 					InputQueueLength:        100,
 					ProjectionType:          event.CCS,
 					RetryDurations:          nil,
+					Lanes:                   1,
 				},
 				"projection_2": {
 					TenantID:                tenantID,
@@ -825,6 +828,7 @@ func testGetProjectionStates(t *testing.T, adapter func() persistence.Port, clea
 					InputQueueLength:        100,
 					ProjectionType:          event.ESS,
 					RetryDurations:          nil,
+					Lanes:                   1,
 				},
 			},
 		},
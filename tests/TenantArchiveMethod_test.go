@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTenantArchive(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantID := "0000-0000-0000"
+	aggregateType := "forTestConcreteAggregate"
+
+	newStore := func() event.EventStore {
+		store, err, _ := eventstore.New(adapter(),
+			eventstore.WithDeleteStrategy(aggregateType, event.SoftDelete),
+			eventstore.WithProjection(newTestProjectionTypeOne("archived", tenantID, 0, 100)))
+		if err != nil {
+			t.Fatalf("store creation failed: %s", err)
+		}
+		return store
+	}
+
+	store := newStore()
+	_, err := store.SaveAll(ctx, tenantID, []event.PersistenceEvents{
+		{
+			Events: []event.PersistenceEvent{
+				resetVersionForSave(ForTestEvent0()),
+				resetVersionForSave(ForTestEvent1()),
+				resetVersionForSave(ForTestEvent2()),
+				resetVersionForSave(ForTestEventPatch()),
+				resetVersionForSave(ForTestEventFuturePatch()),
+			},
+			Version: 0,
+		},
+	})
+	if err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+	t1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	withSnapshot, _ := newForTestConcreteAggregate("5", "", 0, tenantID, nil).ApplyEvent(ForTestMakeCreateEvent("5", tenantID, t1, t1))
+	withSnapshot, _ = withSnapshot.ApplyEvent(ForTestMakeSnapshot("5", tenantID, t2, t2))
+	if _, err = event.SaveAggregate(ctx, store, withSnapshot); err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+	if err = store.DeleteEvent(ctx, tenantID, aggregateType, "1", "2"); err != nil {
+		t.Fatalf("delete failed: %s", err)
+	}
+
+	projectionTime := time.Now()
+	wantAsOf, err := store.LoadAllAsOf(ctx, tenantID, projectionTime)
+	assert.NoError(t, err)
+	wantStates, err := store.GetAggregateStatesForAggregateType(ctx, tenantID, aggregateType)
+	assert.NoError(t, err)
+	wantEvents, _, err := store.GetAggregatesEvents(ctx, tenantID, event.PageDTO{PageSize: 100})
+	assert.NoError(t, err)
+
+	var plain bytes.Buffer
+	if err = store.ExportTenant(ctx, tenantID, &plain); err != nil {
+		t.Fatalf("export failed: %s", err)
+	}
+	var compressed bytes.Buffer
+	zipper := gzip.NewWriter(&compressed)
+	assert.NoError(t, store.ExportTenant(ctx, tenantID, zipper))
+	assert.NoError(t, zipper.Close())
+
+	t.Run("archive format", func(t *testing.T) {
+		var records []event.TenantArchiveRecord
+		scanner := bufio.NewScanner(bytes.NewReader(plain.Bytes()))
+		for scanner.Scan() {
+			var record event.TenantArchiveRecord
+			if !assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record)) {
+				return
+			}
+			records = append(records, record)
+		}
+		if !assert.Len(t, records, 1+2+6+1+1+1) {
+			return
+		}
+		assert.Equal(t, event.TenantArchiveHeader, records[0].Kind)
+		assert.Equal(t, event.TenantArchiveFormat, records[0].Header.Format)
+		assert.Equal(t, event.TenantArchiveVersion, records[0].Header.Version)
+		assert.Equal(t, tenantID, records[0].Header.TenantID)
+		assert.Equal(t, event.ArchiveTrailer{Aggregates: 2, Events: 6, Snapshots: 1, Projections: 1}, *records[len(records)-1].Trailer)
+
+		// the deleted event keeps its deletion mark
+		var deleted []string
+		for _, record := range records {
+			if record.Kind == event.TenantArchiveEvent && record.Event.Class == event.DeletePatch {
+				deleted = append(deleted, record.Event.ID)
+			}
+		}
+		assert.Equal(t, []string{"2"}, deleted)
+	})
+
+	t.Run("import into an empty store", func(t *testing.T) {
+		cleanUp()
+		imported := newStore()
+		if !assert.NoError(t, imported.ImportTenant(ctx, bytes.NewReader(compressed.Bytes()))) {
+			return
+		}
+
+		gotAsOf, err := imported.LoadAllAsOf(ctx, tenantID, projectionTime)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, forTestImportedStreams(wantAsOf), forTestImportedStreams(gotAsOf))
+
+		gotStates, err := imported.GetAggregateStatesForAggregateType(ctx, tenantID, aggregateType)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, forTestImportedStates(wantStates), forTestImportedStates(gotStates))
+
+		gotEvents, _, err := imported.GetAggregatesEvents(ctx, tenantID, event.PageDTO{PageSize: 100})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, forTestImportedEvents(wantEvents), forTestImportedEvents(gotEvents))
+
+		projections, err := imported.GetAllProjectionStates(ctx, tenantID)
+		if assert.NoError(t, err) && assert.Len(t, projections, 1) {
+			assert.Equal(t, "archived", projections[0].ProjectionID)
+		}
+
+		err = imported.ImportTenant(ctx, bytes.NewReader(plain.Bytes()))
+		assert.ErrorContains(t, err, "tenant already contains aggregates")
+	})
+
+	t.Run("incomplete archive", func(t *testing.T) {
+		cleanUp()
+		imported := newStore()
+		lines := strings.Split(strings.TrimSpace(plain.String()), "\n")
+		err := imported.ImportTenant(ctx, strings.NewReader(strings.Join(lines[:len(lines)-1], "\n")))
+		assert.ErrorContains(t, err, "missing trailer")
+
+		events, _, err := imported.GetAggregatesEvents(ctx, tenantID, event.PageDTO{PageSize: 100})
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := newStore().ImportTenant(ctx, strings.NewReader(`{"kind":"header","header":{"format":"other","version":1,"tenantID":"1"}}`))
+		assert.ErrorContains(t, err, "unknown archive format")
+	})
+}
+
+// forTestImportedEvents marks the events as FromMigration and strips the time zones, as an import does.
+func forTestImportedEvents(events []event.PersistenceEvent) []event.PersistenceEvent {
+	result := make([]event.PersistenceEvent, len(events))
+	for i, evt := range events {
+		evt.FromMigration = true
+		evt.TransactionTime = evt.TransactionTime.UTC()
+		evt.ValidTime = evt.ValidTime.UTC()
+		result[i] = evt
+	}
+	return result
+}
+
+func forTestImportedStreams(eventStreams []event.PersistenceEvents) []event.PersistenceEvents {
+	result := make([]event.PersistenceEvents, len(eventStreams))
+	for i, eventStream := range eventStreams {
+		result[i] = event.PersistenceEvents{Events: forTestImportedEvents(eventStream.Events), Version: eventStream.Version}
+	}
+	return result
+}
+
+func forTestImportedStates(states []event.AggregateState) []event.AggregateState {
+	result := make([]event.AggregateState, len(states))
+	for i, state := range states {
+		state.LastTransactionTime = state.LastTransactionTime.UTC()
+		state.LatestValidTime = state.LatestValidTime.UTC()
+		state.CreateTime = state.CreateTime.UTC()
+		state.CloseTime = state.CloseTime.UTC()
+		result[i] = state
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/global-soft-ba/go-eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore"
+	"github.com/global-soft-ba/go-eventstore/eventstore/core/port/persistence"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type forTestPurgeProjection struct {
+	event.Projection
+	mu     sync.Mutex
+	purged []string
+}
+
+func (p *forTestPurgeProjection) PurgeTenant(_ context.Context, tenantID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.purged = append(p.purged, tenantID)
+	return nil
+}
+
+func (p *forTestPurgeProjection) purgedTenants() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.purged...)
+}
+
+func testTenantLifecycle(t *testing.T, adapter func() persistence.Port, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	ctx := context.Background()
+	tenantA := "0000-0000-0000"
+	tenantB := "1111-1111-1111"
+	aggregateType := "forTestConcreteAggregate"
+	t1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	adp := adapter()
+	projection := &forTestPurgeProjection{Projection: newTestProjectionTypeOne("purged", tenantA, 0, 100)}
+	store, err, _ := eventstore.New(adp, eventstore.WithProjection(projection))
+	if err != nil {
+		t.Fatalf("store creation failed: %s", err)
+	}
+
+	save := func(tenantID, aggregateID string) error {
+		aggregate, _ := newForTestConcreteAggregate(aggregateID, "", 0, tenantID, nil).ApplyEvent(ForTestMakeCreateEvent(aggregateID, tenantID, t1, t1))
+		_, err := event.SaveAggregate(ctx, store, aggregate)
+		return err
+	}
+	statusOf := func(t *testing.T, tenantID string) event.TenantStatus {
+		tenants, err := store.ListTenants(ctx)
+		assert.NoError(t, err)
+		for _, tnt := range tenants {
+			if tnt.TenantID == tenantID {
+				return tnt.Status
+			}
+		}
+		return ""
+	}
+
+	if err = save(tenantA, "1"); err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+	if err = save(tenantB, "1"); err != nil {
+		t.Fatalf("save failed: %s", err)
+	}
+
+	t.Run("list tenants", func(t *testing.T) {
+		tenants, err := store.ListTenants(ctx)
+		if assert.NoError(t, err) && assert.Len(t, tenants, 2) {
+			assert.Equal(t, tenantA, tenants[0].TenantID)
+			assert.Equal(t, event.TenantActive, tenants[0].Status)
+			assert.False(t, tenants[0].CreatedAt.IsZero())
+			assert.Equal(t, tenantB, tenants[1].TenantID)
+		}
+	})
+
+	t.Run("suspend rejects saves", func(t *testing.T) {
+		if !assert.NoError(t, store.SuspendTenant(ctx, tenantA)) {
+			return
+		}
+		assert.Equal(t, event.TenantSuspended, statusOf(t, tenantA))
+
+		var errSuspended *event.ErrorTenantSuspended
+		if assert.True(t, errors.As(save(tenantA, "2"), &errSuspended)) {
+			assert.Equal(t, tenantA, errSuspended.TenantID)
+		}
+		assert.NoError(t, save(tenantB, "2"))
+
+		assert.ErrorContains(t, store.SuspendTenant(ctx, "unknown"), "tenant not found")
+	})
+
+	t.Run("suspension survives a restart", func(t *testing.T) {
+		restarted, err, _ := eventstore.New(adp)
+		if !assert.NoError(t, err) {
+			return
+		}
+		aggregate, _ := newForTestConcreteAggregate("3", "", 0, tenantA, nil).ApplyEvent(ForTestMakeCreateEvent("3", tenantA, t1, t1))
+		_, err = event.SaveAggregate(ctx, restarted, aggregate)
+		var errSuspended *event.ErrorTenantSuspended
+		assert.True(t, errors.As(err, &errSuspended))
+	})
+
+	t.Run("resume accepts saves", func(t *testing.T) {
+		if !assert.NoError(t, store.ResumeTenant(ctx, tenantA)) {
+			return
+		}
+		assert.Equal(t, event.TenantActive, statusOf(t, tenantA))
+		assert.NoError(t, save(tenantA, "2"))
+	})
+
+	t.Run("purge requires suspension", func(t *testing.T) {
+		assert.ErrorContains(t, store.PurgeTenant(ctx, tenantA), "tenant must be suspended before it is purged")
+
+		states, err := store.GetAggregateStatesForAggregateType(ctx, tenantA, aggregateType)
+		assert.NoError(t, err)
+		assert.Len(t, states, 2)
+		assert.Empty(t, projection.purgedTenants())
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		assert.NoError(t, store.SuspendTenant(ctx, tenantA))
+		if !assert.NoError(t, store.PurgeTenant(ctx, tenantA)) {
+			return
+		}
+
+		tenants, err := store.ListTenants(ctx)
+		if assert.NoError(t, err) && assert.Len(t, tenants, 1) {
+			assert.Equal(t, tenantB, tenants[0].TenantID)
+		}
+		states, _ := store.GetAggregateStatesForAggregateType(ctx, tenantA, aggregateType)
+		assert.Empty(t, states)
+		events, _, err := store.GetAggregatesEvents(ctx, tenantA, event.PageDTO{PageSize: 100})
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+		projections, err := store.GetAllProjectionStates(ctx, tenantA)
+		assert.NoError(t, err)
+		assert.Empty(t, projections)
+		assert.Equal(t, []string{tenantA}, projection.purgedTenants())
+
+		// the other tenant is untouched
+		states, err = store.GetAggregateStatesForAggregateType(ctx, tenantB, aggregateType)
+		assert.NoError(t, err)
+		assert.Len(t, states, 2)
+
+		// a repeated purge is accepted
+		assert.NoError(t, store.PurgeTenant(ctx, tenantA))
+		assert.Equal(t, []string{tenantA, tenantA}, projection.purgedTenants())
+	})
+
+	t.Run("purged tenant starts over", func(t *testing.T) {
+		assert.NoError(t, save(tenantA, "1"))
+		assert.Equal(t, event.TenantActive, statusOf(t, tenantA))
+	})
+}
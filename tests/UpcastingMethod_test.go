@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/global-soft-ba/go-eventstore"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// forTestEvent4 is the current schema (version 2) of an event, which was historically stored as forTestEvent4Legacy
+// with the property Name instead of Title.
+type forTestEvent4 struct {
+	event.Event
+	Title string
+}
+
+const forTestEvent4Legacy = "github.com/global-soft-ba/go-eventstore/tests/legacy/forTestEvent4"
+
+func init() {
+	event.RegisterEventAndAggregate(forTestEvent4{}, reflect.TypeOf(forTestConcreteAggregate{}).Name())
+	event.RegisterEventAlias(forTestEvent4{}, forTestEvent4Legacy)
+	event.RegisterUpcaster(forTestEvent4{}, 1, func(data json.RawMessage) (json.RawMessage, error) {
+		var v1 map[string]any
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		v1["Title"] = v1["Name"]
+		delete(v1, "Name")
+		return json.Marshal(v1)
+	})
+}
+
+func ForTestMakeEvent4(aggregateID, tenantID string, transactionTimestamp, validTimestamp time.Time, title string) event.IEvent {
+	e := event.NewMigrationEvent(aggregateID, tenantID, validTimestamp, transactionTimestamp, event.InstantEvent)
+	return &forTestEvent4{Event: e, Title: title}
+}
+
+func testUpcasting(t *testing.T, eventStoreFactory func() event.EventStore, cleanUp func()) {
+	cleanUp()
+	defer cleanUp()
+	tenantID := "0000-0000-0000"
+	ctx := context.Background()
+	store := eventStoreFactory()
+
+	// events of the historical event type with the initial schema version (e.g. saved by an old release)
+	create, _, _ := event.SerializeEventWithCodec(ForTestMakeCreateEvent("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC)), event.WithAggregateType("forTestConcreteAggregate"))
+	legacy, _ := json.Marshal(map[string]any{"Name": "old"})
+	resCh, err := store.Save(ctx, tenantID, []event.PersistenceEvent{
+		{ID: "e1", AggregateID: "1", TenantID: tenantID, AggregateType: "forTestConcreteAggregate", Type: event.EventType(forTestEvent{}), Class: event.CreateStreamEvent, FromMigration: true, TransactionTime: time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), ValidTime: time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC), Data: create},
+		{ID: "e2", AggregateID: "1", TenantID: tenantID, AggregateType: "forTestConcreteAggregate", Type: forTestEvent4Legacy, Class: event.InstantEvent, FromMigration: true, TransactionTime: time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), ValidTime: time.Date(2021, 1, 1, 1, 1, 1, 1, time.UTC), Data: legacy},
+	}, 0)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	<-resCh
+
+	// events of the current schema version
+	_, err = event.SaveAggregate(ctx, store, newForTestConcreteAggregate("1", "Name", 2, tenantID, []event.IEvent{
+		ForTestMakeEvent4("1", tenantID, time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), time.Date(2021, 1, 1, 1, 1, 1, 2, time.UTC), "new"),
+	}))
+	if err != nil {
+		t.Fatalf("SaveAggregate() error = %v", err)
+	}
+
+	persisted, _, err := store.LoadAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now())
+	if err != nil {
+		t.Fatalf("LoadAsAt() error = %v", err)
+	}
+	if gotVersions := []int{persisted[1].SchemaVersion, persisted[2].SchemaVersion}; !reflect.DeepEqual(gotVersions, []int{0, 2}) {
+		t.Errorf("LoadAsAt() got schema versions %v, want %v", gotVersions, []int{0, 2})
+	}
+
+	eventStream, version, err := event.LoadAggregateAsAt(ctx, tenantID, "forTestConcreteAggregate", "1", time.Now(), store)
+	if err != nil {
+		t.Fatalf("LoadAggregateAsAt() error = %v", err)
+	}
+	if version != 3 || len(eventStream) != 3 {
+		t.Fatalf("LoadAggregateAsAt() got %d events with version %d, want 3 events with version 3", len(eventStream), version)
+	}
+	for i, want := range []string{"old", "new"} {
+		got, ok := eventStream[i+1].(*forTestEvent4)
+		if !ok {
+			t.Fatalf("LoadAggregateAsAt() got event %T, want %T", eventStream[i+1], &forTestEvent4{})
+		}
+		if got.Title != want {
+			t.Errorf("LoadAggregateAsAt() got title %q, want %q", got.Title, want)
+		}
+	}
+}
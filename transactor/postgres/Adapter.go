@@ -280,7 +280,82 @@ func (a *Adapter) executeFinishRebuild(ctx context.Context, tx transactor.DBTX,
 	return nil
 }
 
-// ------------------------------------FOR TESTS-------------------------------------------------
+// PrepareShadowIntern creates the empty shadow table of the tenant table for a blue/green rebuild (see
+// event.ProjectionGenerations). The shadow table is no partition of the parent table, i.e. it must be written by its
+// name (see GetShadowTableName), until SwitchShadowIntern replaces the tenant table by it.
+func (a *Adapter) PrepareShadowIntern(ctx context.Context, tenantID, parentTable string) error {
+	return a.ExecWithinTransaction(ctx, tenantID, func(ctx context.Context) error {
+		tx, err := a.GetTx(ctx)
+		if err != nil {
+			return err
+		}
+		if err = a.executeShadowPreparation(ctx, tx, tenantID, parentTable); err != nil {
+			return fmt.Errorf("could not execute shadow preparation for %s: %w", parentTable, err)
+		}
+		return nil
+	})
+}
+
+func (a *Adapter) executeShadowPreparation(ctx context.Context, tx transactor.DBTX, tenantID, parentTable string) error {
+	// Create Tenant Table and Partition if not there yet
+	if err := a.createNewTenantIfNeeded(ctx, tx, tenantID, parentTable); err != nil {
+		return fmt.Errorf("could not execute shadow preparation for table %q: %w", parentTable, err)
+	}
+	// Create shadow table if not exists
+	if err := a.execStatement(ctx, tx, a.sql.CreateNewShadowTable, tenantID, parentTable); err != nil {
+		return fmt.Errorf("could not execute shadow preparation for table %q: %w", parentTable, err)
+	}
+	// Truncate shadow table of a previous blue/green rebuild
+	if err := a.execStatement(ctx, tx, a.sql.TruncateShadowTable, tenantID, parentTable); err != nil {
+		return fmt.Errorf("could not execute shadow preparation for table %q: %w", parentTable, err)
+	}
+	return nil
+}
+
+// SwitchShadowIntern replaces the tenant table by the shadow table (see PrepareShadowIntern) within a single transaction.
+func (a *Adapter) SwitchShadowIntern(ctx context.Context, tenantID, parentTable string) error {
+	return a.ExecWithinTransaction(ctx, tenantID, func(ctx context.Context) error {
+		tx, err := a.GetTx(ctx)
+		if err != nil {
+			return err
+		}
+		if err = a.executeShadowSwitch(ctx, tx, tenantID, parentTable); err != nil {
+			return fmt.Errorf("could not execute shadow switch for %s: %w", parentTable, err)
+		}
+		return nil
+	})
+}
+
+func (a *Adapter) executeShadowSwitch(ctx context.Context, tx transactor.DBTX, tenantID, parentTable string) error {
+	// the partitions are named by the cleaned tenant ID (see CreateNewTenantTable)
+	cleanID := queries.NotAllowedCharacters.ReplaceAllString(tenantID, "")
+	// query 1: What partitions are already there?
+	partitionsOfTenant, err := a.getAllPartitionsOfTenantForParentTable(ctx, tx, cleanID, parentTable)
+	if err != nil {
+		return fmt.Errorf("could not execute shadow switch for table %q: %w", parentTable, err)
+	}
+	// query 2: (Optional) Detach tenant partition if it was there
+	if slices.Contains(partitionsOfTenant, a.sql.GetTenantTableName(cleanID, parentTable)) {
+		if err = a.execStatement(ctx, tx, a.sql.DetachTenantTablePartition, cleanID, parentTable); err != nil {
+			return fmt.Errorf("could not execute shadow switch for table %q: %w", parentTable, err)
+		}
+	}
+	// query 3: Drop tenant table and replace it by the shadow table
+	if err = a.execStatement(ctx, tx, a.sql.DropTenantTable, cleanID, parentTable); err != nil {
+		return fmt.Errorf("could not execute shadow switch for table %q: %w", parentTable, err)
+	}
+	if err = a.execStatement(ctx, tx, a.sql.RenameShadowTableToTenantTable, cleanID, parentTable); err != nil {
+		return fmt.Errorf("could not execute shadow switch for table %q: %w", parentTable, err)
+	}
+	// query 4: Attach the new tenant table
+	if err = a.attachTenantTablePartition(ctx, tx, cleanID, parentTable); err != nil {
+		return fmt.Errorf("could not execute shadow switch for table %q: %w", parentTable, err)
+	}
+	return nil
+}
+
+// DropTenantIntern drops the tables of the tenant for the parent table (incl. a rebuild or shadow table), e.g. to
+// purge the read model of a tenant (see event.ProjectionPurge).
 func (a *Adapter) DropTenantIntern(ctx context.Context, tenantID, parentTable string) error {
 	errTX := a.transactor.ExecWithinTransaction(ctx, func(txCtx context.Context) error {
 		tx, err := a.GetTx(txCtx)
@@ -302,5 +377,15 @@ func (a *Adapter) DropTenantIntern(ctx context.Context, tenantID, parentTable st
 		return nil
 	})
 	return errTX
+}
 
+func (a *Adapter) execStatement(ctx context.Context, tx transactor.DBTX, statement func(tenantID, parentTable string) (string, error), tenantID, parentTable string) error {
+	stmt, err := statement(tenantID, parentTable)
+	if err != nil {
+		return fmt.Errorf("could not build sql statement: %w", err)
+	}
+	if _, err = tx.Exec(ctx, stmt); err != nil {
+		return fmt.Errorf("could not execute sql statement %q: %w", stmt, err)
+	}
+	return nil
 }
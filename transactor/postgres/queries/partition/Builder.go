@@ -11,6 +11,8 @@ import (
 const (
 	// rebuildSuffix is used to rename tables during rebuild process
 	rebuildSuffix = "tmp"
+	// shadowSuffix is used for the shadow tables of blue/green rebuilds
+	shadowSuffix = "shadow"
 )
 
 var NotAllowedCharacters = regexp.MustCompile(`[^a-zA-Z0-9\-_.]`)
@@ -61,6 +63,12 @@ func parseAllDDLTemplates() (*template.Template, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing template %s: %w", truncateRebuildTableDDL, err)
 	}
+	for _, listPartitionDDL := range []string{createListPartitionTableDDL, createDefaultPartitionDDL, attachListPartitionDDL, moveRowsOfDefaultPartitionDDL} {
+		tmpls, err = tmpls.New(listPartitionDDL).Parse(listPartitionDDL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template %s: %w", listPartitionDDL, err)
+		}
+	}
 	return tmpls, nil
 }
 
@@ -76,10 +84,18 @@ func (b *Builder) GetTempTableName(tenantID string, tableName string) string {
 	return fmt.Sprintf("%s_%s_%s", tableName, tenantID, rebuildSuffix)
 }
 
-// -----------------------------------------------------------------------------------------------------------------------
-// --------------------------------------------TEST SUPPORT------------------------------------------------------------
-// -----------------------------------------------------------------------------------------------------------------------
+// GetShadowTableName returns the name of the shadow table of a blue/green rebuild. The tenant ID is cleaned like for
+// the creation of the table (see CreateNewShadowTable), so that projections can write the shadow table by this name.
+func (b *Builder) GetShadowTableName(tenantID string, tableName string) string {
+	cleanID := NotAllowedCharacters.ReplaceAllString(tenantID, "")
+	return fmt.Sprintf("%s_%s_%s", tableName, cleanID, shadowSuffix)
+}
 
+// DropTenant drops the tenant table of the parent table together with its rebuild and shadow table.
 func (b *Builder) DropTenant(tenantID, parentTableName string) (string, []interface{}, error) {
-	return sq.Expr(fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", b.GetEscapedTenantTableName(tenantID, parentTableName))).ToSql()
+	return sq.Expr(fmt.Sprintf("DROP TABLE IF EXISTS %s, %s, %s CASCADE",
+		b.GetEscapedTenantTableName(tenantID, parentTableName),
+		b.WithEscapedName(b.GetTempTableName(tenantID, parentTableName)),
+		b.WithEscapedName(b.GetShadowTableName(tenantID, parentTableName)),
+	)).ToSql()
 }
@@ -24,6 +24,11 @@ func (b *Builder) CreateNewRebuildTable(tenantID, parentTable string) (string, e
 	return b.createTable(b.GetTempTableName(cleanID, parentTable), b.GetTenantTableName(cleanID, parentTable))
 }
 
+func (b *Builder) CreateNewShadowTable(tenantID, parentTable string) (string, error) {
+	cleanID := NotAllowedCharacters.ReplaceAllString(tenantID, "")
+	return b.createTable(b.GetTenantTableName(cleanID, parentTable), b.GetShadowTableName(cleanID, parentTable))
+}
+
 func (b *Builder) createTable(parentTable, childTable string) (statement string, err error) {
 	var buf bytes.Buffer
 	if err = b.Templates.ExecuteTemplate(&buf, createTableIfNotExistsDDL, CreateTableStatementData{
@@ -12,6 +12,18 @@ type DetachTenantPartitionStatementData struct {
 	TenantTable string
 }
 
+func (b *Builder) DetachTenantTablePartition(tenantID, parentTable string) (statement string, err error) {
+	cleanID := NotAllowedCharacters.ReplaceAllString(tenantID, "")
+	var buf bytes.Buffer
+	if err = b.Templates.ExecuteTemplate(&buf, detachTenantPartitionDDL, DetachTenantPartitionStatementData{
+		ParentTable: parentTable,
+		TenantTable: b.GetTenantTableName(cleanID, parentTable),
+	}); err != nil {
+		return "", fmt.Errorf("error executing template %s: %w", detachTenantPartitionDDL, err)
+	}
+	return buf.String(), nil
+}
+
 func (b *Builder) DetachTempTablePartition(tenantID, parentTable string) (statement string, err error) {
 	var buf bytes.Buffer
 	if err = b.Templates.ExecuteTemplate(&buf, detachTenantPartitionDDL, DetachTenantPartitionStatementData{
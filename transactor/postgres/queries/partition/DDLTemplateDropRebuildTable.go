@@ -11,6 +11,17 @@ type DropRebuildTableStatementData struct {
 	RebuildTable string
 }
 
+func (b *Builder) DropTenantTable(tenantID, parentTable string) (statement string, err error) {
+	cleanID := NotAllowedCharacters.ReplaceAllString(tenantID, "")
+	var buf bytes.Buffer
+	if err = b.Templates.ExecuteTemplate(&buf, dropRebuildTableDDL, DropRebuildTableStatementData{
+		RebuildTable: b.GetTenantTableName(cleanID, parentTable),
+	}); err != nil {
+		return "", fmt.Errorf("error executing template %s: %w", dropRebuildTableDDL, err)
+	}
+	return buf.String(), nil
+}
+
 func (b *Builder) DropRebuildTable(tenantID, parentTable string) (statement string, err error) {
 	cleanID := NotAllowedCharacters.ReplaceAllString(tenantID, "")
 	var buf bytes.Buffer
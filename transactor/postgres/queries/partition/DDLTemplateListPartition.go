@@ -0,0 +1,165 @@
+package partition
+
+import (
+	"bytes"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"hash/fnv"
+	"strings"
+)
+
+// The list partition DDL works with schema qualified tables, which are partitioned by a column (e.g. the aggregate type)
+// and can be sub-partitioned by another column (e.g. the tenant). Unlike the tenant tables above, the partition values
+// are not restricted to the allowed characters (see GetTenantTableName), so that the partition names are cleaned,
+// shortened and get a hash suffix.
+
+const maxIdentifierLength = 63
+
+const createListPartitionTableDDL = `CREATE TABLE IF NOT EXISTS "{{.Schema}}"."{{.Partition}}" (LIKE "{{.Schema}}"."{{.ParentTable}}" INCLUDING ALL){{if .SubPartitionKey}} PARTITION BY LIST ("{{.SubPartitionKey}}"){{end}};`
+
+const createDefaultPartitionDDL = `CREATE TABLE IF NOT EXISTS "{{.Schema}}"."{{.Partition}}" PARTITION OF "{{.Schema}}"."{{.ParentTable}}" DEFAULT;`
+
+const attachListPartitionDDL = `ALTER TABLE "{{.Schema}}"."{{.ParentTable}}" ATTACH PARTITION "{{.Schema}}"."{{.Partition}}" FOR VALUES IN ('{{.Value}}');`
+
+const moveRowsOfDefaultPartitionDDL = `WITH moved AS (DELETE FROM {{.DefaultPartition}} WHERE ctid IN (SELECT ctid FROM {{.DefaultPartition}} WHERE "{{.Key}}" = ? LIMIT {{.BatchSize}}) RETURNING *) INSERT INTO "{{.Schema}}"."{{.Partition}}" SELECT * FROM moved`
+
+type ListPartitionStatementData struct {
+	Schema           string
+	ParentTable      string
+	Partition        string
+	DefaultPartition string
+	SubPartitionKey  string
+	Key              string
+	Value            string
+	BatchSize        int
+}
+
+// GetListPartitionName returns the name of the partition of the parent table for the value. The name is unique, even if
+// it is shortened to the maximal length of identifiers.
+func (b *Builder) GetListPartitionName(parentTable, value string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(parentTable + "\x00" + value))
+	suffix := fmt.Sprintf("_%08x", hash.Sum32())
+
+	name := parentTable + "_" + NotAllowedCharacters.ReplaceAllString(value, "")
+	if len(name)+len(suffix) > maxIdentifierLength {
+		name = name[:maxIdentifierLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+func (b *Builder) GetDefaultPartitionName(parentTable string) string {
+	const suffix = "_default"
+	if len(parentTable)+len(suffix) > maxIdentifierLength {
+		return parentTable[:maxIdentifierLength-len(suffix)] + suffix
+	}
+	return parentTable + suffix
+}
+
+// CreateListPartitionTable creates the (not yet attached) partition table of the parent table for the value. If a sub
+// partition key is given, the partition table is partitioned by this key and needs a default partition (see
+// CreateDefaultPartition).
+func (b *Builder) CreateListPartitionTable(parentTable, value, subPartitionKey string) (string, error) {
+	return b.executeListPartitionTemplate(createListPartitionTableDDL, ListPartitionStatementData{
+		Schema:          b.GetDatabaseSchema(),
+		ParentTable:     parentTable,
+		Partition:       b.GetListPartitionName(parentTable, value),
+		SubPartitionKey: subPartitionKey,
+	})
+}
+
+func (b *Builder) CreateDefaultPartition(parentTable string) (string, error) {
+	return b.executeListPartitionTemplate(createDefaultPartitionDDL, ListPartitionStatementData{
+		Schema:      b.GetDatabaseSchema(),
+		ParentTable: parentTable,
+		Partition:   b.GetDefaultPartitionName(parentTable),
+	})
+}
+
+func (b *Builder) AttachListPartition(parentTable, value string) (string, error) {
+	return b.executeListPartitionTemplate(attachListPartitionDDL, ListPartitionStatementData{
+		Schema:      b.GetDatabaseSchema(),
+		ParentTable: parentTable,
+		Partition:   b.GetListPartitionName(parentTable, value),
+		Value:       strings.ReplaceAll(value, "'", "''"),
+	})
+}
+
+// MoveRowsOfDefaultPartition moves up to batch size rows with the value from the default partition (as returned by
+// GetDefaultPartition) into the partition of the value. It has to be repeated, until fewer rows than the batch size
+// are moved.
+func (b *Builder) MoveRowsOfDefaultPartition(parentTable, defaultPartition, key, value string, batchSize int) (string, []interface{}, error) {
+	if batchSize <= 0 {
+		return "", nil, fmt.Errorf("invalid batch size %d", batchSize)
+	}
+	stmt, err := b.executeListPartitionTemplate(moveRowsOfDefaultPartitionDDL, ListPartitionStatementData{
+		Schema:           b.GetDatabaseSchema(),
+		Partition:        b.GetListPartitionName(parentTable, value),
+		DefaultPartition: defaultPartition,
+		Key:              key,
+		BatchSize:        batchSize,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	stmt, err = b.GetPlaceholder().ReplacePlaceholders(stmt)
+	return stmt, []interface{}{value}, err
+}
+
+// GetDefaultPartition returns the (qualified) default partition of the parent table, or no row, if there is none.
+func (b *Builder) GetDefaultPartition(parentTable string) (string, []interface{}, error) {
+	return b.Build().
+		Select("partdefid::regclass::text").
+		From("pg_catalog.pg_partitioned_table").
+		Where("partrelid = ?::regclass AND partdefid <> 0", b.qualified(parentTable)).
+		ToSql()
+}
+
+// IsListPartitionAttached returns true, if the partition of the value is attached to the parent table.
+func (b *Builder) IsListPartitionAttached(parentTable, value string) (string, []interface{}, error) {
+	return b.Build().
+		Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM pg_catalog.pg_inherits WHERE inhrelid = to_regclass(?) AND inhparent = ?::regclass)",
+			b.qualified(b.GetListPartitionName(parentTable, value)), b.qualified(parentTable))).
+		ToSql()
+}
+
+// IsPartitioned returns true, if the (partition) table is partitioned itself.
+func (b *Builder) IsPartitioned(table string) (string, []interface{}, error) {
+	return b.Build().
+		Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM pg_catalog.pg_partitioned_table WHERE partrelid = to_regclass(?))", b.qualified(table))).
+		ToSql()
+}
+
+// GetLeafPartitions returns all leaf partitions of the parent table with their bounds and the bounds of their parents,
+// their estimated number of rows and their total size in bytes.
+func (b *Builder) GetLeafPartitions(parentTable string) (string, []interface{}, error) {
+	return b.Build().
+		Select(
+			"child.relname AS partition",
+			"tree.level AS level",
+			"COALESCE(pg_get_expr(child.relpartbound, child.oid), '') AS bound",
+			"COALESCE(pg_get_expr(parent.relpartbound, parent.oid), '') AS parent_bound",
+			"GREATEST(child.reltuples, 0)::bigint AS rows",
+			"pg_total_relation_size(tree.relid) AS bytes",
+		).
+		From(fmt.Sprintf("pg_partition_tree('%s'::regclass) AS tree", strings.ReplaceAll(b.qualified(parentTable), "'", "''"))).
+		Join("pg_catalog.pg_class AS child ON child.oid = tree.relid").
+		Join("pg_catalog.pg_class AS parent ON parent.oid = tree.parentrelid").
+		Where("tree.isleaf").
+		OrderBy("partition").
+		ToSql()
+}
+
+func (b *Builder) qualified(table string) string {
+	return fmt.Sprintf(`"%s"."%s"`, b.GetDatabaseSchema(), table)
+}
+
+func (b *Builder) executeListPartitionTemplate(tmpl string, data ListPartitionStatementData) (string, error) {
+	var buf bytes.Buffer
+	if err := b.Templates.ExecuteTemplate(&buf, tmpl, data); err != nil {
+		return "", fmt.Errorf("error executing template %s: %w", tmpl, err)
+	}
+	return buf.String(), nil
+}
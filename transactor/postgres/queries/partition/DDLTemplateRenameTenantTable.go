@@ -12,6 +12,18 @@ type RenameTenantTableToRebuildTableStatementData struct {
 	RebuildTable string
 }
 
+func (b *Builder) RenameShadowTableToTenantTable(tenantID, parentTable string) (statement string, err error) {
+	cleanID := NotAllowedCharacters.ReplaceAllString(tenantID, "")
+	var buf bytes.Buffer
+	if err = b.Templates.ExecuteTemplate(&buf, renameTenantTableToRebuildTableDDL, RenameTenantTableToRebuildTableStatementData{
+		TenantTable:  b.GetShadowTableName(cleanID, parentTable),
+		RebuildTable: b.GetTenantTableName(cleanID, parentTable),
+	}); err != nil {
+		return "", fmt.Errorf("error executing template %s: %w", renameTenantTableToRebuildTableDDL, err)
+	}
+	return buf.String(), nil
+}
+
 func (b *Builder) RenameTenantTableToRebuildTable(tenantID, parentTable string) (statement string, err error) {
 	var buf bytes.Buffer
 	if err = b.Templates.ExecuteTemplate(&buf, renameTenantTableToRebuildTableDDL, RenameTenantTableToRebuildTableStatementData{
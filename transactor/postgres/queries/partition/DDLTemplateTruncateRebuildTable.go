@@ -11,6 +11,17 @@ type TruncateRebuildTableStatementData struct {
 	RebuildTable string
 }
 
+func (b *Builder) TruncateShadowTable(tenantID, parentTable string) (statement string, err error) {
+	cleanID := NotAllowedCharacters.ReplaceAllString(tenantID, "")
+	var buf bytes.Buffer
+	if err = b.Templates.ExecuteTemplate(&buf, truncateRebuildTableDDL, TruncateRebuildTableStatementData{
+		RebuildTable: b.GetShadowTableName(cleanID, parentTable),
+	}); err != nil {
+		return "", fmt.Errorf("error executing template %s: %w", truncateRebuildTableDDL, err)
+	}
+	return buf.String(), nil
+}
+
 func (b *Builder) TruncateTenantTable(tenantID, parentTable string) (statement string, err error) {
 	var buf bytes.Buffer
 	if err = b.Templates.ExecuteTemplate(&buf, truncateRebuildTableDDL, TruncateRebuildTableStatementData{